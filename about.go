@@ -0,0 +1,103 @@
+package fisk
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// AboutInfo describes the application for the command added by WithAboutCommand()
+type AboutInfo struct {
+	// Author defaults to the value set using Author()
+	Author string
+	// Homepage is the URL of the project
+	Homepage string
+	// License is the license the application is distributed under, like Apache-2.0
+	License string
+	// Notices holds third party license notices, one file per dependency
+	// named after the dependency, typically an embed.FS
+	Notices fs.FS
+}
+
+// WithAboutCommand adds an about command showing the author, homepage and
+// license of the application and listing the third party notices in
+// info.Notices, the notices are shown in full using --licenses
+func (a *Application) WithAboutCommand(info AboutInfo) *Application {
+	var licenses bool
+
+	a.AboutCommand = a.Commandf("about", "Shows information about %s", a.Name).Action(func(_ *ParseContext) error {
+		return a.writeAbout(a.usageWriter, info, licenses)
+	})
+	if info.Notices != nil {
+		a.AboutCommand.Flag("licenses", "Shows the full third party license notices").UnNegatableBoolVar(&licenses)
+	}
+
+	return a
+}
+
+func (a *Application) writeAbout(w io.Writer, info AboutInfo, licenses bool) error {
+	if info.Author == "" {
+		info.Author = a.author
+	}
+
+	fmt.Fprintln(w, a.Name)
+	if a.Help != "" {
+		fmt.Fprintf(w, "\n%s\n", a.Help)
+	}
+	fmt.Fprintln(w)
+
+	for _, field := range [][2]string{{"Version", a.version}, {"Author", info.Author}, {"Homepage", info.Homepage}, {"License", info.License}} {
+		if field[1] != "" {
+			fmt.Fprintf(w, "%10s: %s\n", field[0], field[1])
+		}
+	}
+
+	if info.Notices == nil {
+		return nil
+	}
+
+	var notices []string
+	err := fs.WalkDir(info.Notices, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			notices = append(notices, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not read license notices: %w", err)
+	}
+	if len(notices) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Third party notices:")
+	fmt.Fprintln(w)
+
+	for _, notice := range notices {
+		name := strings.TrimSuffix(notice, path.Ext(notice))
+		if !licenses {
+			fmt.Fprintf(w, "  %s\n", name)
+			continue
+		}
+
+		body, err := fs.ReadFile(info.Notices, notice)
+		if err != nil {
+			return fmt.Errorf("could not read license notice %s: %w", notice, err)
+		}
+
+		fmt.Fprintf(w, "%s\n%s\n\n%s\n\n", name, strings.Repeat("-", len(name)), strings.TrimSpace(string(body)))
+	}
+
+	if !licenses {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "Use '%s about --licenses' to show the full notices\n", a.Name)
+	}
+
+	return nil
+}
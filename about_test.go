@@ -0,0 +1,52 @@
+package fisk
+
+import (
+	"bytes"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAboutCommand(t *testing.T) {
+	notices := fstest.MapFS{
+		"github.com/example/yaml.txt": {Data: []byte("MIT License\n\nCopyright Example\n")},
+		"golang.org/x/text.txt":       {Data: []byte("BSD 3-Clause\n")},
+	}
+
+	w := &bytes.Buffer{}
+	app := New("tool", "A test tool").Author("Example Inc").Version("1.0.0").WithAboutCommand(AboutInfo{
+		Homepage: "https://example.net",
+		License:  "Apache-2.0",
+		Notices:  notices,
+	})
+	app.UsageWriter(w).Terminate(nil)
+
+	_, err := app.Parse([]string{"about"})
+	assert.NoError(t, err)
+	assert.Equal(t, `tool
+
+A test tool
+
+   Version: 1.0.0
+    Author: Example Inc
+  Homepage: https://example.net
+   License: Apache-2.0
+
+Third party notices:
+
+  github.com/example/yaml
+  golang.org/x/text
+
+Use 'tool about --licenses' to show the full notices
+`, w.String())
+
+	w.Reset()
+	_, err = app.Parse([]string{"about", "--licenses"})
+	assert.NoError(t, err)
+	assert.Contains(t, w.String(), "github.com/example/yaml\n-----------------------\n\nMIT License\n\nCopyright Example\n\n")
+	assert.Contains(t, w.String(), "golang.org/x/text\n-----------------\n\nBSD 3-Clause\n\n")
+
+	app = New("tool", "").WithAboutCommand(AboutInfo{License: "MIT"})
+	assert.Nil(t, app.AboutCommand.GetFlag("licenses"))
+}
@@ -0,0 +1,133 @@
+package fisk
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// HelpStyles are usage templates that can be selected by users by setting the
+// FISK_HELP_STYLE environment variable to their name, overriding the template
+// set using UsageTemplate() and ErrorUsageTemplate()
+var HelpStyles = map[string]string{
+	"accessible": AccessibleUsageTemplate,
+}
+
+// styledTemplate is the template of the style selected using FISK_HELP_STYLE, tmpl otherwise
+func styledTemplate(tmpl string) string {
+	if style, ok := HelpStyles[os.Getenv("FISK_HELP_STYLE")]; ok {
+		return style
+	}
+
+	return tmpl
+}
+
+// takesValue describes the value placeholder p like "takes a URL"
+func takesValue(p string) string {
+	if strings.HasPrefix(p, "{") && strings.HasSuffix(p, "}") {
+		return "takes one of " + strings.ReplaceAll(strings.Trim(p, "{}"), "|", ", ")
+	}
+
+	if strings.ContainsAny(p[:1], "AEIOaeio") {
+		return "takes an " + p
+	}
+
+	return "takes a " + p
+}
+
+// describeFlag describes flag in a single line without alignment or symbols
+// for screen readers, for example "Flag --server, takes a URL, default
+// 127.0.0.1: server address"
+func describeFlag(flag *FlagModel) string {
+	parts := []string{"Flag --" + flag.Name}
+
+	if flag.IsNegatable() {
+		parts = append(parts, "negate using --no-"+flag.Name)
+	}
+
+	var shorts []string
+	for _, short := range append([]rune{flag.Short}, flag.ShortAliases...) {
+		if short != 0 {
+			shorts = append(shorts, "-"+string(short))
+		}
+	}
+	if len(shorts) > 0 {
+		parts = append(parts, "short "+strings.Join(shorts, " or "))
+	}
+
+	if !flag.IsBoolFlag() {
+		placeholder := flag.PlaceHolder
+		if placeholder == "" {
+			placeholder = typePlaceHolder(flag.Value)
+		}
+		if placeholder == "" {
+			placeholder = strings.ToUpper(flag.Name)
+		}
+		parts = append(parts, takesValue(placeholder))
+	}
+
+	if flag.Required {
+		parts = append(parts, "required")
+	}
+	if flag.IsCumulative() {
+		parts = append(parts, "may be repeated")
+	}
+	if len(flag.Default) > 0 && !flag.Secret {
+		parts = append(parts, "default "+strings.Join(flag.Default, " and "))
+	}
+	if flag.Envar != "" {
+		parts = append(parts, "environment variable "+flag.Envar)
+	}
+
+	return describe(parts, flag.Help+flag.Annotations())
+}
+
+// describeArg is like describeFlag for arguments
+func describeArg(arg *ArgModel) string {
+	parts := []string{"Argument " + arg.Name}
+
+	if arg.PlaceHolder != "" {
+		parts = append(parts, takesValue(arg.PlaceHolder))
+	} else if p := typePlaceHolder(arg.Value); p != "" {
+		parts = append(parts, takesValue(p))
+	}
+
+	if arg.Required {
+		parts = append(parts, "required")
+	} else {
+		parts = append(parts, "optional")
+	}
+	if arg.IsCumulative() {
+		parts = append(parts, "may be repeated")
+	}
+	if len(arg.Default) > 0 && !arg.Secret {
+		parts = append(parts, "default "+strings.Join(arg.Default, " and "))
+	}
+	if arg.Envar != "" {
+		parts = append(parts, "environment variable "+arg.Envar)
+	}
+
+	return describe(parts, arg.Help)
+}
+
+// describeCommand is like describeFlag for commands
+func describeCommand(cmd *CmdModel) string {
+	parts := []string{"Command " + cmd.FullCommand}
+
+	if len(cmd.Aliases) > 0 {
+		parts = append(parts, "alias "+strings.Join(cmd.Aliases, " or "))
+	}
+	if cmd.Default {
+		parts = append(parts, "default")
+	}
+
+	return describe(parts, strings.SplitN(cmd.Help, "\n", 2)[0]+cmd.Annotations())
+}
+
+func describe(parts []string, help string) string {
+	if help == "" {
+		return strings.Join(parts, ", ")
+	}
+
+	return fmt.Sprintf("%s: %s", strings.Join(parts, ", "), help)
+}
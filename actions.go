@@ -5,14 +5,30 @@ package fisk
 // actions.
 type Action func(*ParseContext) error
 
+// Action priorities for use with Priority(), actions with a lower priority run first
+const (
+	// PrioritySetup runs actions before all others, for example to load configuration
+	PrioritySetup = -100
+	// PriorityMain is the default priority
+	PriorityMain = 0
+	// PriorityTeardown runs actions after all others
+	PriorityTeardown = 100
+)
+
 type actionMixin struct {
 	actions    []Action
 	preActions []Action
+	priority   int
 }
 
 type actionApplier interface {
-	applyActions(*ParseContext) error
-	applyPreActions(*ParseContext) error
+	applyActions(*ParseContext, interface{}) error
+	applyPreActions(*ParseContext, interface{}) error
+	actionPriority() int
+}
+
+func (a *actionMixin) actionPriority() int {
+	return a.priority
 }
 
 func (a *actionMixin) addAction(action Action) {
@@ -23,18 +39,21 @@ func (a *actionMixin) addPreAction(action Action) {
 	a.preActions = append(a.preActions, action)
 }
 
-func (a *actionMixin) applyActions(context *ParseContext) error {
-	for _, action := range a.actions {
-		if err := action(context); err != nil {
-			return err
-		}
-	}
-	return nil
+// applyActions runs the actions, owner is the clause the actions belong to
+func (a *actionMixin) applyActions(context *ParseContext, owner interface{}) error {
+	return a.runActions(context, owner, a.actions, false)
+}
+
+// applyPreActions runs the pre actions, owner is the clause the actions belong to
+func (a *actionMixin) applyPreActions(context *ParseContext, owner interface{}) error {
+	return a.runActions(context, owner, a.preActions, true)
 }
 
-func (a *actionMixin) applyPreActions(context *ParseContext) error {
-	for _, preAction := range a.preActions {
-		if err := preAction(context); err != nil {
+func (a *actionMixin) runActions(context *ParseContext, owner interface{}, actions []Action, pre bool) error {
+	for _, action := range actions {
+		err := action(context)
+		context.traceAction(owner, pre, err)
+		if err != nil {
 			return err
 		}
 	}
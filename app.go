@@ -5,12 +5,16 @@ import (
 	"fmt"
 	"io"
 	"io/fs"
+	"log/slog"
+	"net/http"
 	"os"
 	"path/filepath"
 	"regexp"
 	"sort"
 	"strings"
+	"sync"
 	"text/template"
+	"time"
 )
 
 var (
@@ -23,11 +27,15 @@ type ApplicationValidator func(*Application) error
 // OptionValidator can be used to validate individual flags or arguments during parsing
 type OptionValidator func(string) error
 
+// CommandRunHook is called after a command was executed with the names of the flags the user set
+type CommandRunHook func(cmdPath string, flagsSetByUser []string, err error, took time.Duration)
+
 // An Application contains the definitions of flags, arguments and commands
 // for an application.
 type Application struct {
 	cmdMixin
 	initialized bool
+	initMu      sync.Mutex
 
 	Name string
 	Help string
@@ -42,12 +50,61 @@ type Application struct {
 	validator          ApplicationValidator
 	terminate          func(status int) // See Terminate()
 	noInterspersed     bool             // can flags be interspersed with args (or must they come first)
+	argsAndCommands    bool             // top-level args are allowed alongside commands
 	defaultEnvars      bool
 	completion         bool
 	introspect         bool
+	introspectFlag     *FlagClause
+	introspectEnvar    string
+	introspectSecrets  bool
+	introspectPretty   bool
+	debugParse         bool
+	exportEnv          bool
+	exportSecrets      bool
 	cheats             map[string]string
+	shortcuts          map[string]string
 	cheatTags          []string
 	helpFlagIsSet      bool
+	autoConfirm        bool
+	inShell            bool
+	commandPicker      bool
+	promptMissing      bool
+	verbosityFlags     bool
+	verbose            int
+	quiet              bool
+	outputFormat       string
+	color              bool
+	colorSet           bool
+	signals            []os.Signal
+	timeout            time.Duration
+	logger             *slog.Logger
+	logLevel           *slog.LevelVar
+	runHooks           []CommandRunHook
+	exitHooks          []func(code int)
+	exiting            bool
+	tracers            []func(ev ParseEvent)
+	lastContext        *ParseContext
+	model              *ApplicationModel // Cached by Model() until the application changes
+	modelMu            sync.Mutex
+	terminalWidth      int
+	terminal           Terminal
+	pluginExecutor     PluginExecutor
+	remoteClient       *http.Client // Used by RemoteCommands(), set in tests
+	remoteTTL          time.Duration
+	secretResolvers    map[string]SecretResolver
+	versionCommit      string
+	versionBuildDate   string
+	versionTemplate    string
+	versionDetails     map[string]string
+	versionFormat      string
+	versionFormatter   VersionFormatter
+	gateAlpha          bool
+	alphaEnabled       bool
+	whatsNewVersion    string
+	whatsNewText       string
+	noTips             bool
+	helpHeader         string
+	helpFooter         string
 
 	// Help flag. Exposed for user customisation.
 	HelpFlag *FlagClause
@@ -57,6 +114,22 @@ type Application struct {
 	VersionFlag *FlagClause
 	// Cheat command. Exposed for user customisation. May be nil.
 	CheatCommand *CmdClause
+	// Shell command. Exposed for user customisation. May be nil.
+	ShellCommand *CmdClause
+	// Version command. Exposed for user customisation. May be nil.
+	VersionCommand *CmdClause
+	// About command. Exposed for user customisation. May be nil.
+	AboutCommand *CmdClause
+	// Changelog command. Exposed for user customisation. May be nil.
+	ChangelogCommand *CmdClause
+	// Docs command. Exposed for user customisation. May be nil.
+	DocsCommand *CmdClause
+	// Stats command. Exposed for user customisation. May be nil.
+	StatsCommand *CmdClause
+	// Browse command. Exposed for user customisation. May be nil.
+	BrowseCommand *CmdClause
+	// Completion command. Exposed for user customisation. May be nil.
+	CompletionCommand *CmdClause
 }
 
 // Newf creates a new application with printf parsing of the help
@@ -73,13 +146,16 @@ func New(name, help string) *Application {
 		usageWriter:        os.Stderr,
 		usageTemplate:      CompactMainUsageTemplate,
 		errorUsageTemplate: CompactMainUsageTemplate,
-		terminate:          os.Exit,
 		cheats:             map[string]string{},
 		cheatTags:          []string{name},
+		logLevel:           new(slog.LevelVar),
 	}
 
+	a.terminate = a.withExitHooks(os.Exit)
 	a.flagGroup = newFlagGroup()
+	a.flagGroup.app = a
 	a.argGroup = newArgGroup()
+	a.argGroup.app = a
 	a.cmdGroup = newCmdGroup(a)
 	a.HelpFlag = a.Flag("help", "Show context-sensitive help").IsSetByUser(&a.helpFlagIsSet)
 	a.HelpFlag.UnNegatableBool()
@@ -90,7 +166,14 @@ func New(name, help string) *Application {
 	a.Flag("completion-bash", "Output possible completions for the given args.").Hidden().UnNegatableBoolVar(&a.completion)
 	a.Flag("completion-script-bash", "Generate completion script for bash.").Hidden().PreAction(a.generateBashCompletionScript).UnNegatableBool()
 	a.Flag("completion-script-zsh", "Generate completion script for ZSH.").Hidden().PreAction(a.generateZSHCompletionScript).UnNegatableBool()
-	a.Flag("fisk-introspect", "Introspect the application model").Hidden().Action(a.introspectAction).UnNegatableBoolVar(&a.introspect)
+	a.Flag("completion-script-fish", "Generate completion script for fish.").Hidden().PreAction(a.generateScript(FishCompletionTemplate)).UnNegatableBool()
+	a.Flag("completion-command-not-found-bash", "Generate a command not found handler for bash.").Hidden().PreAction(a.generateScript(BashCommandNotFoundTemplate)).UnNegatableBool()
+	a.Flag("completion-command-not-found-zsh", "Generate a command not found handler for ZSH.").Hidden().PreAction(a.generateScript(ZshCommandNotFoundTemplate)).UnNegatableBool()
+	a.introspectFlag = a.Flag("fisk-introspect", "Introspect the application model").Hidden().Action(a.introspectAction)
+	a.introspectFlag.UnNegatableBoolVar(&a.introspect)
+	a.Flag(debugParseFlag, "Dump the parsed tokens and values to stderr").Hidden().UnNegatableBoolVar(&a.debugParse)
+	a.Flag(exportEnvFlag, "Print the resolved flags as environment variable exports").Hidden().UnNegatableBoolVar(&a.exportEnv)
+	a.Flag(exportSecretsFlag, "Include secret values when exporting the environment").Hidden().UnNegatableBoolVar(&a.exportSecrets)
 
 	return a
 }
@@ -140,6 +223,17 @@ func (a *Application) generateZSHCompletionScript(c *ParseContext) error {
 	return nil
 }
 
+func (a *Application) generateScript(tmpl string) Action {
+	return func(c *ParseContext) error {
+		a.Writer(os.Stdout)
+		if err := a.UsageForContextWithTemplate(c, 2, tmpl); err != nil {
+			return err
+		}
+		a.terminate(0)
+		return nil
+	}
+}
+
 // DefaultEnvars configures all flags (that do not already have an associated
 // envar) to use a default environment variable in the form "<app>_<flag>".
 //
@@ -151,15 +245,40 @@ func (a *Application) DefaultEnvars() *Application {
 }
 
 // Terminate specifies the termination handler. Defaults to os.Exit(status).
+// Hooks added using OnExit() are called before it.
 // If nil is passed, a no-op function will be used.
 func (a *Application) Terminate(terminate func(int)) *Application {
 	if terminate == nil {
 		terminate = func(int) {}
 	}
-	a.terminate = terminate
+	a.terminate = a.withExitHooks(terminate)
+	return a
+}
+
+// OnExit adds a hook called with the exit code before the application
+// terminates from help, version, errors and other built-in paths, hooks are
+// called in the order they were added
+func (a *Application) OnExit(hook func(code int)) *Application {
+	a.exitHooks = append(a.exitHooks, hook)
 	return a
 }
 
+// withExitHooks wraps terminate to first call the hooks added using OnExit()
+func (a *Application) withExitHooks(terminate func(int)) func(int) {
+	return func(code int) {
+		// hooks terminating the application should not call the hooks again
+		if !a.exiting {
+			a.exiting = true
+			for _, hook := range a.exitHooks {
+				hook(code)
+			}
+			a.exiting = false
+		}
+
+		terminate(code)
+	}
+}
+
 // Writer specifies the writer to use for usage and errors. Defaults to os.Stderr.
 // DEPRECATED: See ErrorWriter and UsageWriter.
 func (a *Application) Writer(w io.Writer) *Application {
@@ -180,6 +299,33 @@ func (a *Application) UsageWriter(w io.Writer) *Application {
 	return a
 }
 
+// usageWriterFor is the usage writer of the command selected in context or
+// its closest parent that has one, the application's otherwise
+func (a *Application) usageWriterFor(context *ParseContext) io.Writer {
+	if context != nil {
+		for cmd := context.SelectedCommand; cmd != nil; cmd = cmd.parent {
+			if cmd.usageWriter != nil {
+				return cmd.usageWriter
+			}
+		}
+	}
+
+	return a.usageWriter
+}
+
+// errorWriterFor is like usageWriterFor for the error writer
+func (a *Application) errorWriterFor(context *ParseContext) io.Writer {
+	if context != nil {
+		for cmd := context.SelectedCommand; cmd != nil; cmd = cmd.parent {
+			if cmd.errorWriter != nil {
+				return cmd.errorWriter
+			}
+		}
+	}
+
+	return a.errorWriter
+}
+
 // UsageTemplate specifies the text template to use when displaying usage
 // information. The default is UsageTemplate.
 func (a *Application) UsageTemplate(template string) *Application {
@@ -195,6 +341,16 @@ func (a *Application) ErrorUsageTemplate(template string) *Application {
 	return a
 }
 
+// TerminalWidth sets the width usage is rendered at rather than detecting
+// it from the terminal, making help output reproducible.
+//
+// Without it the FISK_WIDTH and COLUMNS environment variables are honored,
+// in that order, before asking the terminal.
+func (a *Application) TerminalWidth(width int) *Application {
+	a.terminalWidth = width
+	return a
+}
+
 // UsageFuncs adds extra functions that can be used in the usage template.
 func (a *Application) UsageFuncs(funcs template.FuncMap) *Application {
 	a.usageFuncs = funcs
@@ -214,14 +370,22 @@ func (a *Application) ParseContext(args []string) (*ParseContext, error) {
 }
 
 func (a *Application) parseContext(ignoreDefault bool, args []string) (*ParseContext, error) {
-	if err := a.init(); err != nil {
+	if err := a.lockedInit(); err != nil {
 		return nil, err
 	}
 	context := tokenize(args, ignoreDefault)
+	context.app = a
+	context.recordTokens = !ignoreDefault && wantsParseDebug(args)
 	err := parse(context, a)
 	return context, err
 }
 
+// LastParseContext is the context of the most recent Parse(), nil when the
+// command line could not be tokenized or after Reset()
+func (a *Application) LastParseContext() *ParseContext {
+	return a.lastContext
+}
+
 // Parse parses command-line arguments. It returns the selected command and an
 // error. The selected command will be a space separated subcommand, if
 // subcommands have been configured.
@@ -229,6 +393,8 @@ func (a *Application) parseContext(ignoreDefault bool, args []string) (*ParseCon
 // This will populate all flag and argument values, call all callbacks, and so
 // on.
 func (a *Application) Parse(args []string) (command string, err error) {
+
+	args = a.trailingHelp(args)
 	context, parseErr := a.ParseContext(args)
 	var selected []string
 	var setValuesErr error
@@ -238,6 +404,21 @@ func (a *Application) Parse(args []string) (command string, err error) {
 		// where a context returns nil. Protect against that.
 		return "", parseErr
 	}
+	a.lastContext = context
+
+	// Completion only needs the structure so skips resolving values and running actions
+	if a.completionRequested(context) {
+		a.generateBashCompletion(context)
+		a.terminate(0)
+		return "", nil
+	}
+
+	// help for the deepest command that could be resolved when the rest of the line is invalid
+	if parseErr != nil && helpRequested(args) {
+		a.writeUsage(context.withoutDefaults(a), nil)
+		a.terminate(0)
+		return "", nil
+	}
 
 	if err = a.setDefaults(context); err != nil {
 		return "", err
@@ -249,14 +430,29 @@ func (a *Application) Parse(args []string) (command string, err error) {
 
 	selected, setValuesErr = a.setValues(context)
 
+	if a.debugParse {
+		a.writeParseDebug(a.errorWriter, context)
+	}
+
+	if a.exportEnv && parseErr == nil && setValuesErr == nil {
+		if err = a.writeEnvExport(a.usageWriter, context, a.exportSecrets); err != nil {
+			return "", err
+		}
+		a.terminate(0)
+		return "", nil
+	}
+
+	a.Logger().Debug("Parsed command line", "command", strings.Join(selected, " "), "error", parseErr)
+
+	// pre actions and actions share the context canceled by signals and --timeout
+	done := a.prepareContext(context)
+	defer done()
+
 	if err = a.applyPreActions(context, !a.completion); err != nil {
 		return "", err
 	}
 
-	if a.completion {
-		a.generateBashCompletion(context)
-		a.terminate(0)
-	} else if a.introspect {
+	if a.introspect {
 		a.introspectAction(context)
 		a.terminate(0)
 	} else {
@@ -273,8 +469,13 @@ func (a *Application) Parse(args []string) (command string, err error) {
 			return "", setValuesErr
 		}
 
+		start := time.Now()
 		command, err = a.execute(context, selected)
+		a.callRunHooks(context, selected, err, time.Since(start))
 		if err == ErrCommandNotSpecified {
+			if picked, ok, perr := a.maybePickCommand(context); ok || perr != nil {
+				return picked, perr
+			}
 			a.writeUsage(context, nil)
 		}
 	}
@@ -282,6 +483,32 @@ func (a *Application) Parse(args []string) (command string, err error) {
 	return command, err
 }
 
+// OnCommandRun registers a hook that is called after the selected command was
+// executed, this can be used to gather usage metrics
+func (a *Application) OnCommandRun(hook CommandRunHook) *Application {
+	a.runHooks = append(a.runHooks, hook)
+	return a
+}
+
+func (a *Application) callRunHooks(context *ParseContext, selected []string, err error, took time.Duration) {
+	if len(a.runHooks) == 0 {
+		return
+	}
+
+	seen := map[string]bool{}
+	flags := []string{}
+	for _, element := range context.Elements {
+		if flag, ok := element.Clause.(*FlagClause); ok && !seen[flag.name] {
+			seen[flag.name] = true
+			flags = append(flags, flag.name)
+		}
+	}
+
+	for _, hook := range a.runHooks {
+		hook(strings.Join(selected, " "), flags, err, took)
+	}
+}
+
 func (a *Application) writeUsage(context *ParseContext, err error) {
 	if err != nil {
 		a.Errorf("%s", err)
@@ -296,12 +523,50 @@ func (a *Application) writeUsage(context *ParseContext, err error) {
 	}
 }
 
+// trailingHelp turns a final help word into --help so help can be appended to
+// any command line, like "stream help", a help that is the value of an argument
+// or flag, or that follows --, is kept as is
+func (a *Application) trailingHelp(args []string) []string {
+	if len(args) < 2 || args[len(args)-1] != "help" || !a.cmdGroup.have() {
+		return args
+	}
+
+	for _, arg := range args[:len(args)-1] {
+		if arg == "--" {
+			return args
+		}
+	}
+
+	// only rewrite when help is unexpected, it might be the value of a flag like --name help or an argument
+	if context, _ := a.parseContext(true, args); context != nil && len(context.Elements) > 0 {
+		last := context.Elements[len(context.Elements)-1]
+		if last.Value != nil && *last.Value == "help" {
+			return args
+		}
+	}
+
+	return append(append([]string{}, args[:len(args)-1]...), "--help")
+}
+
+// helpRequested determines if --help appears in args before any --
+func helpRequested(args []string) bool {
+	for _, arg := range args {
+		switch arg {
+		case "--":
+			return false
+		case "--help":
+			return true
+		}
+	}
+
+	return false
+}
+
 func (a *Application) maybeHelp(context *ParseContext) {
 	for _, element := range context.Elements {
 		if flag, ok := element.Clause.(*FlagClause); ok && flag == a.HelpFlag {
-			// Re-parse the command-line ignoring defaults, so that help works correctly.
-			context, _ = a.parseContext(true, context.rawArgs)
-			a.writeUsage(context, nil)
+			// Show help for the command line as given, ignoring default commands.
+			a.writeUsage(context.withoutDefaults(a), nil)
 		}
 	}
 }
@@ -383,6 +648,7 @@ func (a *Application) saveCheats(dir string) error {
 func (a *Application) WithCheats(tags ...string) *Application {
 	if len(tags) > 0 {
 		a.cheatTags = tags
+		a.modelChanged()
 	}
 
 	var (
@@ -469,21 +735,41 @@ func (a *Application) Cheat(cheat string, help string) *Application {
 	return a
 }
 
-// Version adds a --version flag for displaying the application version.
+// Version adds a --version flag for displaying the application version,
+// --version=json shows VersionInfo() as JSON, see VersionFormatter().
 func (a *Application) Version(version string) *Application {
 	a.version = version
+	a.modelChanged()
 	a.VersionFlag = a.Flag("version", "Show application version.").PreAction(func(*ParseContext) error {
-		fmt.Fprintln(a.usageWriter, version)
+		err := a.formatVersion(a.versionFormat, true)
+		if err != nil {
+			return err
+		}
 		a.terminate(0)
 		return nil
 	})
-	a.VersionFlag.UnNegatableBool()
+	a.VersionFlag.SetValue(&versionFormatValue{app: a, format: &a.versionFormat})
+	return a
+}
+
+// HelpHeader is shown before the usage in all help
+func (a *Application) HelpHeader(header string) *Application {
+	a.helpHeader = header
+	a.modelChanged()
+	return a
+}
+
+// HelpFooter is shown at the end of all help, after the footer of the selected command
+func (a *Application) HelpFooter(footer string) *Application {
+	a.helpFooter = footer
+	a.modelChanged()
 	return a
 }
 
 // Author sets the author output by some help templates.
 func (a *Application) Author(author string) *Application {
 	a.author = author
+	a.modelChanged()
 	return a
 }
 
@@ -503,6 +789,14 @@ func (a *Application) PreAction(action Action) *Application {
 	return a
 }
 
+// Priority sets the order the actions and pre actions of this application run in relative
+// to those of other clauses, lower priorities run first, see PrioritySetup,
+// PriorityMain and PriorityTeardown
+func (a *Application) Priority(priority int) *Application {
+	a.priority = priority
+	return a
+}
+
 // Commandf adds a new top-level command with printf parsing of help
 func (a *Application) Commandf(name string, format string, arg ...interface{}) *CmdClause {
 	return a.Command(name, fmt.Sprintf(format, arg...))
@@ -521,6 +815,31 @@ func (a *Application) Interspersed(interspersed bool) *Application {
 	return a
 }
 
+// AllowArgsAndCommands allows top-level arguments alongside commands, when the
+// first argument is not a command it and those that follow are parsed as the
+// top-level arguments, like "tool <url>" next to "tool clone <url>"
+func (a *Application) AllowArgsAndCommands() *Application {
+	a.argsAndCommands = true
+	return a
+}
+
+// commandRequired determines if parsing context without selecting a command is an error
+func (a *Application) commandRequired(context *ParseContext) bool {
+	if !a.cmdGroup.have() {
+		return false
+	}
+
+	if a.argsAndCommands && context != nil {
+		for _, element := range context.Elements {
+			if _, ok := element.Clause.(*ArgClause); ok {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
 func (a *Application) defaultEnvarPrefix() string {
 	if a.defaultEnvars {
 		return a.Name
@@ -528,28 +847,55 @@ func (a *Application) defaultEnvarPrefix() string {
 	return ""
 }
 
+// lockedInit initializes the application once even when parsed from many goroutines
+func (a *Application) lockedInit() error {
+	a.initMu.Lock()
+	defer a.initMu.Unlock()
+
+	return a.init()
+}
+
 func (a *Application) init() error {
 	if a.initialized {
 		return nil
 	}
-	if a.cmdGroup.have() && a.argGroup.have() {
+	if a.cmdGroup.have() && a.argGroup.have() && !a.argsAndCommands {
 		return fmt.Errorf("can't mix top-level Arg()s with Command()s")
 	}
 
 	// If we have subcommands, add a help command at the top-level.
 	if a.cmdGroup.have() {
-		var command []string
+		var (
+			command []string
+			search  string
+		)
 		a.HelpCommand = a.Command("help", "Show help.").PreAction(func(context *ParseContext) error {
+			if search != "" {
+				matches, err := a.SearchHelp(search)
+				if err != nil {
+					return err
+				}
+				writeHelpSearch(a.usageWriter, search, matches)
+				a.terminate(0)
+				return nil
+			}
+
 			a.Usage(command)
 			a.terminate(0)
 			return nil
 		})
 		a.HelpCommand.Arg("command", "Show help on command.").StringsVar(&command)
+		a.HelpCommand.Flag("search", "Search the help of all commands").PlaceHolder("TERM").StringVar(&search)
 		// Make help first command.
 		l := len(a.commandOrder)
 		a.commandOrder = append(a.commandOrder[l-1:l], a.commandOrder[:l-1]...)
 	}
 
+	if a.introspectFlag != nil && a.introspectEnvar != "" && os.Getenv(a.introspectEnvar) == "" {
+		a.flagGroup.removeFlag(a.introspectFlag.name)
+		a.introspectFlag = nil
+	}
+
 	if err := a.flagGroup.init(a.defaultEnvarPrefix()); err != nil {
 		return err
 	}
@@ -564,44 +910,50 @@ func (a *Application) init() error {
 			return err
 		}
 	}
-	flagGroups := []*flagGroup{a.flagGroup}
+
+	// a single pass over the tree finds every duplicate flag
+	root, errs := mergeFlagGroups(newFlagGroup(), a.flagGroup)
 	for _, cmd := range a.commandOrder {
-		if err := checkDuplicateFlags(cmd, flagGroups); err != nil {
-			return err
-		}
+		errs = append(errs, cmd.buildFlagLookup(root))
 	}
-	a.initialized = true
-	return nil
-}
-
-// Recursively check commands for duplicate flags.
-func checkDuplicateFlags(current *CmdClause, flagGroups []*flagGroup) error {
-	// Check for duplicates.
-	for _, flags := range flagGroups {
-		for _, flag := range current.flagOrder {
-			if flag.shorthand != 0 {
-				if _, ok := flags.short[string(flag.shorthand)]; ok {
-					return fmt.Errorf("duplicate short flag -%c", flag.shorthand)
-				}
-			}
-			if _, ok := flags.long[flag.name]; ok {
-				return fmt.Errorf("duplicate long flag --%s", flag.name)
-			}
-		}
+	if err := errors.Join(errs...); err != nil {
+		return err
 	}
-	flagGroups = append(flagGroups, current.flagGroup)
-	// Check subcommands.
-	for _, subcmd := range current.commandOrder {
-		if err := checkDuplicateFlags(subcmd, flagGroups); err != nil {
-			return err
-		}
+
+	if err := a.validateShortcuts(); err != nil {
+		return err
 	}
+
+	a.initialized = true
 	return nil
 }
 
 func (a *Application) execute(context *ParseContext, selected []string) (string, error) {
 	var err error
 
+	if err = a.validateStability(context); err != nil {
+		return "", err
+	}
+
+	a.warnDeprecated(context)
+	a.maybeShowWhatsNew()
+
+	if err = a.maybeRunWizard(context); err != nil {
+		return "", err
+	}
+
+	if err = a.promptPasswords(context); err != nil {
+		return "", err
+	}
+
+	if err = a.maybePromptMissing(context); err != nil {
+		return "", err
+	}
+
+	if err = a.maybePromptArgs(context); err != nil {
+		return "", err
+	}
+
 	if err = a.validateRequired(context); err != nil {
 		return "", err
 	}
@@ -610,12 +962,13 @@ func (a *Application) execute(context *ParseContext, selected []string) (string,
 		return "", err
 	}
 
-	if err = a.applyActions(context); err != nil {
+	err = a.applyActions(context)
+	if err != nil {
 		return "", err
 	}
 
 	command := strings.Join(selected, " ")
-	if command == "" && a.cmdGroup.have() {
+	if command == "" && a.commandRequired(context) {
 		return "", ErrCommandNotSpecified
 	}
 	return command, err
@@ -647,7 +1000,7 @@ func (a *Application) validateFlagsAndArgs(context *ParseContext) error {
 		if clause.validator != nil {
 			err := clause.validator(*flag.Value)
 			if err != nil {
-				return fmt.Errorf("%s: %w", clause.name, err)
+				return fmt.Errorf("%s: %w", clause.name, redactError(err, clause.secret, *flag.Value))
 			}
 		}
 	}
@@ -657,7 +1010,7 @@ func (a *Application) validateFlagsAndArgs(context *ParseContext) error {
 		if clause.validator != nil {
 			err := clause.validator(*arg.Value)
 			if err != nil {
-				return fmt.Errorf("%s: %w", clause.name, err)
+				return fmt.Errorf("%s: %w", clause.name, redactError(err, clause.secret, *arg.Value))
 			}
 		}
 	}
@@ -686,9 +1039,10 @@ func (a *Application) setDefaults(context *ParseContext) error {
 	// Check required flags and set defaults.
 	for _, flag := range context.flags.long {
 		if flagElements[flag.name] == nil {
-			if err := flag.setDefault(); err != nil {
+			if err := flag.setDefault(context); err != nil {
 				return err
 			}
+			context.traceDefault(flag, flag.envar, flag.HasEnvarValue(), flag.hasDefault(), flag.value, flag.secret)
 		}
 	}
 
@@ -697,6 +1051,7 @@ func (a *Application) setDefaults(context *ParseContext) error {
 			if err := arg.setDefault(); err != nil {
 				return err
 			}
+			context.traceDefault(arg, arg.envar, arg.HasEnvarValue(), arg.hasDefault(), arg.value, arg.secret)
 		}
 	}
 
@@ -760,14 +1115,14 @@ func (a *Application) setValues(context *ParseContext) (selected []string, err e
 					return nil, fmt.Errorf("flag '%s' %w", clause.name, ErrFlagCannotRepeat)
 				}
 			}
-			if err = clause.value.Set(*element.Value); err != nil {
-				return
+			if err = clause.set(context, *element.Value); err != nil {
+				return nil, redactError(err, clause.secret, *element.Value)
 			}
 			flagSet[clause.name] = struct{}{}
 
 		case *ArgClause:
-			if err = clause.value.Set(*element.Value); err != nil {
-				return
+			if err = clause.set(*element.Value); err != nil {
+				return nil, redactError(err, clause.secret, *element.Value)
 			}
 
 		case *CmdClause:
@@ -800,17 +1155,13 @@ func (a *Application) applyValidators(context *ParseContext) (err error) {
 }
 
 func (a *Application) applyPreActions(context *ParseContext, dispatch bool) error {
-	if err := a.actionMixin.applyPreActions(context); err != nil {
-		return err
+	if !dispatch {
+		return a.actionMixin.applyPreActions(context, a)
 	}
-	// Dispatch to actions.
-	if dispatch {
-		for _, element := range context.Elements {
-			if applier, ok := element.Clause.(actionApplier); ok {
-				if err := applier.applyPreActions(context); err != nil {
-					return err
-				}
-			}
+
+	for _, owner := range a.actionOwners(context) {
+		if err := owner.applier.applyPreActions(context, owner.clause); err != nil {
+			return err
 		}
 	}
 
@@ -818,23 +1169,39 @@ func (a *Application) applyPreActions(context *ParseContext, dispatch bool) erro
 }
 
 func (a *Application) applyActions(context *ParseContext) error {
-	if err := a.actionMixin.applyActions(context); err != nil {
-		return err
+	for _, owner := range a.actionOwners(context) {
+		if err := owner.applier.applyActions(context, owner.clause); err != nil {
+			return err
+		}
 	}
-	// Dispatch to actions.
+
+	return nil
+}
+
+type actionOwner struct {
+	clause  interface{}
+	applier actionApplier
+}
+
+// actionOwners is the application and all parsed clauses in the order their actions should run
+func (a *Application) actionOwners(context *ParseContext) []actionOwner {
+	owners := []actionOwner{{a, &a.actionMixin}}
 	for _, element := range context.Elements {
 		if applier, ok := element.Clause.(actionApplier); ok {
-			if err := applier.applyActions(context); err != nil {
-				return err
-			}
+			owners = append(owners, actionOwner{element.Clause, applier})
 		}
 	}
-	return nil
+
+	sort.SliceStable(owners, func(i, j int) bool {
+		return owners[i].applier.actionPriority() < owners[j].applier.actionPriority()
+	})
+
+	return owners
 }
 
 // Errorf prints an error message to w in the format "<appname>: error: <message>".
 func (a *Application) Errorf(format string, args ...interface{}) {
-	fmt.Fprintf(a.errorWriter, a.Name+": error: "+format+"\n", args...)
+	fmt.Fprintf(a.errorWriterFor(a.lastContext), a.Name+": error: "+format+"\n", args...)
 }
 
 // Fatalf writes a formatted error to w then terminates with exit status 1.
@@ -864,7 +1231,7 @@ func (a *Application) FatalUsageContext(context *ParseContext, format string, ar
 }
 
 // FatalIfError prints an error and exits if err is not nil. The error is printed
-// with the given formatted string, if any.
+// with the given formatted string, if any. Errors implementing ExitCoder set the exit code.
 func (a *Application) FatalIfError(err error, format string, args ...interface{}) {
 	if err == nil {
 		return
@@ -875,7 +1242,7 @@ func (a *Application) FatalIfError(err error, format string, args ...interface{}
 		prefix = fmt.Sprintf(format, args...) + ": "
 	}
 	a.Errorf(prefix+"%s", err)
-	a.terminate(1)
+	a.terminate(ExitCodeFor(err))
 }
 
 // MustParseWithUsage parses args using Parse() and shows usage on certain errors
@@ -889,35 +1256,10 @@ func (a *Application) FatalIfError(err error, format string, args ...interface{}
 // full help for that command showing available arguments and flags.
 //
 // All other errors just shows the error.
+//
+// See ParseOrExit() for further options.
 func (a *Application) MustParseWithUsage(args []string) (command string) {
-	cmd, err := a.Parse(args)
-	if err == nil {
-		return cmd
-	}
-
-	ut := a.usageTemplate
-
-	switch {
-	case errorIs(err, ErrSubCommandRequired):
-		fmt.Fprintf(a.errorWriter, "error: a subcommand from the list below is required, use --help for full help including flags and arguments\n\n")
-		ut = a.errorUsageTemplate
-
-	case errorIs(err, ErrExpectedKnownCommand):
-		fmt.Fprintf(a.errorWriter, "error: %v, use --help for full help including flags and arguments\n\n", err)
-		ut = a.errorUsageTemplate
-
-	case errorIs(err, ErrRequiredArgument, ErrRequiredFlag, ErrUnknownLongFlag, ErrUnknownShortFlag, ErrExpectedFlagArgument, ErrFlagCannotRepeat, ErrUnexpectedArgument, ErrDuplicateCommand):
-		fmt.Fprintf(a.errorWriter, "error: %v\n\n", err)
-
-	default:
-		a.Fatalf("%v", err)
-	}
-
-	pc, _ := a.parseContext(true, args)
-	a.UsageForContextWithTemplate(pc, 2, ut)
-	a.terminate(1)
-
-	return ""
+	return a.ParseOrExit(args, ParseOptions{UsageOnError: true})
 }
 
 func (a *Application) completionOptions(context *ParseContext) []string {
@@ -967,28 +1309,17 @@ func (a *Application) completionOptions(context *ParseContext) []string {
 			flagName = currArg[2:] // Strip the "--"
 		}
 
-		options, flagMatched, valueMatched := target.FlagCompletion(flagName, flagValue)
+		flags := a.flagGroup
+		if context.SelectedCommand != nil {
+			flags = context.SelectedCommand.flagsInScope()
+		}
+
+		options, _, valueMatched := flagCompletion(flags, flagName, flagValue)
 		if valueMatched {
 			// Value Matched. Show cmdCompletions
 			return target.CmdCompletion(context)
 		}
 
-		// Add top level flags if we're not at the top level and no match was found.
-		if context.SelectedCommand != nil && !flagMatched {
-			topOptions, topFlagMatched, topValueMatched := a.FlagCompletion(flagName, flagValue)
-			if topValueMatched {
-				// Value Matched. Back to cmdCompletions
-				return target.CmdCompletion(context)
-			}
-
-			if topFlagMatched {
-				// Top level had a flag which matched the input. Return its options.
-				options = topOptions
-			} else {
-				// Add top level flags
-				options = append(options, topOptions...)
-			}
-		}
 		return options
 	}
 
@@ -996,12 +1327,24 @@ func (a *Application) completionOptions(context *ParseContext) []string {
 	return target.CmdCompletion(context)
 }
 
+func (a *Application) completionRequested(context *ParseContext) bool {
+	for _, element := range context.Elements {
+		if flag, ok := element.Clause.(*FlagClause); ok && flag.name == "completion-bash" {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (a *Application) generateBashCompletion(context *ParseContext) {
 	options := a.completionOptions(context)
 	opt1String := strings.Join(options, "\n")
 
-	// Re-parse the command-line ignoring defaults to find what if we did not set defaults
-	context, _ = a.parseContext(true, context.rawArgs)
+	a.Logger().Debug("Generated completion options", "options", options)
+
+	// Find what the options would be if we did not select default commands
+	context = context.withoutDefaults(a)
 	opt2String := strings.Join(a.completionOptions(context), "\n")
 	if opt1String == "" {
 		fmt.Printf("%s", opt2String)
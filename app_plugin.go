@@ -1,7 +1,9 @@
 package fisk
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -19,17 +21,74 @@ type pluginDelegator struct {
 	proxyGlobals   []string
 	globalFlags    *flagGroup
 	flagsIsSet     map[string]*bool
+	secrets        map[string]bool
+	secretArgs     map[string]bool
 	parent         string
 	name           string
+	executor       PluginExecutor // overrides the executor of the application
+}
+
+// IntrospectionFlag renames the hidden --fisk-introspect flag, an empty name
+// removes it. Plugins built using fisk are introspected using the default name.
+func (a *Application) IntrospectionFlag(name string) *Application {
+	if a.introspectFlag == nil {
+		return a
+	}
+
+	a.flagGroup.removeFlag(a.introspectFlag.name)
+	if name == "" {
+		a.introspectFlag = nil
+		return a
+	}
+
+	a.introspectFlag.name = name
+	a.flagGroup.long[name] = a.introspectFlag
+	a.flagGroup.flagOrder = append(a.flagGroup.flagOrder, a.introspectFlag)
+
+	return a
+}
+
+// IntrospectionEnvar only enables the introspection flag when the environment
+// variable envar is set, for applications that consider their model sensitive
+func (a *Application) IntrospectionEnvar(envar string) *Application {
+	a.introspectEnvar = envar
+	return a
+}
+
+// IntrospectionOmitSecrets removes secret flags and arguments from the
+// introspection model rather than only removing their defaults
+func (a *Application) IntrospectionOmitSecrets() *Application {
+	a.introspectSecrets = true
+	return a
+}
+
+// IntrospectionPretty indents the introspection JSON
+func (a *Application) IntrospectionPretty() *Application {
+	a.introspectPretty = true
+	return a
+}
+
+// IntrospectionModel is the model shown by --fisk-introspect, built in flags
+// and commands are not included and defaults of secrets are removed
+func (a *Application) IntrospectionModel() (*ApplicationModel, error) {
+	if err := a.lockedInit(); err != nil {
+		return nil, err
+	}
+
+	return a.introspectModel(), nil
 }
 
 func (a *Application) introspectModel() *ApplicationModel {
-	model := a.Model()
+	// a fresh model as this one is modified
+	model := a.buildModel()
 	var nf []*FlagModel
 	for _, flag := range model.Flags {
 		if flag.Name == "help" || strings.HasPrefix(flag.Name, "help-") || strings.HasPrefix(flag.Name, "completion-") || strings.HasPrefix(flag.Name, "fisk-") || flag.Name == "version" {
 			continue
 		}
+		if a.introspectFlag != nil && flag.Name == a.introspectFlag.name {
+			continue
+		}
 
 		nf = append(nf, flag)
 	}
@@ -44,13 +103,60 @@ func (a *Application) introspectModel() *ApplicationModel {
 	}
 	model.Commands = nc
 
+	redactModelDefaults(model.FlagGroupModel, model.ArgGroupModel, model.CmdGroupModel, a.introspectSecrets)
+
 	return model
 }
 
+// redactModelDefaults removes the default values of secret flags and arguments, or the secrets entirely when omit is set
+func redactModelDefaults(flags *FlagGroupModel, args *ArgGroupModel, cmds *CmdGroupModel, omit bool) {
+	if flags != nil {
+		var kept []*FlagModel
+		for _, flag := range flags.Flags {
+			if flag.Secret {
+				if omit {
+					continue
+				}
+				flag.Default = nil
+			}
+			kept = append(kept, flag)
+		}
+		flags.Flags = kept
+	}
+
+	if args != nil {
+		var kept []*ArgModel
+		for _, arg := range args.Args {
+			if arg.Secret {
+				if omit {
+					continue
+				}
+				arg.Default = nil
+			}
+			kept = append(kept, arg)
+		}
+		args.Args = kept
+	}
+
+	if cmds != nil {
+		for _, cmd := range cmds.Commands {
+			redactModelDefaults(cmd.FlagGroupModel, cmd.ArgGroupModel, cmd.CmdGroupModel, omit)
+		}
+	}
+}
+
 func (a *Application) introspectAction(_ *ParseContext) error {
 	a.Writer(os.Stdout)
 
-	j, err := json.Marshal(a.introspectModel())
+	var (
+		j   []byte
+		err error
+	)
+	if a.introspectPretty {
+		j, err = json.MarshalIndent(a.introspectModel(), "", "  ")
+	} else {
+		j, err = json.Marshal(a.introspectModel())
+	}
 	if err != nil {
 		return err
 	}
@@ -74,6 +180,11 @@ func (c *CmdClause) addArgsFromModel(model *ArgGroupModel) {
 		a.hidden = arg.Hidden
 		a.defaultValues = arg.Default
 		a.envar = arg.Envar
+		a.secret = arg.Secret
+		a.group = arg.Group
+		if arg.Secret {
+			c.pluginDelegator.secretArgs[arg.Name] = true
+		}
 
 		switch {
 		case arg.Cumulative:
@@ -104,11 +215,22 @@ func (c *CmdClause) addFlagsFromModel(model *FlagGroupModel, appFlags *FlagGroup
 
 		f := c.Flag(flag.Name, flag.Help)
 		f.shorthand = flag.Short
+		f.shortAliases = flag.ShortAliases
 		f.defaultValues = flag.Default
 		f.envar = flag.Envar
 		f.placeholder = flag.PlaceHolder
 		f.required = flag.Required
 		f.hidden = flag.Hidden
+		f.helpLong = flag.HelpLong
+		f.category = flag.Category
+		f.group = flag.Group
+		f.stability = flag.Stability
+		f.deprecated = flag.Deprecated
+		f.removedIn = flag.RemovedIn
+		f.secret = flag.Secret
+		if flag.Secret {
+			c.pluginDelegator.secrets[flag.Name] = true
+		}
 
 		f.setByUser = c.pluginDelegator.flagsIsSet[flag.Name]
 
@@ -198,18 +320,106 @@ func (c *CmdClause) pluginAction(pd *pluginDelegator) Action {
 			}
 		}
 
-		if os.Getenv("FISK_DEBUG") != "" {
-			fmt.Printf("Fisk Plugin Running: %s %s\n", pd.command, strings.Join(args, " "))
-			fmt.Printf("PD: %#v\n", pd)
+		execution := &PluginExecution{
+			Command:      pd.command,
+			Args:         args,
+			RedactedArgs: pd.redactedArgs(args),
+			Env:          os.Environ(),
+		}
+
+		c.app.Logger().Debug("Running fisk plugin", "command", execution.Command, "args", execution.RedactedArgs)
+
+		executor := pd.executor
+		if executor == nil {
+			executor = c.app.pluginExecutorOrDefault()
+		}
+
+		return executor.ExecutePlugin(execution)
+	}
+}
+
+// PluginExecution is a single run of an external plugin
+type PluginExecution struct {
+	// Command is the plugin to run
+	Command string
+	// Args are the arguments delegated to the plugin
+	Args []string
+	// RedactedArgs are Args with the values of secret flags and arguments masked
+	RedactedArgs []string
+	// Env is the environment the plugin runs in
+	Env []string
+}
+
+// PluginExecutor runs the plugins added using ExternalPluginCommand()
+type PluginExecutor interface {
+	ExecutePlugin(execution *PluginExecution) error
+}
+
+// PluginExecutor sets the executor that runs external plugins, by default
+// they are run as child processes sharing stdin, stdout and stderr
+func (a *Application) PluginExecutor(executor PluginExecutor) *Application {
+	a.pluginExecutor = executor
+	return a
+}
+
+func (a *Application) pluginExecutorOrDefault() PluginExecutor {
+	if a.pluginExecutor != nil {
+		return a.pluginExecutor
+	}
+
+	return processPluginExecutor{}
+}
+
+type processPluginExecutor struct{}
+
+func (processPluginExecutor) ExecutePlugin(execution *PluginExecution) error {
+	cmd := exec.Command(execution.Command, execution.Args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+	cmd.Env = execution.Env
+	return cmd.Run()
+}
+
+// redactedArgs masks the values of secret flags and arguments for use in debug output
+func (pd *pluginDelegator) redactedArgs(args []string) []string {
+	secretValues := map[string]bool{}
+	for k, v := range pd.args {
+		if pd.secretArgs[k] && v != nil {
+			secretValues[*v] = true
+		}
+	}
+	for k, v := range pd.cumuArgs {
+		if pd.secretArgs[k] && v != nil {
+			for _, i := range *v {
+				secretValues[i] = true
+			}
+		}
+	}
+
+	var out []string
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "--") {
+			parts := strings.SplitN(arg[2:], "=", 2)
+			if len(parts) == 2 && pd.isSecret(parts[0]) {
+				arg = fmt.Sprintf("--%s=%s", parts[0], redactedValue)
+			}
+		} else if secretValues[arg] {
+			arg = redactedValue
 		}
+		out = append(out, arg)
+	}
+	return out
+}
 
-		cmd := exec.Command(pd.command, args...)
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-		cmd.Stdin = os.Stdin
-		cmd.Env = os.Environ()
-		return cmd.Run()
+func (pd *pluginDelegator) isSecret(flag string) bool {
+	if pd.secrets[flag] {
+		return true
+	}
+	if f, ok := pd.globalFlags.long[flag]; ok {
+		return f.secret
 	}
+	return false
 }
 
 func (c *CmdClause) addCommandsFromModel(model *CmdGroupModel) {
@@ -227,10 +437,13 @@ func (c *CmdClause) addCommandsFromModel(model *CmdGroupModel) {
 			cumuArgs:       map[string]*[]string{},
 			boolFlags:      map[string]*bool{},
 			unNegBoolFlags: map[string]*bool{},
-			flagsIsSet:     c.pluginDelegator.flagsIsSet,   // shared with global so global flags isSet is also handled
+			flagsIsSet:     c.pluginDelegator.flagsIsSet, // shared with global so global flags isSet is also handled
+			secrets:        c.pluginDelegator.secrets,    // shared with global so secret values are always masked
+			secretArgs:     map[string]bool{},
 			command:        c.pluginDelegator.command,      // the command to run is always the same
 			globalFlags:    c.pluginDelegator.globalFlags,  // global flags are global
 			proxyGlobals:   c.pluginDelegator.proxyGlobals, // global flags are global
+			executor:       c.pluginDelegator.executor,
 		}
 
 		cm := c.Command(cmd.Name, cmd.Help)
@@ -239,7 +452,14 @@ func (c *CmdClause) addCommandsFromModel(model *CmdGroupModel) {
 		cm.aliases = cmd.Aliases
 		cm.helpLong = cmd.HelpLong
 		cm.hidden = cmd.Hidden
+		cm.category = cmd.Category
+		cm.stability = cmd.Stability
+		cm.deprecated = cmd.Deprecated
+		cm.removedIn = cmd.RemovedIn
+		cm.helpFooter = cmd.HelpFooter
 		cm.isDefault = cmd.Default
+		cm.examples = cmd.Examples
+		cm.cheat = cmd.Cheat
 
 		if cmd.CmdGroupModel == nil || len(cmd.CmdGroupModel.Commands) == 0 {
 			cm.Action(cm.pluginAction(&pd))
@@ -251,19 +471,22 @@ func (c *CmdClause) addCommandsFromModel(model *CmdGroupModel) {
 	}
 }
 
-func (a *Application) registerPluginModel(command string, model *ApplicationModel) (*CmdClause, error) {
+func (a *Application) registerPluginModel(command string, model *ApplicationModel, executor PluginExecutor) (*CmdClause, error) {
 	cmd := a.Command(model.Name, model.Help)
 	cmd.pluginDelegator = &pluginDelegator{
 		parent:         a.Name,
 		command:        command,
 		flags:          map[string]*string{},
 		flagsIsSet:     map[string]*bool{},
+		secrets:        map[string]bool{},
+		secretArgs:     map[string]bool{},
 		cumuFlags:      map[string]*[]string{},
 		args:           map[string]*string{},
 		cumuArgs:       map[string]*[]string{},
 		boolFlags:      map[string]*bool{},
 		unNegBoolFlags: map[string]*bool{},
 		globalFlags:    a.flagGroup,
+		executor:       executor,
 	}
 
 	for k, v := range model.Cheats {
@@ -275,9 +498,10 @@ func (a *Application) registerPluginModel(command string, model *ApplicationMode
 		a.cheats[k] = v
 		a.cheatTags = append(a.cheatTags, k)
 	}
+	a.modelChanged()
 
 	cmd.addArgsFromModel(model.ArgGroupModel)
-	cmd.addFlagsFromModel(model.FlagGroupModel, a.Model().FlagGroupModel)
+	cmd.addFlagsFromModel(model.FlagGroupModel, a.sharedModel().FlagGroupModel)
 	cmd.addCommandsFromModel(model.CmdGroupModel)
 
 	return cmd, nil
@@ -285,10 +509,20 @@ func (a *Application) registerPluginModel(command string, model *ApplicationMode
 
 // ExternalPluginCommand extends the application using a plugin and a model describing the application, when name or help is not an empty string it will override that from the plugin
 func (a *Application) ExternalPluginCommand(command string, model json.RawMessage, name string, help string) (*CmdClause, error) {
+	return a.externalPluginCommand(command, model, name, help, nil)
+}
+
+func (a *Application) externalPluginCommand(command string, model json.RawMessage, name string, help string, executor PluginExecutor) (*CmdClause, error) {
 	var m ApplicationModel
-	err := json.Unmarshal(model, &m)
+	dec := json.NewDecoder(bytes.NewReader(model))
+	dec.DisallowUnknownFields()
+	err := dec.Decode(&m)
 	if err != nil {
-		return nil, err
+		var serr *json.SyntaxError
+		if errors.As(err, &serr) {
+			return nil, fmt.Errorf("invalid plugin model: %w at offset %d", err, serr.Offset)
+		}
+		return nil, fmt.Errorf("invalid plugin model: %w", err)
 	}
 
 	if name != "" {
@@ -305,5 +539,127 @@ func (a *Application) ExternalPluginCommand(command string, model json.RawMessag
 		return nil, fmt.Errorf("plugin declared no help")
 	}
 
-	return a.registerPluginModel(command, &m)
+	err = a.validatePluginModel(&m)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.registerPluginModel(command, &m, executor)
+}
+
+// validatePluginModel checks a plugin model for problems that would otherwise
+// only surface when parsing, errors name the offending command, flag or argument
+func (a *Application) validatePluginModel(m *ApplicationModel) error {
+	// flags shared with the host are proxied, only shorts of other flags conflict
+	shorts := map[rune]string{}
+	for _, flag := range a.flagGroup.flagOrder {
+		for _, short := range flag.shorts() {
+			shorts[short] = flag.name
+		}
+	}
+
+	var errs []error
+	validatePluginGroup(nil, m.FlagGroupModel, m.ArgGroupModel, m.CmdGroupModel, shorts, &errs)
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid plugin model %s: %w", m.Name, errors.Join(errs...))
+	}
+
+	return nil
+}
+
+func validatePluginGroup(path []string, flags *FlagGroupModel, args *ArgGroupModel, cmds *CmdGroupModel, inheritedShorts map[rune]string, errs *[]error) {
+	fail := func(name string, format string, a ...interface{}) {
+		*errs = append(*errs, fmt.Errorf("%s: %s", diffPath(path, name), fmt.Sprintf(format, a...)))
+	}
+
+	validStability := func(s Stability) bool {
+		return s == Stable || s == Beta || s == Alpha
+	}
+
+	shorts := map[rune]string{}
+	for k, v := range inheritedShorts {
+		shorts[k] = v
+	}
+
+	if flags != nil {
+		seen := map[string]bool{}
+		for _, flag := range flags.Flags {
+			switch {
+			case flag.Name == "":
+				fail("--", "flag has no name")
+				continue
+			case seen[flag.Name]:
+				fail("--"+flag.Name, "duplicate flag")
+			}
+			seen[flag.Name] = true
+
+			for _, short := range append([]rune{flag.Short}, flag.ShortAliases...) {
+				if short == 0 {
+					continue
+				}
+				if other, ok := shorts[short]; ok && other != flag.Name {
+					fail("--"+flag.Name, "short flag -%c conflicts with --%s", short, other)
+				}
+				shorts[short] = flag.Name
+			}
+			if !validStability(flag.Stability) {
+				fail("--"+flag.Name, "invalid stability %q", flag.Stability)
+			}
+		}
+	}
+
+	var haveArgs bool
+	if args != nil {
+		seen := map[string]bool{}
+		var optional, cumulative bool
+		for _, arg := range args.Args {
+			haveArgs = true
+			switch {
+			case arg.Name == "":
+				fail("<>", "argument has no name")
+				continue
+			case seen[arg.Name]:
+				fail("<"+arg.Name+">", "duplicate argument")
+			case cumulative:
+				fail("<"+arg.Name+">", "argument follows a cumulative argument")
+			case optional && arg.Required:
+				fail("<"+arg.Name+">", "required argument follows an optional argument")
+			}
+			seen[arg.Name] = true
+			optional = optional || !arg.Required
+			cumulative = cumulative || arg.Cumulative
+		}
+	}
+
+	if cmds == nil {
+		return
+	}
+
+	if haveArgs && len(cmds.Commands) > 0 {
+		where := strings.Join(path, " ")
+		if where == "" {
+			where = "plugin"
+		}
+		*errs = append(*errs, fmt.Errorf("%s: can't mix arguments and commands", where))
+	}
+
+	seen := map[string]bool{}
+	for _, cmd := range cmds.Commands {
+		if cmd.Name == "" {
+			fail("<unnamed>", "command has no name")
+			continue
+		}
+
+		for _, name := range append([]string{cmd.Name}, cmd.Aliases...) {
+			if seen[name] {
+				fail(cmd.Name, "duplicate command or alias %q", name)
+			}
+			seen[name] = true
+		}
+		if !validStability(cmd.Stability) {
+			fail(cmd.Name, "invalid stability %q", cmd.Stability)
+		}
+
+		validatePluginGroup(append(append([]string{}, path...), cmd.Name), cmd.FlagGroupModel, cmd.ArgGroupModel, cmd.CmdGroupModel, shorts, errs)
+	}
 }
@@ -0,0 +1,133 @@
+package fisk
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPluginSecretsAreInherited(t *testing.T) {
+	plugin := newTestApp()
+	plugin.Flag("token", "").Default("s3cret").Secret().String()
+	add := plugin.Command("add", "")
+	add.Arg("password", "").Secret().Default("hunter2").String()
+	add.Arg("name", "").String()
+
+	model := plugin.introspectModel()
+	assert.True(t, model.Flags[0].Secret)
+	assert.Empty(t, model.Flags[0].Default)
+	assert.Empty(t, model.Commands[0].Args[0].Default)
+
+	j, err := json.Marshal(model)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(j), "s3cret")
+	assert.NotContains(t, string(j), "hunter2")
+
+	host := newTestApp()
+	cmd, err := host.ExternalPluginCommand("/bin/true", j, "plugin", "A plugin")
+	assert.NoError(t, err)
+	assert.True(t, cmd.GetFlag("token").secret)
+
+	sub := cmd.GetCommand("add")
+	assert.True(t, sub.GetArg("password").secret)
+	assert.False(t, sub.GetArg("name").secret)
+
+	*sub.pluginDelegator.args["password"] = "hunter2"
+	*sub.pluginDelegator.args["name"] = "bob"
+	assert.Equal(t, []string{"add", "--token=*****", "*****", "bob"}, sub.pluginDelegator.redactedArgs([]string{"add", "--token=s3cret", "hunter2", "bob"}))
+}
+
+func TestIntrospectionOptions(t *testing.T) {
+	app := newTestApp().IntrospectionFlag("dump-model").IntrospectionOmitSecrets()
+	app.Flag("token", "").Secret().String()
+	app.Flag("name", "").String()
+	add := app.Command("add", "")
+	add.Arg("password", "").Secret().String()
+
+	_, err := app.Parse([]string{"add"})
+	assert.NoError(t, err)
+	assert.Nil(t, app.GetFlag("fisk-introspect"))
+	assert.NotNil(t, app.GetFlag("dump-model"))
+
+	model := app.introspectModel()
+	assert.Len(t, model.Flags, 1)
+	assert.Equal(t, "name", model.Flags[0].Name)
+	assert.Empty(t, model.Commands[0].Args)
+
+	t.Setenv("TEST_INTROSPECT", "")
+	app = newTestApp().IntrospectionEnvar("TEST_INTROSPECT")
+	_, err = app.Parse([]string{"--fisk-introspect"})
+	assert.Error(t, err)
+
+	t.Setenv("TEST_INTROSPECT", "1")
+	app = newTestApp().IntrospectionEnvar("TEST_INTROSPECT")
+	assert.NoError(t, app.init())
+	assert.NotNil(t, app.GetFlag("fisk-introspect"))
+}
+
+func TestPluginModelValidation(t *testing.T) {
+	host := newTestApp()
+	host.Flag("server", "").Short('s').String()
+
+	_, err := host.ExternalPluginCommand("/bin/true", []byte(`{"name":"p","help":"p","bogus":1}`), "", "")
+	assert.EqualError(t, err, `invalid plugin model: json: unknown field "bogus"`)
+
+	_, err = host.ExternalPluginCommand("/bin/true", []byte(`{"name":"p",`), "", "")
+	assert.ErrorContains(t, err, "invalid plugin model: unexpected EOF")
+
+	model := `{"name":"p","help":"p","flags":[{"name":"server","short":115},{"name":"subject","short":115}],
+		"commands":[
+			{"name":"add","stability":"gamma","args":[{"name":"a"},{"name":"b","required":true}]},
+			{"name":"ls","aliases":["add"],"flags":[{"name":"x"},{"name":"x"}]}
+		]}`
+	_, err = host.ExternalPluginCommand("/bin/true", []byte(model), "", "")
+	assert.EqualError(t, err, `invalid plugin model p: --subject: short flag -s conflicts with --server
+add: invalid stability "gamma"
+add <b>: required argument follows an optional argument
+ls: duplicate command or alias "add"
+ls --x: duplicate flag`)
+}
+
+type testPluginBackend struct {
+	model []byte
+	runs  []*PluginExecution
+}
+
+func (b *testPluginBackend) PluginModel() (json.RawMessage, error) {
+	if b.model == nil {
+		return nil, errors.New("no model")
+	}
+
+	return b.model, nil
+}
+
+func (b *testPluginBackend) ExecutePlugin(execution *PluginExecution) error {
+	b.runs = append(b.runs, execution)
+	return nil
+}
+
+func TestPluginCommand(t *testing.T) {
+	plugin := newTestApp()
+	plugin.Command("add", "").Arg("name", "").String()
+
+	j, err := json.Marshal(plugin.introspectModel())
+	assert.NoError(t, err)
+
+	host := newTestApp()
+	host.PluginExecutor(&testPluginBackend{})
+
+	_, err = host.PluginCommand("plugin.wasm", &testPluginBackend{}, "plugin", "A plugin")
+	assert.EqualError(t, err, "could not load plugin model from plugin.wasm: no model")
+
+	backend := &testPluginBackend{model: j}
+	_, err = host.PluginCommand("plugin.wasm", backend, "plugin", "A plugin")
+	assert.NoError(t, err)
+
+	_, err = host.Parse([]string{"plugin", "add", "bob"})
+	assert.NoError(t, err)
+	assert.Len(t, backend.runs, 1)
+	assert.Equal(t, "plugin.wasm", backend.runs[0].Command)
+	assert.Equal(t, []string{"add", "bob"}, backend.runs[0].Args)
+}
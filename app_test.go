@@ -645,3 +645,254 @@ func TestParseWithUsage(t *testing.T) {
 	assert.Contains(t, buf.String(), "flag 'thing' cannot be repeated")
 	assert.Contains(t, buf.String(), "Flags")
 }
+
+func TestParseOrExit(t *testing.T) {
+	var (
+		buf, appBuf bytes.Buffer
+		code        int
+	)
+
+	errCustom := errors.New("custom failure")
+
+	c := newTestApp().Terminate(func(c int) { code = c })
+	c.usageWriter = &appBuf
+	c.errorWriter = &appBuf
+	c.Command("fail", "").Action(func(_ *ParseContext) error { return fmt.Errorf("failed: %w", errCustom) })
+	c.Command("run", "").Flag("thing", "thing").Required().String()
+
+	opts := ParseOptions{Writer: &buf, ExitCodes: map[error]int{errCustom: 10}}
+
+	assert.Equal(t, "run", c.ParseOrExit([]string{"run", "--thing", "x"}, opts))
+	assert.Equal(t, 0, code)
+
+	c.ParseOrExit([]string{"fail"}, opts)
+	assert.Equal(t, "test: error: failed: custom failure, try --help\n", buf.String())
+	assert.Equal(t, 10, code)
+
+	buf.Reset()
+	c.ParseOrExit([]string{"run"}, opts)
+	assert.Contains(t, buf.String(), "required flag --thing not provided")
+	assert.NotContains(t, buf.String(), "Flags")
+	assert.Equal(t, 1, code)
+
+	buf.Reset()
+	opts.UsageOnError = true
+	c.ParseOrExit([]string{"run"}, opts)
+	assert.Contains(t, buf.String(), "required flag --thing not provided")
+	assert.Contains(t, buf.String(), "Flags")
+	assert.Equal(t, 1, code)
+
+	buf.Reset()
+	c.ParseOrExit([]string{"fail"}, opts)
+	assert.Equal(t, "test: error: failed: custom failure\n", buf.String())
+	assert.Equal(t, 10, code)
+	assert.Empty(t, appBuf.String())
+}
+
+func TestOnExit(t *testing.T) {
+	var (
+		calls []string
+		code  = -1
+	)
+
+	app := New("test", "").Terminate(func(c int) {
+		calls = append(calls, "terminate")
+		code = c
+	})
+	app.UsageWriter(&bytes.Buffer{}).ErrorWriter(&bytes.Buffer{})
+	app.Version("1.0.0")
+	app.OnExit(func(c int) { calls = append(calls, fmt.Sprintf("first %d", c)) })
+	app.OnExit(func(c int) {
+		calls = append(calls, fmt.Sprintf("second %d", c))
+		app.Fatalf("hook failed")
+	})
+
+	app.Parse([]string{"--version"})
+	assert.Equal(t, []string{"first 0", "second 0", "terminate", "terminate"}, calls)
+	assert.Equal(t, 0, code)
+
+	calls = nil
+	app.Fatalf("failed")
+	assert.Equal(t, []string{"first 1", "second 1", "terminate", "terminate"}, calls)
+	assert.Equal(t, 1, code)
+}
+
+func TestOnCommandRun(t *testing.T) {
+	var (
+		path  string
+		flags []string
+		rerr  error
+		took  time.Duration
+	)
+
+	app := newTestApp()
+	app.Flag("server", "").Default("localhost").String()
+	add := app.Command("stream", "").Command("add", "")
+	add.Flag("replicas", "").Int()
+	add.Arg("name", "").String()
+	add.Action(func(*ParseContext) error {
+		time.Sleep(10 * time.Millisecond)
+		return fmt.Errorf("failed")
+	})
+
+	app.OnCommandRun(func(cmdPath string, flagsSetByUser []string, err error, d time.Duration) {
+		path, flags, rerr, took = cmdPath, flagsSetByUser, err, d
+	})
+
+	_, err := app.Parse([]string{"stream", "add", "ORDERS", "--replicas", "3", "--server", "x", "--replicas", "3"})
+	assert.Error(t, err)
+
+	_, err = app.Parse([]string{"stream", "add", "ORDERS", "--replicas", "3", "--server", "x"})
+	assert.EqualError(t, err, "failed")
+	assert.Equal(t, "stream add", path)
+	assert.Equal(t, []string{"replicas", "server"}, flags)
+	assert.EqualError(t, rerr, "failed")
+	assert.GreaterOrEqual(t, took, 10*time.Millisecond)
+}
+
+func TestActionPriority(t *testing.T) {
+	var order []string
+	record := func(name string) Action {
+		return func(*ParseContext) error {
+			order = append(order, name)
+			return nil
+		}
+	}
+
+	app := newTestApp().PreAction(record("app"))
+	app.Flag("creds", "").PreAction(record("creds")).String()
+	app.Flag("config", "").PreAction(record("config")).Priority(PrioritySetup).String()
+	app.Flag("cleanup", "").PreAction(record("cleanup")).Priority(PriorityTeardown).Bool()
+
+	_, err := app.Parse([]string{"--cleanup", "--creds", "x", "--config", "y"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"config", "app", "creds", "cleanup"}, order)
+}
+
+func TestHelpIgnoresDefaultCommands(t *testing.T) {
+	help := func(args ...string) string {
+		t.Helper()
+
+		buf := &bytes.Buffer{}
+		app := newTestApp().UsageWriter(buf)
+		list := app.Command("list", "").Default()
+		list.Flag("long", "").Bool()
+		app.Command("add", "")
+
+		_, err := app.Parse(args)
+		assert.NoError(t, err)
+
+		return buf.String()
+	}
+
+	assert.Contains(t, help("--long", "--help"), "usage: test [<flags>] <command>")
+	assert.Contains(t, help("list", "--help"), "usage: test list [<flags>]")
+}
+
+func TestCompletionSkipsValuesAndActions(t *testing.T) {
+	os.Setenv("TEST_COMPLETION_REPLICAS", "invalid")
+	defer os.Unsetenv("TEST_COMPLETION_REPLICAS")
+
+	ran := false
+	terminated := false
+	app := newTestApp().Terminate(func(int) { terminated = true })
+	app.PreAction(func(*ParseContext) error { ran = true; return nil })
+	app.Validate(func(*Application) error { ran = true; return nil })
+	add := app.Command("add", "").PreAction(func(*ParseContext) error { ran = true; return nil })
+	add.Flag("replicas", "").Envar("TEST_COMPLETION_REPLICAS").Int()
+
+	_, err := app.Parse([]string{"--completion-bash", "add", "--"})
+	assert.NoError(t, err)
+	assert.False(t, ran)
+	assert.True(t, terminated)
+}
+
+func TestAllowArgsAndCommands(t *testing.T) {
+	app := newTestApp()
+	url := app.Arg("url", "").Required().String()
+	path := app.Arg("path", "").String()
+	clone := app.Command("clone", "")
+	cloneURL := clone.Arg("url", "").Required().String()
+
+	_, err := app.Parse([]string{"x"})
+	assert.EqualError(t, err, "can't mix top-level Arg()s with Command()s")
+
+	app.AllowArgsAndCommands()
+
+	cmd, err := app.Parse([]string{"https://example.net", "clone"})
+	assert.NoError(t, err)
+	assert.Equal(t, "", cmd)
+	assert.Equal(t, "https://example.net", *url)
+	assert.Equal(t, "clone", *path)
+
+	app.Reset()
+	cmd, err = app.Parse([]string{"clone", "https://example.net"})
+	assert.NoError(t, err)
+	assert.Equal(t, "clone", cmd)
+	assert.Equal(t, "https://example.net", *cloneURL)
+	assert.Equal(t, "", *url)
+
+	app.Reset()
+	_, err = app.Parse([]string{})
+	assert.ErrorIs(t, err, ErrCommandNotSpecified)
+}
+
+func TestTrailingHelp(t *testing.T) {
+	var (
+		buf         bytes.Buffer
+		name, label string
+	)
+
+	app := newTestApp().UsageWriter(&buf).ErrorWriter(&buf)
+	stream := app.Command("stream", "Manage streams")
+	add := stream.Command("add", "Adds a stream")
+	add.Arg("name", "").StringVar(&name)
+	add.Flag("label", "").StringVar(&label)
+	stream.Command("rm", "Removes a stream")
+
+	for _, args := range [][]string{{"stream", "add", "x", "help"}, {"stream", "--help", "add"}} {
+		buf.Reset()
+		app.Reset()
+		_, err := app.Parse(args)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "usage: test stream add", args)
+		assert.NotEqual(t, "help", name)
+	}
+
+	// help is a valid value for the argument
+	buf.Reset()
+	app.Reset()
+	_, err := app.Parse([]string{"stream", "add", "help"})
+	assert.NoError(t, err)
+	assert.Equal(t, "help", name)
+	assert.Empty(t, buf.String())
+
+	for _, args := range [][]string{{"stream", "help"}, {"stream", "ad", "--help"}, {"stream", "ad", "help"}} {
+		buf.Reset()
+		app.Reset()
+		app.Parse(args)
+		assert.Contains(t, buf.String(), "usage: test stream <command>", args)
+		assert.NotContains(t, buf.String(), "error", args)
+	}
+
+	buf.Reset()
+	app.Reset()
+	_, err = app.Parse([]string{"stream", "add", "--", "help"})
+	assert.NoError(t, err)
+	assert.Equal(t, "help", name)
+	assert.Empty(t, buf.String())
+
+	app.Reset()
+	_, err = app.Parse([]string{"stream", "add", "x", "--label", "help"})
+	assert.NoError(t, err)
+	assert.Equal(t, "help", label)
+	assert.Empty(t, buf.String())
+
+	var passed []string
+	app.Command("exec", "Runs a command").Arg("command", "").PassThrough().StringsVar(&passed)
+	app.Reset()
+	_, err = app.Parse([]string{"exec", "git", "help"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"git", "help"}, passed)
+	assert.Empty(t, buf.String())
+}
@@ -5,6 +5,7 @@ import (
 )
 
 type argGroup struct {
+	app  *Application // nil for groups built while parsing
 	args []*ArgClause
 }
 
@@ -21,6 +22,7 @@ func (a *argGroup) have() bool {
 // This allows existing arguments to be modified after definition but before parsing. Useful for
 // modular applications.
 func (a *argGroup) GetArg(name string) *ArgClause {
+	a.app.modelChanged()
 	for _, arg := range a.args {
 		if arg.name == name {
 			return arg
@@ -31,6 +33,7 @@ func (a *argGroup) GetArg(name string) *ArgClause {
 
 func (a *argGroup) Arg(name, help string) *ArgClause {
 	arg := newArg(name, help)
+	a.app.modelChanged()
 	a.args = append(a.args, arg)
 	return arg
 }
@@ -45,6 +48,8 @@ func (a *argGroup) init() error {
 		}
 		if arg.consumesRemainder() {
 			previousArgMustBeLast = true
+		} else if arg.passThrough {
+			return fmt.Errorf("PassThrough() argument '%s' must accumulate values, for example using Strings()", arg.name)
 		}
 		if _, ok := seen[arg.name]; ok {
 			return fmt.Errorf("duplicate argument '%s'", arg.name)
@@ -68,12 +73,18 @@ type ArgClause struct {
 	parserMixin
 	completionsMixin
 	envarMixin
+	normalizeMixin
 	name          string
 	help          string
 	defaultValues []string
+	defaultFunc   func() (string, error)
 	placeholder   string
 	hidden        bool
+	secret        bool
 	required      bool
+	passThrough   bool
+	group         string
+	promptLabel   string
 	validator     OptionValidator
 }
 
@@ -89,31 +100,54 @@ func (a *ArgClause) setDefault() error {
 	if a.HasEnvarValue() {
 		if v, ok := a.value.(remainderArg); !ok || !v.IsCumulative() {
 			// Use the value as-is
-			return a.value.Set(a.GetEnvarValue())
+			return redactError(a.set(a.GetEnvarValue()), a.secret, a.GetEnvarValue())
 		}
 		for _, value := range a.GetSplitEnvarValue() {
-			if err := a.value.Set(value); err != nil {
-				return err
+			if err := a.set(value); err != nil {
+				return redactError(err, a.secret, value)
 			}
 		}
 		return nil
 	}
 
-	if len(a.defaultValues) > 0 {
-		for _, defaultValue := range a.defaultValues {
-			if err := a.value.Set(defaultValue); err != nil {
-				return err
-			}
+	defaults, err := a.defaults()
+	if err != nil {
+		return fmt.Errorf("default for '%s': %w", a.name, err)
+	}
+	for _, defaultValue := range defaults {
+		if err := a.set(defaultValue); err != nil {
+			return err
 		}
-		return nil
 	}
 
 	return nil
 }
 
+// set normalizes raw before setting the value
+func (a *ArgClause) set(raw string) error {
+	return a.value.Set(a.normalize(raw))
+}
+
+// defaults are the Default() values or the value from DefaultFunc()
+func (a *ArgClause) defaults() ([]string, error) {
+	if len(a.defaultValues) > 0 || a.defaultFunc == nil {
+		return a.defaultValues, nil
+	}
+
+	v, err := a.defaultFunc()
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{v}, nil
+}
+
+func (a *ArgClause) hasDefault() bool {
+	return len(a.defaultValues) > 0 || a.defaultFunc != nil
+}
+
 func (a *ArgClause) needsValue() bool {
-	haveDefault := len(a.defaultValues) > 0
-	return a.required && !(haveDefault || a.HasEnvarValue())
+	return a.required && !(a.hasDefault() || a.HasEnvarValue())
 }
 
 func (a *ArgClause) consumesRemainder() bool {
@@ -123,12 +157,40 @@ func (a *ArgClause) consumesRemainder() bool {
 	return false
 }
 
+// PassThrough captures the first positional argument and everything after it
+// verbatim, including values that look like flags, for wrapper commands like
+// "app run -- ls -l". The value must accumulate, for example using Strings().
+func (a *ArgClause) PassThrough() *ArgClause {
+	a.passThrough = true
+	return a
+}
+
+// PromptIfMissing interactively asks for the optional argument using label
+// when it was not given and stdin is a terminal, the default is used otherwise
+func (a *ArgClause) PromptIfMissing(label string) *ArgClause {
+	a.promptLabel = label
+	return a
+}
+
+// Group shows the argument under a heading of its own in help, for commands with many arguments
+func (a *ArgClause) Group(name string) *ArgClause {
+	a.group = name
+	return a
+}
+
 // Hidden hides the argument from usage but still allows it to be used.
 func (a *ArgClause) Hidden() *ArgClause {
 	a.hidden = true
 	return a
 }
 
+// Secret marks the argument as holding sensitive data, its value will be
+// masked in debug output, audit logs and error messages.
+func (a *ArgClause) Secret() *ArgClause {
+	a.secret = true
+	return a
+}
+
 func (a *ArgClause) Validator(validator OptionValidator) *ArgClause {
 	a.validator = validator
 	return a
@@ -153,6 +215,13 @@ func (a *ArgClause) Default(values ...string) *ArgClause {
 	return a
 }
 
+// DefaultFunc computes the default value when the argument is not given on
+// the command line or environment. Values set using Default() take precedence.
+func (a *ArgClause) DefaultFunc(fn func() (string, error)) *ArgClause {
+	a.defaultFunc = fn
+	return a
+}
+
 // Envar overrides the default value(s) for a flag from an environment variable,
 // if it is set. Several default values can be provided by using new lines to
 // separate them.
@@ -180,6 +249,12 @@ func (a *ArgClause) PreAction(action Action) *ArgClause {
 	return a
 }
 
+// Priority sets when the actions of this argument run relative to other actions
+func (a *ArgClause) Priority(priority int) *ArgClause {
+	a.priority = priority
+	return a
+}
+
 // HintAction registers a HintAction (function) for the arg to provide completions
 func (a *ArgClause) HintAction(action HintAction) *ArgClause {
 	a.addHintAction(action)
@@ -194,6 +269,24 @@ func (a *ArgClause) HintOptions(options ...string) *ArgClause {
 	return a
 }
 
+// HintFiles completes the arg using paths of files and directories
+func (a *ArgClause) HintFiles() *ArgClause {
+	a.hintPaths(false)
+	return a
+}
+
+// HintDirectories completes the arg using paths of directories
+func (a *ArgClause) HintDirectories() *ArgClause {
+	a.hintPaths(true)
+	return a
+}
+
+// HintFilesWithExt completes the arg using paths of directories and files with any of the extensions
+func (a *ArgClause) HintFilesWithExt(ext ...string) *ArgClause {
+	a.hintPaths(false, ext...)
+	return a
+}
+
 // Help sets the help message.
 func (a *ArgClause) Help(help string) *ArgClause {
 	a.help = help
@@ -201,7 +294,7 @@ func (a *ArgClause) Help(help string) *ArgClause {
 }
 
 func (a *ArgClause) init() error {
-	if a.required && len(a.defaultValues) > 0 {
+	if a.required && a.hasDefault() {
 		return fmt.Errorf("required argument '%s' with unusable default value", a.name)
 	}
 	if a.value == nil {
@@ -1,8 +1,10 @@
 package fisk
 
 import (
+	"fmt"
 	"io"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -82,3 +84,58 @@ func TestSubcommandArgRequiredWithEnvar(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, 123, *flag)
 }
+
+func TestArgPassThrough(t *testing.T) {
+	app := newTestApp()
+	verbose := app.Flag("verbose", "").Short('v').Bool()
+	run := app.Command("run", "")
+	dir := run.Flag("dir", "").String()
+	command := run.Arg("command", "").Required().PassThrough().Strings()
+
+	_, err := app.Parse([]string{"run", "-v", "--dir", "/tmp", "ls", "--help", "-v", "--", "@x", "--dir=x"})
+	assert.NoError(t, err)
+	assert.True(t, *verbose)
+	assert.Equal(t, "/tmp", *dir)
+	assert.Equal(t, []string{"ls", "--help", "-v", "--", "@x", "--dir=x"}, *command)
+
+	_, err = app.Reset().Parse([]string{"run", "--", "--dir", "x"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"--dir", "x"}, *command)
+
+	app = newTestApp()
+	app.Arg("command", "").PassThrough().String()
+	_, err = app.Parse([]string{"ls"})
+	assert.EqualError(t, err, "PassThrough() argument 'command' must accumulate values, for example using Strings()")
+}
+
+func TestArgDefaultFunc(t *testing.T) {
+	app := newTestApp()
+	host := app.Arg("host", "").DefaultFunc(func() (string, error) { return "localhost", nil }).String()
+
+	_, err := app.Parse([]string{})
+	assert.NoError(t, err)
+	assert.Equal(t, "localhost", *host)
+
+	res, err := app.Resolve([]string{})
+	assert.NoError(t, err)
+	assert.Equal(t, "localhost", res.ArgValue(app.GetArg("host")))
+}
+
+func TestArgNormalization(t *testing.T) {
+	app := newTestApp()
+	name := app.Arg("name", "").TrimSpace().ToLower().NFC().Validator(func(v string) error {
+		if v != strings.TrimSpace(v) {
+			return fmt.Errorf("not trimmed")
+		}
+		return nil
+	}).Envar("TEST_ARG_NORMALIZATION").String()
+
+	_, err := app.Parse([]string{"  Café "})
+	assert.NoError(t, err)
+	assert.Equal(t, "café", *name)
+
+	t.Setenv("TEST_ARG_NORMALIZATION", " FROM ENV\n")
+	_, err = app.Parse([]string{})
+	assert.NoError(t, err)
+	assert.Equal(t, "from env", *name)
+}
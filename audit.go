@@ -0,0 +1,137 @@
+package fisk
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// redactedValue is shown instead of the value of secret flags and arguments
+const redactedValue = "*****"
+
+// AuditRecord is written to the AuditWriter() for every executed command
+type AuditRecord struct {
+	Time        time.Time `json:"time"`
+	User        string    `json:"user,omitempty"`
+	Command     string    `json:"command"`
+	CommandLine string    `json:"command_line"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// AuditWriter writes a JSON AuditRecord line to w for every executed command,
+// the values of Secret() flags and arguments are masked
+func (a *Application) AuditWriter(w io.Writer) *Application {
+	a.OnCommandRun(func(cmdPath string, _ []string, err error, _ time.Duration) {
+		a.writeAudit(w, cmdPath, err)
+	})
+
+	return a
+}
+
+func (a *Application) writeAudit(w io.Writer, cmdPath string, err error) {
+	record := AuditRecord{
+		Time:        time.Now().UTC(),
+		User:        currentUser(),
+		Command:     cmdPath,
+		CommandLine: strings.Join(a.normalizedCommandLine(a.lastContext), " "),
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+
+	j, jerr := json.Marshal(record)
+	if jerr != nil {
+		a.Errorf("could not write audit record: %v", jerr)
+		return
+	}
+
+	fmt.Fprintln(w, string(j))
+}
+
+// normalizedCommandLine produces the command line for a context with commands
+// first, then flags and then arguments, secret values are masked
+func (a *Application) normalizedCommandLine(context *ParseContext) []string {
+	line := []string{a.Name}
+	if context == nil {
+		return line
+	}
+
+	var flags, args []string
+	for _, element := range context.Elements {
+		switch clause := element.Clause.(type) {
+		case *CmdClause:
+			line = append(line, clause.name)
+
+		case *FlagClause:
+			value := ""
+			if element.Value != nil {
+				value = *element.Value
+			}
+
+			switch {
+			case clause.secret:
+				flags = append(flags, fmt.Sprintf("--%s=%s", clause.name, redactedValue))
+			case isBoolFlag(clause.value):
+				if value == "false" {
+					flags = append(flags, "--no-"+clause.name)
+				} else {
+					flags = append(flags, "--"+clause.name)
+				}
+			default:
+				flags = append(flags, fmt.Sprintf("--%s=%s", clause.name, quoteIfNeeded(value)))
+			}
+
+		case *ArgClause:
+			switch {
+			case element.Value == nil:
+			case clause.secret:
+				args = append(args, redactedValue)
+			default:
+				args = append(args, quoteIfNeeded(*element.Value))
+			}
+		}
+	}
+
+	return append(append(line, flags...), args...)
+}
+
+// redactedError hides a secret value from an error message while still supporting errors.Is()
+type redactedError struct {
+	err error
+	msg string
+}
+
+func (e *redactedError) Error() string { return e.msg }
+
+func (e *redactedError) Unwrap() error { return e.err }
+
+// redactError replaces every occurrence of a secret value in the message of err
+func redactError(err error, secret bool, value string) error {
+	if err == nil || !secret || value == "" {
+		return err
+	}
+
+	return &redactedError{err: err, msg: strings.ReplaceAll(err.Error(), value, redactedValue)}
+}
+
+func quoteIfNeeded(s string) string {
+	if s == "" || strings.ContainsAny(s, " \t\n\"'\\") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+func currentUser() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	if u := os.Getenv("USER"); u != "" {
+		return u
+	}
+	return os.Getenv("USERNAME")
+}
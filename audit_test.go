@@ -0,0 +1,66 @@
+package fisk
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditWriter(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+
+	app := newTestApp().AuditWriter(buf)
+	app.Flag("password", "").Secret().String()
+	app.Flag("force", "").Bool()
+	add := app.Command("stream", "").Command("add", "")
+	add.Arg("name", "").String()
+	add.Flag("description", "").String()
+
+	_, err := app.Parse([]string{"stream", "add", "--password", "s3cret", "ORDERS", "--description", "all orders", "--no-force"})
+	assert.NoError(t, err)
+
+	var record AuditRecord
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "stream add", record.Command)
+	assert.Equal(t, `test stream add --password=***** --description="all orders" --no-force ORDERS`, record.CommandLine)
+	assert.NotContains(t, buf.String(), "s3cret")
+	assert.False(t, record.Time.IsZero())
+	assert.Empty(t, record.Error)
+}
+
+func TestSecretArgsAreRedacted(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+
+	app := newTestApp().AuditWriter(buf)
+	app.Arg("password", "").Secret().Int()
+	app.Arg("other", "").Int()
+
+	_, err := app.Parse([]string{"s3cret"})
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), "s3cret")
+	assert.Contains(t, err.Error(), "*****")
+
+	_, err = app.Parse([]string{"nope"})
+	assert.Error(t, err)
+	var numErr *strconv.NumError
+	assert.ErrorAs(t, err, &numErr)
+
+	_, err = app.Parse([]string{"1234", "10"})
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), `"command_line":"test ***** 10"`)
+}
+
+func TestRedactError(t *testing.T) {
+	err := errors.New(`invalid token "s3cret" for token=abc123x and xs3cret, s3cret.`)
+
+	assert.Equal(t, `invalid token "*****" for token=abc123x and x*****, *****.`, redactError(err, true, "s3cret").Error())
+	assert.Equal(t, `invalid token "s3cret" for token=*****x and xs3cret, s3cret.`, redactError(err, true, "abc123").Error())
+	assert.Equal(t, `invalid token "s3cret" for token=abc*****x and xs3cret, s3cret.`, redactError(err, true, "123").Error())
+	assert.Equal(t, err, redactError(err, false, "s3cret"))
+	assert.Equal(t, err, redactError(err, true, ""))
+	assert.ErrorIs(t, redactError(err, true, "s3cret"), err)
+}
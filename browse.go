@@ -0,0 +1,324 @@
+package fisk
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// WithBrowseCommand adds a browse command that shows the commands as a tree
+// that can be navigated using the arrow keys, the help, examples and cheat of
+// the selected command are shown below the tree. Pressing enter prompts for
+// the rest of the selected command line and runs it.
+func (a *Application) WithBrowseCommand() *Application {
+	a.BrowseCommand = a.Commandf("browse", "Browse the commands of %s interactively", a.Name).Action(func(pc *ParseContext) error {
+		if !a.term().IsTTY() {
+			return fmt.Errorf("browsing requires an interactive terminal")
+		}
+
+		p := a.newPrompter()
+		chosen, err := func() (string, error) {
+			// a custom terminal decides how keys are delivered
+			if a.terminal == nil {
+				if restore, err := enableRawInput(int(os.Stdin.Fd())); err == nil {
+					defer restore()
+				}
+			}
+
+			return a.browse(p)
+		}()
+		if err != nil || chosen == "" {
+			return err
+		}
+
+		return a.runBrowsed(pc, p, chosen)
+	})
+
+	return a
+}
+
+const (
+	keyNone = iota
+	keyUp
+	keyDown
+	keyLeft
+	keyRight
+	keyEnter
+	keyQuit
+)
+
+type browseNode struct {
+	cmd      *CmdModel
+	children []*browseNode
+	parent   *browseNode
+	expanded bool
+}
+
+type browseRow struct {
+	node  *browseNode
+	depth int
+}
+
+type browser struct {
+	roots  []*browseNode
+	cursor int
+	cheats map[string]string
+}
+
+func (a *Application) newBrowser() *browser {
+	model := a.sharedModel()
+
+	var build func(cmds []*CmdModel, parent *browseNode) []*browseNode
+	build = func(cmds []*CmdModel, parent *browseNode) []*browseNode {
+		var nodes []*browseNode
+		for _, cmd := range cmds {
+			if cmd.Hidden || cmd.Name == "help" || (a.BrowseCommand != nil && cmd.FullCommand == a.BrowseCommand.FullCommand()) {
+				continue
+			}
+
+			node := &browseNode{cmd: cmd, parent: parent}
+			if cmd.CmdGroupModel != nil {
+				node.children = build(cmd.Commands, node)
+			}
+			nodes = append(nodes, node)
+		}
+
+		return nodes
+	}
+
+	return &browser{roots: build(model.Commands, nil), cheats: model.Cheats}
+}
+
+// rows are the nodes currently visible in the tree
+func (b *browser) rows() []browseRow {
+	var rows []browseRow
+	var walk func(nodes []*browseNode, depth int)
+	walk = func(nodes []*browseNode, depth int) {
+		for _, node := range nodes {
+			rows = append(rows, browseRow{node: node, depth: depth})
+			if node.expanded {
+				walk(node.children, depth+1)
+			}
+		}
+	}
+	walk(b.roots, 0)
+
+	return rows
+}
+
+func (b *browser) selected() *browseNode {
+	rows := b.rows()
+	if len(rows) == 0 {
+		return nil
+	}
+
+	return rows[b.cursor].node
+}
+
+// handle applies key to the tree, returning the command to run when one was chosen
+func (b *browser) handle(key int) (string, bool) {
+	rows := b.rows()
+	if len(rows) == 0 {
+		return "", key == keyEnter || key == keyQuit
+	}
+	node := rows[b.cursor].node
+
+	switch key {
+	case keyUp:
+		if b.cursor > 0 {
+			b.cursor--
+		}
+
+	case keyDown:
+		if b.cursor < len(rows)-1 {
+			b.cursor++
+		}
+
+	case keyRight:
+		if len(node.children) > 0 {
+			node.expanded = true
+		}
+
+	case keyLeft:
+		if node.expanded {
+			node.expanded = false
+		} else if node.parent != nil {
+			node.parent.expanded = false
+			for i, row := range b.rows() {
+				if row.node == node.parent {
+					b.cursor = i
+				}
+			}
+		}
+
+	case keyEnter:
+		return node.cmd.FullCommand, true
+
+	case keyQuit:
+		return "", true
+	}
+
+	return "", false
+}
+
+func (b *browser) render(w io.Writer, name string) {
+	var out strings.Builder
+
+	out.WriteString("\x1b[H\x1b[2J")
+	for i, row := range b.rows() {
+		marker := "  "
+		if i == b.cursor {
+			marker = "> "
+		}
+
+		state := " "
+		switch {
+		case len(row.node.children) > 0 && row.node.expanded:
+			state = "-"
+		case len(row.node.children) > 0:
+			state = "+"
+		}
+
+		fmt.Fprintf(&out, "%s%s%s %-20s %s\n", marker, strings.Repeat("  ", row.depth), state, row.node.cmd.Name, strings.SplitN(row.node.cmd.Help, "\n", 2)[0])
+	}
+
+	if node := b.selected(); node != nil {
+		cmd := node.cmd
+		fmt.Fprintf(&out, "\n%s %s\n\n", name, cmd.FullCommand)
+
+		help := cmd.HelpLong
+		if help == "" {
+			help = cmd.Help
+		}
+		if help != "" {
+			fmt.Fprintf(&out, "%s\n\n", help)
+		}
+
+		for _, example := range cmd.Examples {
+			if example.Help != "" {
+				fmt.Fprintf(&out, "# %s\n", example.Help)
+			}
+			fmt.Fprintf(&out, "%s\n", example.Command)
+		}
+		if len(cmd.Examples) > 0 {
+			out.WriteString("\n")
+		}
+
+		if cheat := b.cheats[cmd.Cheat]; cmd.Cheat != "" && cheat != "" {
+			fmt.Fprintf(&out, "%s\n\n", strings.TrimSpace(cheat))
+		}
+	}
+
+	out.WriteString("up/down: move  right/left: expand/collapse  enter: run  q: quit\n")
+
+	// the terminal may be in raw mode where a new line does not return the cursor
+	io.WriteString(w, strings.ReplaceAll(out.String(), "\n", "\r\n"))
+}
+
+// keyReader reads key presses from raw or line buffered input
+type keyReader struct {
+	p    *prompter
+	last byte
+}
+
+// read reads a single key press, arrow keys are accepted as escape sequences
+// or as the vi keys h, j, k and l. With line buffered input the new line
+// ending a line of keys is skipped, an empty line is enter.
+func (r *keyReader) read() (int, error) {
+	for {
+		c, err := r.p.in.ReadByte()
+		if err != nil {
+			return keyNone, err
+		}
+
+		last := r.last
+		r.last = c
+
+		switch c {
+		case 'k':
+			return keyUp, nil
+		case 'j':
+			return keyDown, nil
+		case 'h':
+			return keyLeft, nil
+		case 'l':
+			return keyRight, nil
+		case '\r':
+			return keyEnter, nil
+		case '\n':
+			if last == 0 || last == '\n' {
+				return keyEnter, nil
+			}
+		case 'q', 3, 4:
+			return keyQuit, nil
+		case 0x1b:
+			next, err := r.p.in.ReadByte()
+			if err != nil || (next != '[' && next != 'O') {
+				return keyQuit, nil
+			}
+
+			c, err = r.p.in.ReadByte()
+			if err != nil {
+				return keyNone, err
+			}
+			r.last = c
+
+			switch c {
+			case 'A':
+				return keyUp, nil
+			case 'B':
+				return keyDown, nil
+			case 'C':
+				return keyRight, nil
+			case 'D':
+				return keyLeft, nil
+			}
+		}
+	}
+}
+
+// browse shows the tree until a command is chosen, returns an empty string when none was
+func (a *Application) browse(p *prompter) (string, error) {
+	b := a.newBrowser()
+	keys := &keyReader{p: p}
+
+	for {
+		b.render(p.out, a.Name)
+
+		key, err := keys.read()
+		if err == io.EOF {
+			return "", nil
+		}
+		if err != nil {
+			return "", err
+		}
+
+		if command, done := b.handle(key); done {
+			return command, nil
+		}
+	}
+}
+
+// runBrowsed prompts for the rest of the chosen command line and runs it
+// keeping the global flags given to the browse command
+func (a *Application) runBrowsed(pc *ParseContext, p *prompter, chosen string) error {
+	fmt.Fprintf(p.out, "%s %s ", a.Name, chosen)
+	rest, err := p.readLine()
+	if err != nil {
+		return err
+	}
+
+	words, err := splitCommandLine(chosen + " " + rest)
+	if err != nil {
+		return err
+	}
+
+	state := &shellState{values: map[string]string{}}
+	state.rememberGlobals(a, pc)
+
+	a.Reset()
+	_, err = a.Parse(append(state.globals, words...))
+
+	return err
+}
@@ -0,0 +1,61 @@
+package fisk
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type inputTerminal struct {
+	ttyTerminal
+	in io.Reader
+}
+
+func (t inputTerminal) Input() io.Reader { return t.in }
+
+func TestBrowseCommand(t *testing.T) {
+	var (
+		out  bytes.Buffer
+		name string
+	)
+
+	newApp := func(input string) *Application {
+		app := newTestApp().WithBrowseCommand().Terminal(inputTerminal{in: strings.NewReader(input)}).ErrorWriter(&out)
+		stream := app.Command("stream", "Manage streams")
+		stream.Command("add", "Adds a stream").Example("test stream add ORDERS", "Adds the ORDERS stream").Arg("name", "").StringVar(&name)
+		stream.Command("rm", "Removes a stream")
+		app.Command("pub", "Publish a message")
+		app.Command("secret", "").Hidden()
+		assert.NoError(t, app.init())
+		return app
+	}
+
+	app := newApp("")
+	p := newPrompter(strings.NewReader("l\nj\n\n"), &out)
+	chosen, err := app.browse(p)
+	assert.NoError(t, err)
+	assert.Equal(t, "stream add", chosen)
+	assert.Contains(t, out.String(), "> - stream")
+	assert.Contains(t, out.String(), ">     add")
+	assert.Contains(t, out.String(), "# Adds the ORDERS stream\r\ntest stream add ORDERS")
+	assert.NotContains(t, out.String(), "secret")
+	assert.NotContains(t, out.String(), "browse")
+
+	chosen, err = app.browse(newPrompter(strings.NewReader("ljjhj\n\n"), &out))
+	assert.NoError(t, err)
+	assert.Equal(t, "pub", chosen)
+
+	chosen, err = app.browse(newPrompter(strings.NewReader("jq"), &out))
+	assert.NoError(t, err)
+	assert.Equal(t, "", chosen)
+
+	out.Reset()
+	app = newApp("\x1b[C\x1b[B\rORDERS\n")
+	_, err = app.Parse([]string{"browse"})
+	assert.NoError(t, err)
+	assert.Equal(t, "ORDERS", name)
+	assert.Contains(t, out.String(), "test stream add ")
+}
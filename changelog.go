@@ -0,0 +1,108 @@
+package fisk
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// WithChangelog adds a changelog command showing the markdown changelog at
+// path in fsys, typically an embed.FS. The command shows the section for a
+// specific version, or the current version using --current, when versions
+// are level 2 headings like "## 1.2.0", "## [1.2.0] - 2024-01-02" or
+// "## v1.2.0".
+//
+// The changelog is shown using $PAGER when set and output is a terminal.
+func (a *Application) WithChangelog(fsys fs.FS, path string) *Application {
+	var (
+		version string
+		current bool
+	)
+
+	a.ChangelogCommand = a.Commandf("changelog", "Shows the changes made in each release of %s", a.Name).Action(func(_ *ParseContext) error {
+		body, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return fmt.Errorf("could not read changelog: %w", err)
+		}
+
+		text := string(body)
+		if current {
+			version = a.version
+		}
+		if version != "" {
+			var ok bool
+			text, ok = changelogSection(text, version)
+			if !ok {
+				return fmt.Errorf("no changes found for version %s", version)
+			}
+		}
+
+		return a.page(text)
+	})
+	a.ChangelogCommand.Arg("version", "Shows only the changes for this version").StringVar(&version)
+	a.ChangelogCommand.Flag("current", "Shows only the changes for the running version").UnNegatableBoolVar(&current)
+
+	return a
+}
+
+// changelogSection finds the section of a markdown changelog for version
+func changelogSection(changelog string, version string) (string, bool) {
+	var (
+		section []string
+		found   bool
+	)
+
+	for _, line := range strings.Split(changelog, "\n") {
+		if strings.HasPrefix(line, "# ") || strings.HasPrefix(line, "## ") {
+			if found {
+				break
+			}
+			found = strings.HasPrefix(line, "## ") && changelogHeadingVersion(line) == strings.TrimPrefix(version, "v")
+		}
+		if found {
+			section = append(section, line)
+		}
+	}
+
+	if !found {
+		return "", false
+	}
+
+	return strings.TrimSpace(strings.Join(section, "\n")) + "\n", true
+}
+
+// changelogHeadingVersion extracts the version from headings like "## [v1.2.0] - 2024-01-02"
+func changelogHeadingVersion(heading string) string {
+	fields := strings.Fields(strings.TrimPrefix(heading, "## "))
+	if len(fields) == 0 {
+		return ""
+	}
+
+	return strings.TrimPrefix(strings.Trim(fields[0], "[]"), "v")
+}
+
+// page writes text to the usage writer using $PAGER when it is a terminal
+func (a *Application) page(text string) error {
+	pager := strings.TrimSpace(os.Getenv("PAGER"))
+	out, ok := a.usageWriter.(*os.File)
+	if pager == "" || !ok || !isTerminal(int(out.Fd())) {
+		_, err := io.WriteString(a.usageWriter, text)
+		return err
+	}
+
+	words, err := splitCommandLine(pager)
+	if err != nil || len(words) == 0 {
+		_, err := io.WriteString(a.usageWriter, text)
+		return err
+	}
+
+	cmd := exec.Command(words[0], words[1:]...)
+	cmd.Stdin = strings.NewReader(text)
+	cmd.Stdout = out
+	cmd.Stderr = os.Stderr
+
+	return cmd.Run()
+}
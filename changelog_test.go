@@ -0,0 +1,46 @@
+package fisk
+
+import (
+	"bytes"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangelogCommand(t *testing.T) {
+	changelog := `# Changelog
+
+## [v1.1.0] - 2024-02-01
+
+### Added
+
+ * Widgets
+
+## 1.0.0
+
+ * Initial release
+`
+	w := &bytes.Buffer{}
+	app := newTestApp().Version("1.1.0").WithChangelog(fstest.MapFS{"CHANGELOG.md": {Data: []byte(changelog)}}, "CHANGELOG.md")
+	app.UsageWriter(w)
+
+	_, err := app.Parse([]string{"changelog"})
+	assert.NoError(t, err)
+	assert.Equal(t, changelog, w.String())
+
+	w.Reset()
+	_, err = app.Parse([]string{"changelog", "v1.0.0"})
+	assert.NoError(t, err)
+	assert.Equal(t, "## 1.0.0\n\n * Initial release\n", w.String())
+
+	w.Reset()
+	app.Reset()
+	_, err = app.Parse([]string{"changelog", "--current"})
+	assert.NoError(t, err)
+	assert.Equal(t, "## [v1.1.0] - 2024-02-01\n\n### Added\n\n * Widgets\n", w.String())
+
+	app.Reset()
+	_, err = app.Parse([]string{"changelog", "2.0.0"})
+	assert.EqualError(t, err, "no changes found for version 2.0.0")
+}
@@ -1,7 +1,9 @@
 package fisk
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"strings"
 )
@@ -24,13 +26,25 @@ func (c *cmdMixin) CmdCompletion(context *ParseContext) []string {
 	argsSatisfied := 0
 	allSatisfied := false
 ElementLoop:
-	for _, el := range context.Elements {
+	for i, el := range context.Elements {
 		switch clause := el.Clause.(type) {
 		case *ArgClause:
 			// Each new element should reset the previous state
 			allSatisfied = false
 			options = nil
 
+			if el.Value != nil && *el.Value != "" && clause.pathHint != nil {
+				// Paths are completed by the shell, the last one is the one being completed
+				if i == len(context.Elements)-1 && context.rawArgs[len(context.rawArgs)-1] == *el.Value {
+					options = clause.resolveCompletions()
+					allSatisfied = true
+				}
+				if !clause.consumesRemainder() {
+					argsSatisfied++
+				}
+				continue ElementLoop
+			}
+
 			if el.Value != nil && *el.Value != "" {
 				// Get the list of valid options for the last argument
 				validOptions := c.argGroup.args[argsSatisfied].resolveCompletions()
@@ -86,13 +100,17 @@ ElementLoop:
 }
 
 func (c *cmdMixin) FlagCompletion(flagName string, flagValue string) (choices []string, flagMatch bool, optionMatch bool) {
+	return flagCompletion(c.flagGroup, flagName, flagValue)
+}
+
+func flagCompletion(flags *flagGroup, flagName string, flagValue string) (choices []string, flagMatch bool, optionMatch bool) {
 	// Check if flagName matches a known flag.
 	// If it does, show the options for the flag
 	// Otherwise, show all flags
 
 	options := []string{}
 
-	for _, flag := range c.flagGroup.flagOrder {
+	for _, flag := range flags.flagOrder {
 		// Loop through each flag and determine if a match exists
 		if flag.name == flagName {
 			// User typed entire flag. Need to look for flag options.
@@ -157,6 +175,7 @@ func (c *cmdGroup) cmdNames() []string {
 // This allows existing commands to be modified after definition but before parsing. Useful for
 // modular applications.
 func (c *cmdGroup) GetCommand(name string) *CmdClause {
+	c.app.modelChanged()
 	return c.commands[name]
 }
 
@@ -180,6 +199,7 @@ func (c *cmdGroup) flattenedCommands() (out []*CmdClause) {
 
 func (c *cmdGroup) addCommand(name, help string) *CmdClause {
 	cmd := newCommand(c.app, name, help)
+	c.app.modelChanged()
 	c.commands[name] = cmd
 	c.commandOrder = append(c.commandOrder, cmd)
 	return cmd
@@ -225,6 +245,8 @@ type CmdClauseValidator func(*CmdClause) error
 // and either subcommands or positional arguments.
 type CmdClause struct {
 	cmdMixin
+	deprecationMixin
+	conditionalHiddenMixin
 	app             *Application
 	name            string
 	aliases         []string
@@ -235,6 +257,54 @@ type CmdClause struct {
 	hidden          bool
 	completionAlts  []string
 	pluginDelegator *pluginDelegator
+	wizard          bool
+	wizardRequested bool
+	examples        []*CmdExample
+	stability       Stability
+	helpFooter      string
+	category        string
+	cheat           string
+	usageWriter     io.Writer
+	errorWriter     io.Writer
+	flagLookup      *flagGroup // All flags valid for this command including those of its parents, built at init
+}
+
+// flagsInScope is the lookup of all flags valid for the command, its own and
+// those of its parents and the application
+func (c *CmdClause) flagsInScope() *flagGroup {
+	if c.flagLookup != nil {
+		return c.flagLookup
+	}
+
+	parent := newFlagGroup()
+	switch {
+	case c.parent != nil:
+		parent = c.parent.flagsInScope()
+	case c.app != nil:
+		parent = c.app.flagGroup
+	}
+
+	// commands added after init are not checked for duplicates and not cached
+	lookup, _ := mergeFlagGroups(parent, c.flagGroup)
+
+	return lookup
+}
+
+// buildFlagLookup builds and caches the flag lookup for the command and its
+// subcommands, reporting every flag that duplicates another in scope
+func (c *CmdClause) buildFlagLookup(parent *flagGroup) error {
+	lookup, conflicts := mergeFlagGroups(parent, c.flagGroup)
+	c.flagLookup = lookup
+
+	var errs []error
+	for _, err := range conflicts {
+		errs = append(errs, fmt.Errorf("%s: %w", c.FullCommand(), err))
+	}
+	for _, cmd := range c.commandOrder {
+		errs = append(errs, cmd.buildFlagLookup(lookup))
+	}
+
+	return errors.Join(errs...)
 }
 
 func newCommand(app *Application, name, help string) *CmdClause {
@@ -244,7 +314,9 @@ func newCommand(app *Application, name, help string) *CmdClause {
 		help: help,
 	}
 	c.flagGroup = newFlagGroup()
+	c.flagGroup.app = app
 	c.argGroup = newArgGroup()
+	c.argGroup.app = app
 	c.cmdGroup = newCmdGroup(app)
 	return c
 }
@@ -270,7 +342,43 @@ func (c *CmdClause) Cheat(cheat string, help string) *CmdClause {
 	}
 
 	c.app.cheats[cheat] = help
+	c.cheat = cheat
+
+	return c
+}
+
+// HelpFooter is shown after the flags and arguments in the help for this
+// command, for example "See 'app cheat stream' for examples"
+func (c *CmdClause) HelpFooter(footer string) *CmdClause {
+	c.helpFooter = footer
+	return c
+}
+
+// UsageWriter sets the io.Writer used for help of this command and its
+// subcommands, overriding that of the application
+func (c *CmdClause) UsageWriter(w io.Writer) *CmdClause {
+	c.usageWriter = w
+	return c
+}
 
+// ErrorWriter sets the io.Writer used for errors and warnings while this
+// command or its subcommands are selected, overriding that of the application
+func (c *CmdClause) ErrorWriter(w io.Writer) *CmdClause {
+	c.errorWriter = w
+	return c
+}
+
+// Category groups the command with others of the same category in docs and
+// completion specs generated from the model
+func (c *CmdClause) Category(category string) *CmdClause {
+	c.category = category
+	return c
+}
+
+// Example adds an example invocation of this command, command is the full
+// command line like "nats stream add ORDERS --replicas 3", see fisktest.CheckExamples()
+func (c *CmdClause) Example(command string, help string) *CmdClause {
+	c.examples = append(c.examples, &CmdExample{Command: command, Help: help})
 	return c
 }
 
@@ -322,6 +430,12 @@ func (c *CmdClause) PreAction(action Action) *CmdClause {
 	return c
 }
 
+// Priority sets when the actions of this command run relative to other actions
+func (c *CmdClause) Priority(priority int) *CmdClause {
+	c.priority = priority
+	return c
+}
+
 // Help sets the help message.
 func (c *CmdClause) Help(help string) *CmdClause {
 	c.help = help
@@ -329,6 +443,9 @@ func (c *CmdClause) Help(help string) *CmdClause {
 }
 
 func (c *CmdClause) init() error {
+	if c.hiddenByCondition() {
+		c.hidden = true
+	}
 	if err := c.flagGroup.init(c.app.defaultEnvarPrefix()); err != nil {
 		return err
 	}
@@ -37,11 +37,13 @@ func (f *{{.|ValueName}}) Get() interface{} { return ({{.Type}})(*f.v) }
 
 func (f *{{.|ValueName}}) String() string { return {{.|Format}} }
 
+func (f *{{.|ValueName}}) Reset() { *f.v = *new({{.Type}}) }
+
 {{if .Help}}
 // {{.Help}}
-{{else-}}
+{{else -}}
 // {{.|Name}} parses the next command-line value as {{.Type}}.
-{{end-}}
+{{end -}}
 func (p *parserMixin) {{.|Name}}() (target *{{.Type}}) {
 	target = new({{.Type}})
 	p.{{.|Name}}Var(target)
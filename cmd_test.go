@@ -1,6 +1,7 @@
 package fisk
 
 import (
+	"bytes"
 	"sort"
 	"strings"
 	"testing"
@@ -405,3 +406,72 @@ func TestPartialCmdCompletion(t *testing.T) {
 	// no option
 	assert.Empty(t, complete(t, app, "cmd3", "cmd3-"))
 }
+
+func TestNestedCmdFlagCompletion(t *testing.T) {
+	app := newTestApp()
+	app.Flag("server", "").String()
+	stream := app.Command("stream", "")
+	stream.Flag("json", "").Bool()
+	add := stream.Command("add", "")
+	add.Flag("replicas", "").Int()
+
+	assert.Equal(t, []string{"--help", "--json", "--replicas", "--server"}, complete(t, app, "stream", "add", "--"))
+	assert.Equal(t, add.flagLookup.long["json"], app.GetCommand("stream").GetFlag("json"))
+}
+
+func TestDuplicateParentFlag(t *testing.T) {
+	app := newTestApp()
+	app.Flag("server", "").Short('s').String()
+	stream := app.Command("stream", "")
+	stream.Command("add", "").Flag("size", "").Short('s').String()
+
+	_, err := app.Parse([]string{"stream", "add"})
+	assert.EqualError(t, err, "stream add: duplicate short flag -s")
+}
+
+func TestDuplicateFlagsReportsAll(t *testing.T) {
+	app := newTestApp()
+	app.Flag("server", "").Short('s').String()
+	app.Flag("server", "").String()
+	stream := app.Command("stream", "")
+	stream.Flag("json", "").String()
+	stream.Command("add", "").Flag("json", "").Short('s').String()
+	app.Command("kv", "").Flag("server", "").String()
+
+	_, err := app.Parse([]string{"stream", "add"})
+	assert.EqualError(t, err, strings.Join([]string{
+		"duplicate long flag --server",
+		"stream add: duplicate short flag -s",
+		"stream add: duplicate long flag --json",
+		"kv: duplicate long flag --server",
+	}, "\n"))
+}
+
+func TestCmdWriters(t *testing.T) {
+	var appOut, appErr, cmdOut, cmdErr bytes.Buffer
+
+	app := newTestApp().UsageWriter(&appOut).ErrorWriter(&appErr)
+	export := app.Command("export", "Exports data").UsageWriter(&cmdOut).ErrorWriter(&cmdErr)
+	export.Command("json", "Exports JSON").Deprecated("use export yaml")
+	export.Command("yaml", "Exports YAML")
+	app.Command("other", "Other things")
+
+	app.Parse([]string{"export", "--help"})
+	assert.Contains(t, cmdOut.String(), "Exports data")
+	assert.Empty(t, appOut.String())
+
+	cmdOut.Reset()
+	_, err := app.Parse([]string{"export", "json"})
+	assert.NoError(t, err)
+	assert.Contains(t, cmdErr.String(), "is deprecated: use export yaml")
+	assert.Empty(t, appErr.String())
+
+	app.Errorf("failed")
+	assert.Contains(t, cmdErr.String(), "test: error: failed")
+	assert.Empty(t, appErr.String())
+
+	_, err = app.Parse([]string{"other", "--help"})
+	assert.NoError(t, err)
+	assert.Contains(t, appOut.String(), "Other things")
+	assert.Empty(t, cmdOut.String())
+}
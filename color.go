@@ -0,0 +1,28 @@
+package fisk
+
+// WithColorFlag adds a global --[no-]color flag, when not given colors are
+// enabled when the terminal supports them and NO_COLOR is not set. The
+// resolved decision is available using ParseContext.ColorEnabled(), to the
+// ColorEnabled template function and to the Terminal used by fisk
+func (a *Application) WithColorFlag() *Application {
+	a.Flag("color", "Enables colored output, by default only when writing to a terminal").IsSetByUser(&a.colorSet).BoolVar(&a.color)
+	return a
+}
+
+// ColorEnabled determines if output of the application being parsed may be colored
+func (p *ParseContext) ColorEnabled() bool {
+	if p.app == nil {
+		return false
+	}
+
+	return p.app.term().ColorEnabled()
+}
+
+// colorTerminal overrides the color decision of a terminal with the flag added
+// by WithColorFlag()
+type colorTerminal struct {
+	Terminal
+	enabled bool
+}
+
+func (t *colorTerminal) ColorEnabled() bool { return t.enabled }
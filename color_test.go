@@ -0,0 +1,51 @@
+package fisk
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type colorTestTerminal struct {
+	stdTerminal
+	color bool
+}
+
+func (t *colorTestTerminal) ColorEnabled() bool { return t.color }
+
+func TestColorFlag(t *testing.T) {
+	parse := func(detected bool, args ...string) bool {
+		t.Helper()
+
+		app := newTestApp().WithColorFlag().Terminal(&colorTestTerminal{color: detected})
+		app.Arg("x", "").String()
+
+		pc, err := app.ParseContext(args)
+		assert.NoError(t, err)
+		_, err = app.setValues(pc)
+		assert.NoError(t, err)
+
+		return pc.ColorEnabled()
+	}
+
+	assert.True(t, parse(true))
+	assert.False(t, parse(false))
+	assert.True(t, parse(false, "--color"))
+	assert.False(t, parse(true, "--no-color"))
+	assert.False(t, (&ParseContext{}).ColorEnabled())
+
+	t.Setenv("NO_COLOR", "1")
+	app := newTestApp().WithColorFlag()
+	_, err := app.Parse([]string{})
+	assert.NoError(t, err)
+	assert.False(t, app.term().ColorEnabled())
+	_, err = app.Parse([]string{"--color"})
+	assert.NoError(t, err)
+	assert.True(t, app.term().ColorEnabled())
+
+	var out bytes.Buffer
+	app.UsageWriter(&out)
+	assert.NoError(t, app.UsageForContextWithTemplate(&ParseContext{app: app, flags: app.flagGroup, arguments: app.argGroup}, 2, "{{if ColorEnabled}}color{{end}}"))
+	assert.Equal(t, "color", out.String())
+}
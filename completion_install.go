@@ -0,0 +1,94 @@
+package fisk
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// completionShells are the templates used to install completion for each supported shell
+var completionShells = map[string]string{
+	"bash": BashCompletionTemplate,
+	"zsh":  ZshCompletionTemplate,
+	"fish": FishCompletionTemplate,
+}
+
+// WithCompletionInstall adds a completion install command that saves the
+// completion script for bash, zsh or fish where that shell will find it
+func (a *Application) WithCompletionInstall() *Application {
+	var shell, dir string
+
+	a.CompletionCommand = a.Command("completion", "Manage shell completion")
+	install := a.CompletionCommand.Commandf("install", "Installs shell completion for %s", a.Name).Action(func(_ *ParseContext) error {
+		return a.installCompletion(shell, dir)
+	})
+	install.Arg("shell", "The shell to install completion for, detected from $SHELL by default").EnumVar(&shell, "bash", "zsh", "fish")
+	install.Flag("dir", "Directory to save the completion script in").PlaceHolder("DIRECTORY").StringVar(&dir)
+
+	return a
+}
+
+func (a *Application) installCompletion(shell string, dir string) error {
+	if shell == "" {
+		shell = filepath.Base(os.Getenv("SHELL"))
+		if _, ok := completionShells[shell]; !ok {
+			return fmt.Errorf("could not detect a supported shell from $SHELL, specify one of bash, zsh or fish")
+		}
+	}
+
+	file, hint, err := completionPath(a.Name, shell, dir)
+	if err != nil {
+		return err
+	}
+
+	err = a.renderTemplateFile(file, completionShells[shell])
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.usageWriter, "Saved %s completion to %s\n", shell, file)
+	if hint != "" {
+		fmt.Fprintln(a.usageWriter, hint)
+	}
+
+	return nil
+}
+
+// completionPath is where shell loads completion for name from and any setup the user might need to do
+func completionPath(name string, shell string, dir string) (string, string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil && dir == "" {
+		return "", "", err
+	}
+
+	switch shell {
+	case "bash":
+		if dir == "" {
+			dir = filepath.Join(home, ".bashrc.d")
+		}
+		return filepath.Join(dir, name+".bash"), fmt.Sprintf("Ensure your ~/.bashrc sources the files in %s", dir), nil
+
+	case "zsh":
+		if dir == "" {
+			base := os.Getenv("ZDOTDIR")
+			if base == "" {
+				base = home
+			}
+			dir = filepath.Join(base, ".zfunc")
+		}
+		return filepath.Join(dir, "_"+name), fmt.Sprintf("Ensure %s is in your fpath before compinit is called", dir), nil
+
+	case "fish":
+		if dir == "" {
+			base := os.Getenv("XDG_CONFIG_HOME")
+			if base == "" {
+				base = filepath.Join(home, ".config")
+			}
+			dir = filepath.Join(base, "fish", "completions")
+		}
+		return filepath.Join(dir, name+".fish"), "", nil
+
+	default:
+		return "", "", fmt.Errorf("unsupported shell %q, specify one of bash, zsh or fish", shell)
+	}
+}
@@ -0,0 +1,55 @@
+package fisk
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompletionInstall(t *testing.T) {
+	var out bytes.Buffer
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("ZDOTDIR", "")
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("SHELL", "/bin/zsh")
+
+	app := newTestApp().UsageWriter(&out).WithCompletionInstall()
+	app.Command("stream", "Manage streams")
+
+	_, err := app.Parse([]string{"completion", "install"})
+	assert.NoError(t, err)
+	script, err := os.ReadFile(filepath.Join(home, ".zfunc", "_test"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(script), "#compdef test")
+	assert.Contains(t, out.String(), "Saved zsh completion to "+filepath.Join(home, ".zfunc", "_test"))
+	assert.Contains(t, out.String(), "in your fpath")
+
+	app.Reset()
+	_, err = app.Parse([]string{"completion", "install", "bash"})
+	assert.NoError(t, err)
+	assert.FileExists(t, filepath.Join(home, ".bashrc.d", "test.bash"))
+
+	app.Reset()
+	_, err = app.Parse([]string{"completion", "install", "fish"})
+	assert.NoError(t, err)
+	assert.FileExists(t, filepath.Join(home, ".config", "fish", "completions", "test.fish"))
+
+	dir := t.TempDir()
+	app.Reset()
+	_, err = app.Parse([]string{"completion", "install", "fish", "--dir", dir})
+	assert.NoError(t, err)
+	assert.FileExists(t, filepath.Join(dir, "test.fish"))
+
+	t.Setenv("SHELL", "/bin/csh")
+	app.Reset()
+	_, err = app.Parse([]string{"completion", "install"})
+	assert.ErrorContains(t, err, "could not detect a supported shell")
+
+	_, err = app.Parse([]string{"completion", "install", "csh"})
+	assert.Error(t, err)
+}
@@ -1,11 +1,31 @@
 package fisk
 
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Completion options telling the shell to complete paths instead, extensions
+// follow the files directive as a comma separated list
+const (
+	completeFilesDirective = "__fisk_complete_files"
+	completeDirsDirective  = "__fisk_complete_dirs"
+)
+
 // HintAction is a function type who is expected to return a slice of possible
 // command line arguments.
 type HintAction func() []string
 type completionsMixin struct {
 	hintActions        []HintAction
 	builtinHintActions []HintAction
+	pathHint           *pathHint
+}
+
+// pathHint asks the shell to complete directories or files, optionally with specific extensions
+type pathHint struct {
+	dirs bool
+	exts []string
 }
 
 func (a *completionsMixin) addHintAction(action HintAction) {
@@ -17,7 +37,21 @@ func (a *completionsMixin) addHintActionBuiltin(action HintAction) {
 	a.builtinHintActions = append(a.builtinHintActions, action)
 }
 
+// hintPaths completes directories or files with any of exts, replacing other hints
+func (a *completionsMixin) hintPaths(dirs bool, exts ...string) {
+	hint := &pathHint{dirs: dirs}
+	for _, ext := range exts {
+		hint.exts = append(hint.exts, strings.TrimPrefix(ext, "."))
+	}
+
+	a.pathHint = hint
+}
+
 func (a *completionsMixin) resolveCompletions() []string {
+	if a.pathHint != nil {
+		return []string{a.pathHint.directive()}
+	}
+
 	var hints []string
 
 	options := a.builtinHintActions
@@ -31,3 +65,74 @@ func (a *completionsMixin) resolveCompletions() []string {
 	}
 	return hints
 }
+
+func (h *pathHint) directive() string {
+	switch {
+	case h.dirs:
+		return completeDirsDirective
+	case len(h.exts) > 0:
+		return completeFilesDirective + ":" + strings.Join(h.exts, ",")
+	default:
+		return completeFilesDirective
+	}
+}
+
+// parsePathDirective is the hint requested by a completion option, nil when it is not a directive
+func parsePathDirective(opt string) *pathHint {
+	switch {
+	case opt == completeDirsDirective:
+		return &pathHint{dirs: true}
+	case opt == completeFilesDirective:
+		return &pathHint{}
+	case strings.HasPrefix(opt, completeFilesDirective+":"):
+		return &pathHint{exts: strings.Split(strings.TrimPrefix(opt, completeFilesDirective+":"), ",")}
+	default:
+		return nil
+	}
+}
+
+// complete lists the paths matching the partially entered current path, directories end in a separator
+func (h *pathHint) complete(current string) []string {
+	dir, prefix := filepath.Split(current)
+
+	read := dir
+	if read == "" {
+		read = "."
+	}
+
+	entries, err := os.ReadDir(read)
+	if err != nil {
+		return nil
+	}
+
+	var matches []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, prefix) || (strings.HasPrefix(name, ".") && !strings.HasPrefix(prefix, ".")) {
+			continue
+		}
+
+		switch {
+		case entry.IsDir():
+			matches = append(matches, dir+name+string(filepath.Separator))
+		case !h.dirs && h.matches(name):
+			matches = append(matches, dir+name)
+		}
+	}
+
+	return matches
+}
+
+func (h *pathHint) matches(name string) bool {
+	if len(h.exts) == 0 {
+		return true
+	}
+
+	for _, ext := range h.exts {
+		if strings.HasSuffix(name, "."+ext) {
+			return true
+		}
+	}
+
+	return false
+}
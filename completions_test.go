@@ -1,6 +1,9 @@
 package fisk
 
 import (
+	"bytes"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -76,3 +79,120 @@ func TestAddHintActionBuiltin(t *testing.T) {
 	args := a.resolveCompletions()
 	assert.Equal(t, []string{"opt1", "opt2"}, args)
 }
+
+func TestCommandNotFoundHandler(t *testing.T) {
+	var buf bytes.Buffer
+
+	app := newTestApp().UsageWriter(&buf)
+	app.Command("stream", "").Alias("str")
+	app.Command("kv", "")
+	app.Command("secret", "").Hidden()
+
+	pc := &ParseContext{app: app, flags: app.flagGroup, arguments: app.argGroup}
+	assert.NoError(t, app.UsageForContextWithTemplate(pc, 2, BashCommandNotFoundTemplate))
+	assert.Contains(t, buf.String(), "command_not_found_handle() {")
+	assert.Contains(t, buf.String(), "    stream|str|kv)\n")
+	assert.Contains(t, buf.String(), `did you mean "test %s"?\n'`)
+	assert.NotContains(t, buf.String(), "secret")
+
+	buf.Reset()
+	assert.NoError(t, app.UsageForContextWithTemplate(pc, 2, ZshCommandNotFoundTemplate))
+	assert.Contains(t, buf.String(), "command_not_found_handler() {")
+	assert.Contains(t, buf.String(), "    stream|str|kv)\n")
+}
+
+func TestFishCompletionScript(t *testing.T) {
+	var buf bytes.Buffer
+
+	app := newTestApp().UsageWriter(&buf)
+	stream := app.Command("stream", "Manage 'streams'\nMore detail")
+	stream.Command("add", "Adds a stream")
+	app.Command("secret", "Hidden").Hidden()
+
+	pc := &ParseContext{app: app, flags: app.flagGroup, arguments: app.argGroup}
+	assert.NoError(t, app.UsageForContextWithTemplate(pc, 2, FishCompletionTemplate))
+	assert.Contains(t, buf.String(), "    case 'stream'\n        echo 'Manage \\'streams\\''\n")
+	assert.Contains(t, buf.String(), "    case 'stream add'\n        echo 'Adds a stream'\n")
+	assert.Contains(t, buf.String(), "--completion-bash $words[2..-1] $current")
+	assert.Contains(t, buf.String(), "complete -c test -f -a '(__fish_test_complete)'")
+	assert.NotContains(t, buf.String(), "secret")
+	assert.NotContains(t, buf.String(), "More detail")
+}
+
+func TestZshCompletionScript(t *testing.T) {
+	var buf bytes.Buffer
+
+	app := newTestApp().UsageWriter(&buf)
+	app.Flag("server", "The [server]").Short('s').String()
+	app.Flag("trace", "Traces").Bool()
+	stream := app.Command("stream", "Manage 'streams'").Alias("str")
+	add := stream.Command("add", "Adds a stream")
+	add.Flag("storage", "Storage type").Enum("file", "memory")
+	add.Arg("name", "Stream name").Required().String()
+	add.Arg("subjects", "Subjects").HintOptions("one", "two").Strings()
+	app.Command("secret", "Hidden").Hidden()
+
+	pc := &ParseContext{app: app, flags: app.flagGroup, arguments: app.argGroup}
+	assert.NoError(t, app.UsageForContextWithTemplate(pc, 2, ZshCompletionTemplate))
+	out := buf.String()
+
+	assert.Contains(t, out, "#compdef test\n")
+	assert.Contains(t, out, `'(-s --server)'{-s+,--server=}'[The \[server\]]:SERVER:_files'`)
+	assert.Contains(t, out, `'--trace[Traces]'`)
+	assert.Contains(t, out, `'--no-trace[Traces]'`)
+	assert.Contains(t, out, `'stream:Manage '\''streams'\'''`)
+	assert.Contains(t, out, "stream|str) _test_stream ;;")
+	assert.Contains(t, out, "_test_stream_add() {")
+	assert.Contains(t, out, `'--storage=[Storage type]:{file|memory}:(file memory)'`)
+	assert.Contains(t, out, `'1:name -- Stream name:_files'`)
+	assert.Contains(t, out, `'*:subjects -- Subjects:_test_hints'`)
+	assert.Contains(t, out, `--completion-bash "${(@)_test_words[2,$_test_current]}"`)
+	assert.Contains(t, out, "compdef _test test")
+	assert.NotContains(t, out, "secret")
+}
+
+func TestPathHints(t *testing.T) {
+	app := newTestApp()
+	cp := app.Command("cp", "")
+	cp.Arg("src", "").HintFiles().String()
+	cp.Arg("dst", "").HintDirectories().String()
+	cp.Flag("config", "").HintFilesWithExt(".yaml", "yml").String()
+
+	for _, tc := range []struct {
+		args   []string
+		expect []string
+	}{
+		{[]string{"cp", ""}, []string{"__fisk_complete_files"}},
+		{[]string{"cp", "sr"}, []string{"__fisk_complete_files"}},
+		{[]string{"cp", "src", "ds"}, []string{"__fisk_complete_dirs"}},
+		{[]string{"cp", "src", "dst", ""}, nil},
+		{[]string{"cp", "--config", ""}, []string{"__fisk_complete_files:yaml,yml"}},
+		{[]string{"cp", "--config", "x"}, []string{"__fisk_complete_files:yaml,yml"}},
+	} {
+		context, _ := app.parseContext(true, tc.args)
+		context.rawArgs = append([]string{"--completion-bash"}, tc.args...)
+		assert.Equal(t, tc.expect, app.completionOptions(context), "%q", tc.args)
+	}
+
+	dir := t.TempDir()
+	for _, f := range []string{"a.yaml", "b.yml", "c.txt", ".hidden.yaml"} {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, f), nil, 0600))
+	}
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "sub"), 0700))
+
+	prefix := dir + string(filepath.Separator)
+	sub := prefix + "sub" + string(filepath.Separator)
+	assert.ElementsMatch(t, []string{prefix + "a.yaml", prefix + "b.yml", prefix + "c.txt", sub}, parsePathDirective("__fisk_complete_files").complete(prefix))
+	assert.ElementsMatch(t, []string{sub}, parsePathDirective("__fisk_complete_dirs").complete(prefix))
+	assert.ElementsMatch(t, []string{prefix + "a.yaml", prefix + "b.yml", sub}, parsePathDirective("__fisk_complete_files:yaml,yml").complete(prefix))
+	assert.ElementsMatch(t, []string{prefix + ".hidden.yaml"}, parsePathDirective("__fisk_complete_files").complete(prefix+"."))
+	assert.Nil(t, parsePathDirective("stream"))
+
+	var buf bytes.Buffer
+	app.UsageWriter(&buf)
+	pc := &ParseContext{app: app, flags: app.flagGroup, arguments: app.argGroup}
+	assert.NoError(t, app.UsageForContextWithTemplate(pc, 2, ZshCompletionTemplate))
+	assert.Contains(t, buf.String(), `'1::src:_files'`)
+	assert.Contains(t, buf.String(), `'2::dst:_files -/'`)
+	assert.Contains(t, buf.String(), `'--config=[]:CONFIG:_files -g "*.(yaml|yml)"'`)
+}
@@ -0,0 +1,54 @@
+package fisk
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// AutoConfirmFlag registers a global --yes (-f) flag that answers yes to all
+// confirmations requested using Confirm()
+func (a *Application) AutoConfirmFlag() *Application {
+	a.Flag("yes", "Answer yes to all confirmation prompts").Short('f').UnNegatableBoolVar(&a.autoConfirm)
+	return a
+}
+
+// Confirm asks the user to confirm an action, returning true when the user
+// agrees or when confirmations are bypassed using the flag added by AutoConfirmFlag().
+//
+// When the terminal is not interactive ErrConfirmationRequired is returned
+func Confirm(context *ParseContext, prompt string) (bool, error) {
+	w := io.Writer(os.Stderr)
+
+	if context != nil && context.app != nil {
+		if context.app.autoConfirm {
+			return true, nil
+		}
+		w = context.app.errorWriter
+	}
+
+	term := termFor(context)
+	if !term.IsTTY() {
+		return false, fmt.Errorf("%w: %s", ErrConfirmationRequired, prompt)
+	}
+
+	return confirm(prompt, term.Input(), w)
+}
+
+func confirm(prompt string, r io.Reader, w io.Writer) (bool, error) {
+	fmt.Fprintf(w, "%s [y/N] ", prompt)
+
+	line, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
@@ -0,0 +1,53 @@
+package fisk
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfirm(t *testing.T) {
+	w := bytes.NewBuffer(nil)
+	ok, err := confirm("Delete stream ORDERS?", strings.NewReader("yes\n"), w)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "Delete stream ORDERS? [y/N] ", w.String())
+
+	ok, err = confirm("Delete stream ORDERS?", strings.NewReader("n\n"), w)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = confirm("Delete stream ORDERS?", strings.NewReader(""), w)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestAutoConfirmFlag(t *testing.T) {
+	var confirmed bool
+	var cerr error
+
+	app := newTestApp().AutoConfirmFlag()
+	app.Command("rm", "").Action(func(pc *ParseContext) error {
+		confirmed, cerr = Confirm(pc, "Delete stream ORDERS?")
+		return nil
+	})
+
+	_, err := app.Parse([]string{"rm", "-f"})
+	assert.NoError(t, err)
+	assert.NoError(t, cerr)
+	assert.True(t, confirmed)
+
+	// stdin is not a terminal during tests
+	app = newTestApp().AutoConfirmFlag()
+	app.Command("rm", "").Action(func(pc *ParseContext) error {
+		confirmed, cerr = Confirm(pc, "Delete stream ORDERS?")
+		return nil
+	})
+
+	_, err = app.Parse([]string{"rm"})
+	assert.NoError(t, err)
+	assert.ErrorIs(t, cerr, ErrConfirmationRequired)
+	assert.False(t, confirmed)
+}
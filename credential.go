@@ -0,0 +1,96 @@
+package fisk
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// CommandValuePrefix marks a value as a command whose output is the value, see AllowCommandValues()
+const CommandValuePrefix = "cmd://"
+
+// AllowCommandValues allows values like "cmd://op read vault/token", the
+// command is run and its output, without the trailing newline, becomes the
+// value of the flag.
+//
+// This keeps secrets out of the environment and process arguments in the same
+// way as the docker and git credential helpers. The command is split using
+// shell quoting rules but is not run by a shell, its standard input and error
+// are those of the application.
+func (f *FlagClause) AllowCommandValues() *FlagClause {
+	f.commandValues = true
+	return f
+}
+
+// commandValue runs the helper for values starting with CommandValuePrefix when allowed
+func (f *FlagClause) commandValue(raw string) (string, error) {
+	if !f.commandValues || !strings.HasPrefix(raw, CommandValuePrefix) {
+		return raw, nil
+	}
+
+	words, err := splitCommandLine(strings.TrimPrefix(raw, CommandValuePrefix))
+	if err != nil {
+		return "", fmt.Errorf("invalid command for --%s: %w", f.name, err)
+	}
+	if len(words) == 0 {
+		return "", fmt.Errorf("invalid command for --%s: no command given", f.name)
+	}
+
+	var out bytes.Buffer
+	cmd := exec.Command(words[0], words[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = &out
+	cmd.Stderr = os.Stderr
+
+	err = cmd.Run()
+	if err != nil {
+		return "", fmt.Errorf("could not run %s for --%s: %w", words[0], f.name, err)
+	}
+
+	return strings.TrimRight(out.String(), "\r\n"), nil
+}
+
+// SecretResolver looks up secrets held in a secret store like Vault or the
+// operating system keychain, see Application.SecretResolver()
+type SecretResolver interface {
+	// ResolveSecret retrieves the secret for a reference like "vault://secret/token"
+	ResolveSecret(ref string) (string, error)
+}
+
+// SecretResolver resolves values of Secret() flags that start with
+// scheme:// using resolver, for example "vault" resolves --token
+// vault://secret/token. The value can come from the command line,
+// environment, defaults or prompts.
+func (a *Application) SecretResolver(scheme string, resolver SecretResolver) *Application {
+	if a.secretResolvers == nil {
+		a.secretResolvers = map[string]SecretResolver{}
+	}
+	a.secretResolvers[scheme] = resolver
+	return a
+}
+
+// secretValue resolves values of secret flags using the resolver registered for their scheme
+func (f *FlagClause) secretValue(context *ParseContext, raw string) (string, error) {
+	if !f.secret || context == nil || context.app == nil {
+		return raw, nil
+	}
+
+	scheme, _, ok := strings.Cut(raw, "://")
+	if !ok {
+		return raw, nil
+	}
+
+	resolver, ok := context.app.secretResolvers[scheme]
+	if !ok {
+		return raw, nil
+	}
+
+	v, err := resolver.ResolveSecret(raw)
+	if err != nil {
+		return "", fmt.Errorf("could not resolve secret for --%s: %w", f.name, err)
+	}
+
+	return v, nil
+}
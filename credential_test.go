@@ -0,0 +1,64 @@
+package fisk
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlagCommandValues(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires echo")
+	}
+
+	app := newTestApp()
+	token := app.Flag("token", "").Envar("TEST_COMMAND_VALUE_TOKEN").AllowCommandValues().String()
+	other := app.Flag("other", "").String()
+
+	_, err := app.Parse([]string{"--token", "cmd://echo 's3cret token'", "--other", "cmd://echo other"})
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cret token", *token)
+	assert.Equal(t, "cmd://echo other", *other)
+
+	t.Setenv("TEST_COMMAND_VALUE_TOKEN", "cmd://echo from env")
+	_, err = app.Parse([]string{})
+	assert.NoError(t, err)
+	assert.Equal(t, "from env", *token)
+
+	_, err = app.Parse([]string{"--token", "cmd://"})
+	assert.EqualError(t, err, "invalid command for --token: no command given")
+
+	_, err = app.Parse([]string{"--token", "cmd://false"})
+	assert.EqualError(t, err, "could not run false for --token: exit status 1")
+}
+
+type mapSecretResolver map[string]string
+
+func (r mapSecretResolver) ResolveSecret(ref string) (string, error) {
+	v, ok := r[ref]
+	if !ok {
+		return "", fmt.Errorf("%s not found", ref)
+	}
+
+	return v, nil
+}
+
+func TestSecretResolver(t *testing.T) {
+	app := newTestApp().SecretResolver("vault", mapSecretResolver{"vault://secret/token": "s3cret"})
+	token := app.Flag("token", "").Default("vault://secret/token").Secret().String()
+	url := app.Flag("url", "").String()
+
+	_, err := app.Parse([]string{"--url", "vault://secret/token"})
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cret", *token)
+	assert.Equal(t, "vault://secret/token", *url)
+
+	_, err = app.Parse([]string{"--token", "keychain://token"})
+	assert.NoError(t, err)
+	assert.Equal(t, "keychain://token", *token)
+
+	_, err = app.Parse([]string{"--token", "vault://missing"})
+	assert.EqualError(t, err, "could not resolve secret for --token: ***** not found")
+}
@@ -0,0 +1,177 @@
+package fisk
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var cronDescriptors = []string{"@yearly", "@annually", "@monthly", "@weekly", "@daily", "@midnight", "@hourly"}
+
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names []string
+}
+
+var (
+	cronSeconds = cronField{name: "seconds", min: 0, max: 59}
+	cronFields  = []cronField{
+		{name: "minutes", min: 0, max: 59},
+		{name: "hours", min: 0, max: 23},
+		{name: "day of month", min: 1, max: 31},
+		{name: "month", min: 1, max: 12, names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}},
+		{name: "day of week", min: 0, max: 7, names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}},
+	}
+)
+
+// ParseCronSpec validates a cron expression and returns it normalized
+//
+// Standard 5 field expressions, 6 field expressions starting with seconds,
+// descriptors like @daily and @every followed by a duration understood by
+// ParseDuration() are accepted. Fields are separated by single spaces and
+// month and day names are upper case in the normalized expression.
+func ParseCronSpec(s string) (string, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return "", fmt.Errorf("invalid cron expression: empty")
+	}
+
+	if strings.HasPrefix(fields[0], "@") {
+		return parseCronDescriptor(fields)
+	}
+
+	spec := cronFields
+	switch len(fields) {
+	case 5:
+	case 6:
+		spec = append([]cronField{cronSeconds}, cronFields...)
+	default:
+		return "", fmt.Errorf("invalid cron expression %q: expected 5 or 6 fields, got %d", s, len(fields))
+	}
+
+	for i, field := range fields {
+		v, err := spec[i].parse(field)
+		if err != nil {
+			return "", fmt.Errorf("invalid cron expression %q: %w", s, err)
+		}
+		fields[i] = v
+	}
+
+	return strings.Join(fields, " "), nil
+}
+
+func parseCronDescriptor(fields []string) (string, error) {
+	descriptor := strings.ToLower(fields[0])
+
+	if descriptor == "@every" {
+		if len(fields) != 2 {
+			return "", fmt.Errorf("invalid cron expression: @every requires a duration")
+		}
+
+		d, err := ParseDuration(fields[1])
+		if err != nil {
+			return "", fmt.Errorf("invalid cron expression: @every %s: %w", fields[1], err)
+		}
+		if d <= 0 {
+			return "", fmt.Errorf("invalid cron expression: @every requires a positive duration")
+		}
+
+		return "@every " + d.String(), nil
+	}
+
+	for _, known := range cronDescriptors {
+		if descriptor == known && len(fields) == 1 {
+			return descriptor, nil
+		}
+	}
+
+	return "", fmt.Errorf("invalid cron expression: unknown descriptor %s", strings.Join(fields, " "))
+}
+
+// parse validates a field like */5, 1-5, MON-FRI or 1,15 returning it normalized
+func (f cronField) parse(field string) (string, error) {
+	items := strings.Split(strings.ToUpper(field), ",")
+
+	for _, item := range items {
+		rng, step, hasStep := strings.Cut(item, "/")
+		if hasStep {
+			n, err := strconv.Atoi(step)
+			if err != nil || n < 1 {
+				return "", fmt.Errorf("invalid step %q in %s", step, f.name)
+			}
+		}
+
+		if rng == "*" || (rng == "?" && (f.name == "day of month" || f.name == "day of week")) {
+			continue
+		}
+
+		low, high, isRange := strings.Cut(rng, "-")
+		lv, err := f.value(low)
+		if err != nil {
+			return "", err
+		}
+		if !isRange {
+			continue
+		}
+
+		hv, err := f.value(high)
+		if err != nil {
+			return "", err
+		}
+		if hv < lv {
+			return "", fmt.Errorf("invalid range %s in %s", rng, f.name)
+		}
+	}
+
+	return strings.Join(items, ","), nil
+}
+
+func (f cronField) value(v string) (int, error) {
+	for i, name := range f.names {
+		if v == name {
+			return i + f.min, nil
+		}
+	}
+
+	n, err := strconv.Atoi(v)
+	if err != nil || n < f.min || n > f.max {
+		return 0, fmt.Errorf("invalid value %q in %s, expected %d-%d", v, f.name, f.min, f.max)
+	}
+
+	return n, nil
+}
+
+// -- cron spec Value
+type cronSpecValue string
+
+func (c *cronSpecValue) Set(s string) error {
+	v, err := ParseCronSpec(s)
+	if err != nil {
+		return err
+	}
+
+	*c = cronSpecValue(v)
+	return nil
+}
+
+func (c *cronSpecValue) Get() interface{} { return string(*c) }
+
+func (c *cronSpecValue) String() string { return string(*c) }
+
+func (c *cronSpecValue) Reset() { *c = "" }
+
+func (c *cronSpecValue) PlaceHolder() string { return "CRON" }
+
+// CronSpec validates a cron expression and stores it normalized, see ParseCronSpec()
+func (p *parserMixin) CronSpec() (target *string) {
+	target = new(string)
+	p.CronSpecVar(target)
+	return
+}
+
+// CronSpecVar validates a cron expression and stores it normalized, see ParseCronSpec()
+func (p *parserMixin) CronSpecVar(target *string) {
+	p.SetValue((*cronSpecValue)(target))
+}
@@ -0,0 +1,46 @@
+package fisk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCronSpec(t *testing.T) {
+	cases := []struct {
+		s    string
+		spec string
+		err  string
+	}{
+		{"*/5 * * * *", "*/5 * * * *", ""},
+		{"  0  9 * *   mon-fri ", "0 9 * * MON-FRI", ""},
+		{"30 0 9 1,15 jan-jun ?", "30 0 9 1,15 JAN-JUN ?", ""},
+		{"@Daily", "@daily", ""},
+		{"@every 90s", "@every 1m30s", ""},
+		{"", "", "invalid cron expression: empty"},
+		{"* * *", "", `invalid cron expression "* * *": expected 5 or 6 fields, got 3`},
+		{"60 * * * *", "", `invalid cron expression "60 * * * *": invalid value "60" in minutes, expected 0-59`},
+		{"* * * * FRI-MON", "", `invalid cron expression "* * * * FRI-MON": invalid range FRI-MON in day of week`},
+		{"*/0 * * * *", "", `invalid cron expression "*/0 * * * *": invalid step "0" in minutes`},
+		{"? * * * *", "", `invalid cron expression "? * * * *": invalid value "?" in minutes, expected 0-59`},
+		{"@every", "", "invalid cron expression: @every requires a duration"},
+		{"@every -1m", "", "invalid cron expression: @every requires a positive duration"},
+		{"@often", "", "invalid cron expression: unknown descriptor @often"},
+	}
+
+	for _, c := range cases {
+		spec, err := ParseCronSpec(c.s)
+		if c.err == "" {
+			assert.NoError(t, err, c.s)
+		} else {
+			assert.EqualError(t, err, c.err, c.s)
+		}
+		assert.Equal(t, c.spec, spec, c.s)
+	}
+
+	app := newTestApp()
+	schedule := app.Arg("schedule", "").CronSpec()
+	_, err := app.Parse([]string{"0 */2 * * sun"})
+	assert.NoError(t, err)
+	assert.Equal(t, "0 */2 * * SUN", *schedule)
+}
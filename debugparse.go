@@ -0,0 +1,111 @@
+package fisk
+
+import (
+	"fmt"
+	"io"
+)
+
+const debugParseFlag = "fisk-debug-parse"
+
+// wantsParseDebug determines if the parse debug flag is on the command line,
+// this has to be known before parsing starts so tokens can be recorded
+func wantsParseDebug(args []string) bool {
+	for _, arg := range args {
+		if arg == "--" {
+			return false
+		}
+		if arg == "--"+debugParseFlag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// writeParseDebug dumps the tokens, matched elements and applied defaults of a context
+func (a *Application) writeParseDebug(w io.Writer, context *ParseContext) {
+	matched := map[interface{}]bool{}
+	secrets := map[string]bool{}
+
+	for _, element := range context.Elements {
+		matched[element.Clause] = true
+		if element.Value != nil && isSecretClause(element.Clause) {
+			secrets[*element.Value] = true
+		}
+	}
+
+	for _, token := range context.tokens {
+		value := token.Value
+		if token.Type == TokenArg && secrets[value] {
+			value = redactedValue
+		}
+		fmt.Fprintf(w, "%s: token   %3d %-11s %q\n", debugParseFlag, token.Index, token.Type, value)
+	}
+
+	for _, element := range context.Elements {
+		value := ""
+		if element.Value != nil {
+			value = *element.Value
+		}
+		if isSecretClause(element.Clause) {
+			value = redactedValue
+		}
+
+		switch clause := element.Clause.(type) {
+		case *CmdClause:
+			fmt.Fprintf(w, "%s: element command %s (command line)\n", debugParseFlag, clause.FullCommand())
+		case *FlagClause:
+			fmt.Fprintf(w, "%s: element flag --%s=%q (command line)\n", debugParseFlag, clause.name, value)
+		case *ArgClause:
+			fmt.Fprintf(w, "%s: element arg %s=%q (command line)\n", debugParseFlag, clause.name, value)
+		}
+	}
+
+	for _, flag := range context.flags.flagOrder {
+		if matched[flag] {
+			continue
+		}
+		if source := defaultSource(flag.envar, flag.HasEnvarValue(), flag.hasDefault()); source != "" {
+			fmt.Fprintf(w, "%s: default flag --%s=%q (%s)\n", debugParseFlag, flag.name, debugValue(flag.value, flag.secret), source)
+		}
+	}
+
+	for _, arg := range context.arguments.args {
+		if matched[arg] {
+			continue
+		}
+		if source := defaultSource(arg.envar, arg.HasEnvarValue(), arg.hasDefault()); source != "" {
+			fmt.Fprintf(w, "%s: default arg %s=%q (%s)\n", debugParseFlag, arg.name, debugValue(arg.value, arg.secret), source)
+		}
+	}
+}
+
+func isSecretClause(clause interface{}) bool {
+	switch c := clause.(type) {
+	case *FlagClause:
+		return c.secret
+	case *ArgClause:
+		return c.secret
+	}
+
+	return false
+}
+
+func defaultSource(envar string, hasEnvar bool, hasDefault bool) string {
+	switch {
+	case hasEnvar:
+		return "environment $" + envar
+	case hasDefault:
+		return "default"
+	}
+
+	return ""
+}
+
+func debugValue(v Value, secret bool) string {
+	if secret {
+		return redactedValue
+	}
+
+	return v.String()
+}
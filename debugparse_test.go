@@ -0,0 +1,46 @@
+package fisk
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebugParse(t *testing.T) {
+	os.Setenv("TEST_DEBUG_PARSE_REPLICAS", "3")
+	defer os.Unsetenv("TEST_DEBUG_PARSE_REPLICAS")
+
+	buf := &bytes.Buffer{}
+	app := newTestApp().ErrorWriter(buf)
+	add := app.Command("add", "")
+	add.Flag("server", "").Short('s').String()
+	add.Flag("token", "").Secret().String()
+	add.Flag("replicas", "").Envar("TEST_DEBUG_PARSE_REPLICAS").Int()
+	add.Flag("retention", "").Default("1h").Duration()
+	add.Arg("name", "").String()
+
+	_, err := app.Parse([]string{"add", "-sexample.net", "--token=s3cret", "ORDERS", "--fisk-debug-parse"})
+	assert.NoError(t, err)
+
+	out := buf.String()
+	assert.Contains(t, out, `fisk-debug-parse: token     1 argument    "add"`)
+	assert.Contains(t, out, `fisk-debug-parse: token     2 short flag  "s"`)
+	assert.Contains(t, out, `fisk-debug-parse: token     2 argument    "example.net"`)
+	assert.Contains(t, out, `fisk-debug-parse: token     3 argument    "*****"`)
+	assert.Contains(t, out, `fisk-debug-parse: element command add (command line)`)
+	assert.Contains(t, out, `fisk-debug-parse: element flag --server="example.net" (command line)`)
+	assert.Contains(t, out, `fisk-debug-parse: element flag --token="*****" (command line)`)
+	assert.Contains(t, out, `fisk-debug-parse: element arg name="ORDERS" (command line)`)
+	assert.Contains(t, out, `fisk-debug-parse: default flag --replicas="3" (environment $TEST_DEBUG_PARSE_REPLICAS)`)
+	assert.Contains(t, out, `fisk-debug-parse: default flag --retention="1h0m0s" (default)`)
+	assert.NotContains(t, out, "s3cret")
+
+	buf.Reset()
+	app = newTestApp().ErrorWriter(buf)
+	app.Arg("name", "").String()
+	_, err = app.Parse([]string{"--", "--fisk-debug-parse"})
+	assert.NoError(t, err)
+	assert.Empty(t, buf.String())
+}
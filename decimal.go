@@ -0,0 +1,85 @@
+package fisk
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// -- arbitrary precision decimal Value
+type decimalValue struct {
+	v   *big.Rat
+	min *big.Rat
+	max *big.Rat
+}
+
+func (d *decimalValue) Set(s string) error {
+	v, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return fmt.Errorf("invalid decimal %q", s)
+	}
+
+	if d.min != nil && v.Cmp(d.min) < 0 {
+		return fmt.Errorf("%s is less than the minimum %s", formatDecimal(v), formatDecimal(d.min))
+	}
+	if d.max != nil && v.Cmp(d.max) > 0 {
+		return fmt.Errorf("%s is more than the maximum %s", formatDecimal(v), formatDecimal(d.max))
+	}
+
+	d.v.Set(v)
+	return nil
+}
+
+func (d *decimalValue) Get() interface{} { return d.v }
+
+func (d *decimalValue) String() string { return formatDecimal(d.v) }
+
+func (d *decimalValue) Reset() { d.v.SetInt64(0) }
+
+// formatDecimal formats r exactly when it is a terminating decimal, else with 20 decimal places
+func formatDecimal(r *big.Rat) string {
+	if r == nil {
+		return ""
+	}
+	if r.IsInt() {
+		return r.RatString()
+	}
+
+	ten := big.NewInt(10)
+	scaled := new(big.Rat).Set(r)
+	for places := 1; places <= 20; places++ {
+		scaled.Mul(scaled, new(big.Rat).SetInt(ten))
+		if scaled.IsInt() {
+			return r.FloatString(places)
+		}
+	}
+
+	return r.FloatString(20)
+}
+
+// Decimal parses an arbitrary precision decimal like 10.25 without the
+// rounding of float64, suitable for money or quotas
+func (p *parserMixin) Decimal() (target *big.Rat) {
+	target = new(big.Rat)
+	p.DecimalVar(target)
+	return
+}
+
+// DecimalVar parses an arbitrary precision decimal like 10.25 without the
+// rounding of float64, suitable for money or quotas
+func (p *parserMixin) DecimalVar(target *big.Rat) {
+	p.SetValue(&decimalValue{v: target})
+}
+
+// DecimalBetween parses an arbitrary precision decimal that must be between
+// min and max inclusive, a nil min or max is unbounded
+func (p *parserMixin) DecimalBetween(min *big.Rat, max *big.Rat) (target *big.Rat) {
+	target = new(big.Rat)
+	p.DecimalBetweenVar(target, min, max)
+	return
+}
+
+// DecimalBetweenVar parses an arbitrary precision decimal that must be
+// between min and max inclusive, a nil min or max is unbounded
+func (p *parserMixin) DecimalBetweenVar(target *big.Rat, min *big.Rat, max *big.Rat) {
+	p.SetValue(&decimalValue{v: target, min: min, max: max})
+}
@@ -0,0 +1,35 @@
+package fisk
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecimal(t *testing.T) {
+	app := newTestApp()
+	price := app.Flag("price", "").Decimal()
+	quota := app.Arg("quota", "").DecimalBetween(big.NewRat(1, 10), big.NewRat(100, 1))
+
+	_, err := app.Parse([]string{"--price", "0.1", "0.2"})
+	assert.NoError(t, err)
+	sum := new(big.Rat).Add(price, quota)
+	assert.Equal(t, "0.3", formatDecimal(sum))
+	assert.Equal(t, 0, sum.Cmp(big.NewRat(3, 10)))
+
+	_, err = app.Parse([]string{"--price", "12345678901234567890.123456789", "100"})
+	assert.NoError(t, err)
+	assert.Equal(t, "12345678901234567890.123456789", formatDecimal(price))
+
+	_, err = app.Parse([]string{"--price", "ten", "1"})
+	assert.EqualError(t, err, `invalid decimal "ten"`)
+
+	_, err = app.Parse([]string{"0.05"})
+	assert.EqualError(t, err, "0.05 is less than the minimum 0.1")
+
+	_, err = app.Parse([]string{"100.5"})
+	assert.EqualError(t, err, "100.5 is more than the maximum 100")
+
+	assert.Equal(t, "0.33333333333333333333", formatDecimal(big.NewRat(1, 3)))
+}
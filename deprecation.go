@@ -0,0 +1,139 @@
+package fisk
+
+import (
+	"fmt"
+	"strings"
+)
+
+// deprecationMixin holds the deprecation details of commands and flags
+type deprecationMixin struct {
+	deprecated string
+	removedIn  string
+}
+
+// deprecationNotice describes the deprecation for warnings, empty when not deprecated
+func (d *deprecationMixin) deprecationNotice(kind string, name string) string {
+	if d.deprecated == "" && d.removedIn == "" {
+		return ""
+	}
+
+	notice := fmt.Sprintf("%s %s is deprecated", kind, name)
+	if d.removedIn != "" {
+		notice += " and will be removed in " + d.removedIn
+	}
+	if d.deprecated != "" {
+		notice += ": " + d.deprecated
+	}
+
+	return notice
+}
+
+// deprecationSuffix is the annotation shown in help, like " (deprecated, removed in 2.0.0)"
+func deprecationSuffix(deprecated string, removedIn string) string {
+	switch {
+	case removedIn != "":
+		return fmt.Sprintf(" (deprecated, removed in %s)", removedIn)
+	case deprecated != "":
+		return " (deprecated)"
+	default:
+		return ""
+	}
+}
+
+// Deprecated marks the command as deprecated, a warning including message,
+// like "use 'stream edit' instead", is shown when it is used
+func (c *CmdClause) Deprecated(message string) *CmdClause {
+	c.deprecated = message
+	return c
+}
+
+// RemovedIn marks the command as deprecated and scheduled for removal in version
+func (c *CmdClause) RemovedIn(version string) *CmdClause {
+	c.removedIn = version
+	return c
+}
+
+// Deprecated marks the flag as deprecated, a warning including message,
+// like "use --timeout instead", is shown when it is used
+func (f *FlagClause) Deprecated(message string) *FlagClause {
+	f.deprecated = message
+	return f
+}
+
+// RemovedIn marks the flag as deprecated and scheduled for removal in version
+func (f *FlagClause) RemovedIn(version string) *FlagClause {
+	f.removedIn = version
+	return f
+}
+
+// warnDeprecated warns about deprecated commands and flags given by the user
+func (a *Application) warnDeprecated(context *ParseContext) {
+	warned := map[interface{}]bool{}
+
+	for _, element := range context.Elements {
+		if warned[element.Clause] {
+			continue
+		}
+
+		var notice string
+		switch clause := element.Clause.(type) {
+		case *CmdClause:
+			notice = clause.deprecationNotice("command", "'"+clause.FullCommand()+"'")
+		case *FlagClause:
+			notice = clause.deprecationNotice("flag", "--"+clause.name)
+		}
+
+		if notice != "" {
+			fmt.Fprintf(a.errorWriterFor(context), "%s: warning: %s\n", a.Name, notice)
+			warned[element.Clause] = true
+		}
+	}
+}
+
+// Deprecation is a deprecated command or flag, see ApplicationModel.Deprecations()
+type Deprecation struct {
+	// Command is the full command, or the command the flag belongs to, empty for application flags
+	Command string `json:"command,omitempty"`
+	// Flag is the name of the deprecated flag, empty for commands
+	Flag      string `json:"flag,omitempty"`
+	Message   string `json:"message,omitempty"`
+	RemovedIn string `json:"removed_in,omitempty"`
+}
+
+// Deprecations lists every deprecated command and flag, for example so
+// release tooling can find everything scheduled for removal
+func (a *ApplicationModel) Deprecations() []Deprecation {
+	var found []Deprecation
+
+	addFlags := func(command string, flags *FlagGroupModel) {
+		if flags == nil {
+			return
+		}
+		for _, f := range flags.Flags {
+			if f.Deprecated != "" || f.RemovedIn != "" {
+				found = append(found, Deprecation{Command: command, Flag: f.Name, Message: f.Deprecated, RemovedIn: f.RemovedIn})
+			}
+		}
+	}
+
+	addFlags("", a.FlagGroupModel)
+
+	var walk func(path []string, cmds *CmdGroupModel)
+	walk = func(path []string, cmds *CmdGroupModel) {
+		if cmds == nil {
+			return
+		}
+		for _, cmd := range cmds.Commands {
+			cmdPath := append(append([]string{}, path...), cmd.Name)
+			command := strings.Join(cmdPath, " ")
+			if cmd.Deprecated != "" || cmd.RemovedIn != "" {
+				found = append(found, Deprecation{Command: command, Message: cmd.Deprecated, RemovedIn: cmd.RemovedIn})
+			}
+			addFlags(command, cmd.FlagGroupModel)
+			walk(cmdPath, cmd.CmdGroupModel)
+		}
+	}
+	walk(nil, a.CmdGroupModel)
+
+	return found
+}
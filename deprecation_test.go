@@ -0,0 +1,42 @@
+package fisk
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeprecation(t *testing.T) {
+	usage := &bytes.Buffer{}
+	errs := &bytes.Buffer{}
+	app := newTestApp()
+	app.UsageWriter(usage).ErrorWriter(errs)
+	app.Flag("server", "Server to use").Deprecated("use --context instead").Strings()
+	stream := app.Command("stream", "Stream commands")
+	stream.Command("copy", "Copies a stream").Deprecated("use 'stream clone' instead").RemovedIn("2.0.0")
+	stream.Command("clone", "Clones a stream").Flag("force", "Force cloning").RemovedIn("3.0.0").Bool()
+
+	app.Usage([]string{"stream"})
+	assert.Contains(t, usage.String(), "stream copy (deprecated, removed in 2.0.0)")
+
+	usage.Reset()
+	app.Usage([]string{"stream", "clone"})
+	assert.Contains(t, usage.String(), "Force cloning (deprecated, removed in 3.0.0)")
+	assert.Contains(t, usage.String(), "Server to use (deprecated)")
+
+	_, err := app.Parse([]string{"stream", "copy", "--server", "a", "--server", "b"})
+	assert.NoError(t, err)
+	assert.Equal(t, "test: warning: command 'stream copy' is deprecated and will be removed in 2.0.0: use 'stream clone' instead\ntest: warning: flag --server is deprecated: use --context instead\n", errs.String())
+
+	errs.Reset()
+	_, err = app.Parse([]string{"stream", "clone"})
+	assert.NoError(t, err)
+	assert.Empty(t, errs.String())
+
+	assert.Equal(t, []Deprecation{
+		{Flag: "server", Message: "use --context instead"},
+		{Command: "stream copy", Message: "use 'stream clone' instead", RemovedIn: "2.0.0"},
+		{Command: "stream clone", Flag: "force", RemovedIn: "3.0.0"},
+	}, app.Model().Deprecations())
+}
@@ -0,0 +1,235 @@
+package fisk
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// docFormats are the artifacts the docs command can generate
+var docFormats = []string{"man", "markdown", "html", "org", "completion", "cheats", "aliases"}
+
+// WithDocsCommand adds a docs command that writes the man page, markdown, HTML
+// and org-mode references, shell completion scripts, cheats and shell aliases to a directory in one pass,
+// intended to be run while packaging the application
+func (a *Application) WithDocsCommand() *Application {
+	var (
+		dir     string
+		formats EnumSet
+	)
+
+	a.DocsCommand = a.Commandf("docs", "Generates documentation for %s", a.Name).Action(func(_ *ParseContext) error {
+		return a.writeDocs(dir, formats.Members())
+	})
+	a.DocsCommand.Flag("dir", "Directory to write the documentation to").Default(".").PlaceHolder("DIRECTORY").StringVar(&dir)
+	a.DocsCommand.Flag("formats", "The formats to generate").Default(strings.Join(docFormats, ",")).EnumSetVar(&formats, docFormats...)
+
+	return a
+}
+
+// docsLayout are the paths, relative to the output directory, documentation is written to
+type docsLayout struct {
+	man          string
+	markdown     string
+	html         string
+	org          string
+	bash         string
+	zsh          string
+	fish         string
+	bashNotFound string
+	zshNotFound  string
+	cheats       string
+	aliases      string
+}
+
+// flatDocsLayout writes everything to one directory, used by the docs command
+func flatDocsLayout(name string) docsLayout {
+	return docsLayout{
+		man:          name + ".1",
+		markdown:     name + ".md",
+		html:         name + ".html",
+		org:          name + ".org",
+		bash:         filepath.Join("completion", name+".bash"),
+		zsh:          filepath.Join("completion", "_"+name),
+		fish:         filepath.Join("completion", name+".fish"),
+		bashNotFound: filepath.Join("completion", name+"-command-not-found.bash"),
+		zshNotFound:  filepath.Join("completion", name+"-command-not-found.zsh"),
+		cheats:       "cheats",
+		aliases:      name + "-aliases.sh",
+	}
+}
+
+// packageDocsLayout follows the share directory conventions of rpm, deb and
+// homebrew packages, used by GenerateArtifacts()
+func packageDocsLayout(name string) docsLayout {
+	return docsLayout{
+		man:          filepath.Join("share", "man", "man1", name+".1"),
+		markdown:     filepath.Join("share", "doc", name, name+".md"),
+		html:         filepath.Join("share", "doc", name, name+".html"),
+		org:          filepath.Join("share", "doc", name, name+".org"),
+		bash:         filepath.Join("share", "bash-completion", "completions", name),
+		zsh:          filepath.Join("share", "zsh", "site-functions", "_"+name),
+		fish:         filepath.Join("share", "fish", "vendor_completions.d", name+".fish"),
+		bashNotFound: filepath.Join("share", name, name+"-command-not-found.bash"),
+		zshNotFound:  filepath.Join("share", name, name+"-command-not-found.zsh"),
+		cheats:       filepath.Join("share", name, "cheats"),
+		aliases:      filepath.Join("share", name, name+"-aliases.sh"),
+	}
+}
+
+func (a *Application) writeDocs(dir string, formats []string) error {
+	return a.writeDocsLayout(dir, formats, flatDocsLayout(a.Name))
+}
+
+func (a *Application) writeDocsLayout(dir string, formats []string, layout docsLayout) error {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+
+	for _, format := range formats {
+		switch format {
+		case "man":
+			err = a.writeTemplateFile(filepath.Join(dir, layout.man), ManPageTemplate)
+
+		case "markdown":
+			err = a.writeTemplateFile(filepath.Join(dir, layout.markdown), MarkdownTemplate)
+
+		case "html":
+			err = a.writeTemplateFile(filepath.Join(dir, layout.html), HTMLTemplate)
+
+		case "org":
+			err = a.writeTemplateFile(filepath.Join(dir, layout.org), OrgTemplate)
+
+		case "completion":
+			for _, script := range [][2]string{
+				{layout.bash, BashCompletionTemplate},
+				{layout.zsh, ZshCompletionTemplate},
+				{layout.fish, FishCompletionTemplate},
+				{layout.bashNotFound, BashCommandNotFoundTemplate},
+				{layout.zshNotFound, ZshCommandNotFoundTemplate},
+			} {
+				err = a.writeTemplateFile(filepath.Join(dir, script[0]), script[1])
+				if err != nil {
+					break
+				}
+			}
+
+		case "cheats":
+			// not every application has cheats, only fail when asked for nothing else
+			if len(a.cheats) > 0 || len(formats) == 1 {
+				err = a.saveCheats(filepath.Join(dir, layout.cheats))
+			}
+
+		case "aliases":
+			if len(a.shortcuts) > 0 {
+				err = a.writeAliasesFile(filepath.Join(dir, layout.aliases))
+			}
+
+		default:
+			err = fmt.Errorf("unknown documentation format %q", format)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a *Application) writeAliasesFile(file string) error {
+	err := os.MkdirAll(filepath.Dir(file), 0755)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	err = a.WriteShellAliases(f)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.usageWriter, "Saved documentation to %s\n", file)
+
+	return f.Close()
+}
+
+// writeTemplateFile renders the complete application using tmpl into file
+func (a *Application) writeTemplateFile(file string, tmpl string) error {
+	err := a.renderTemplateFile(file, tmpl)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.usageWriter, "Saved documentation to %s\n", file)
+
+	return nil
+}
+
+// renderTemplateFile renders tmpl for the application into file, creating its directory
+func (a *Application) renderTemplateFile(file string, tmpl string) error {
+	err := os.MkdirAll(filepath.Dir(file), 0755)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := a.usageWriter
+	a.usageWriter = f
+	defer func() { a.usageWriter = w }()
+
+	err = a.UsageForContextWithTemplate(&ParseContext{app: a, flags: a.flagGroup, arguments: a.argGroup}, 2, tmpl)
+	if err != nil {
+		return err
+	}
+
+	return f.Close()
+}
+
+// ArtifactOptions configures GenerateArtifacts()
+type ArtifactOptions struct {
+	// Formats are the artifacts to generate using the names accepted by the
+	// --formats flag of the docs command, all formats when empty
+	Formats []string
+	// Progress receives a line for every file written, nothing is shown when nil
+	Progress io.Writer
+}
+
+// GenerateArtifacts writes the man page, shell completions, command not found
+// handlers, markdown, HTML and org-mode references, cheats and shell aliases
+// to dir in the layout used by rpm, deb and homebrew packages, for example
+// share/man/man1/<name>.1 and share/bash-completion/completions/<name>
+func (a *Application) GenerateArtifacts(dir string, opts ArtifactOptions) error {
+	if err := a.lockedInit(); err != nil {
+		return err
+	}
+
+	formats := opts.Formats
+	if len(formats) == 0 {
+		formats = docFormats
+	}
+
+	progress := opts.Progress
+	if progress == nil {
+		progress = io.Discard
+	}
+
+	w := a.usageWriter
+	a.usageWriter = progress
+	defer func() { a.usageWriter = w }()
+
+	return a.writeDocsLayout(dir, formats, packageDocsLayout(a.Name))
+}
@@ -0,0 +1,97 @@
+package fisk
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocsCommand(t *testing.T) {
+	dir := t.TempDir()
+	out := &bytes.Buffer{}
+
+	app := newTestApp().WithDocsCommand().WithCheats()
+	app.UsageWriter(out)
+	app.Flag("server", "The server to connect to").Short('s').String()
+	add := app.Command("add", "Adds a thing").Cheat("add", "fisk add x")
+	add.Arg("name", "The name").Required().String()
+	add.Example("fisk add x", "Adds x")
+	app.Command("secret", "Hidden").Hidden()
+
+	_, err := app.Parse([]string{"docs", "--dir", dir})
+	assert.NoError(t, err)
+
+	for _, f := range []string{"test.1", "test.md", "test.html", "test.org", "completion/test.bash", "completion/_test", "completion/test.fish", "completion/test-command-not-found.bash", "completion/test-command-not-found.zsh", "cheats/add"} {
+		assert.FileExists(t, filepath.Join(dir, f))
+	}
+
+	md, err := os.ReadFile(filepath.Join(dir, "test.md"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(md), "* `-s, --server=SERVER` The server to connect to")
+	assert.Contains(t, string(md), "### add\n\nAdds a thing\n\n```\ntest add <name>\n```")
+	assert.Contains(t, string(md), "#### Examples\n\nAdds x\n\n```\nfisk add x\n```")
+	assert.NotContains(t, string(md), "secret")
+	assert.NotContains(t, string(md), "### help")
+	assert.Contains(t, out.String(), "Saved documentation to "+filepath.Join(dir, "test.md"))
+
+	page, err := os.ReadFile(filepath.Join(dir, "test.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(page), `<li><a href="#command-add">add</a> Adds a thing</li>`)
+	assert.Contains(t, string(page), `<h3 id="command-add">add</h3>`)
+	assert.Contains(t, string(page), "<pre>test add &lt;name&gt;</pre>")
+	assert.NotContains(t, string(page), "secret")
+
+	org, err := os.ReadFile(filepath.Join(dir, "test.org"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(org), "*** add\n:PROPERTIES:\n:CUSTOM_ID: command-add\n:END:\n\nAdds a thing\n")
+	assert.Contains(t, string(org), "- ~-s, --server=SERVER~ The server to connect to")
+
+	dir = t.TempDir()
+	app.Reset()
+	_, err = app.Parse([]string{"docs", "--dir", dir, "--formats", "markdown"})
+	assert.NoError(t, err)
+	assert.FileExists(t, filepath.Join(dir, "test.md"))
+	assert.NoFileExists(t, filepath.Join(dir, "test.1"))
+}
+
+func TestGenerateArtifacts(t *testing.T) {
+	dir := t.TempDir()
+	progress := &bytes.Buffer{}
+	usage := &bytes.Buffer{}
+
+	app := newTestApp().UsageWriter(usage).WithCheats().Shortcut("ta", "add")
+	app.Command("add", "Adds a thing").Cheat("add", "test add x")
+
+	err := app.GenerateArtifacts(dir, ArtifactOptions{Progress: progress})
+	assert.NoError(t, err)
+
+	for _, f := range []string{
+		"share/man/man1/test.1",
+		"share/doc/test/test.md",
+		"share/doc/test/test.html",
+		"share/doc/test/test.org",
+		"share/bash-completion/completions/test",
+		"share/zsh/site-functions/_test",
+		"share/fish/vendor_completions.d/test.fish",
+		"share/test/test-command-not-found.bash",
+		"share/test/test-command-not-found.zsh",
+		"share/test/cheats/add",
+		"share/test/test-aliases.sh",
+	} {
+		assert.FileExists(t, filepath.Join(dir, f))
+		assert.Contains(t, progress.String(), filepath.Join(dir, f))
+	}
+	assert.Empty(t, usage.String())
+
+	dir = t.TempDir()
+	err = app.GenerateArtifacts(dir, ArtifactOptions{Formats: []string{"man"}})
+	assert.NoError(t, err)
+	assert.FileExists(t, filepath.Join(dir, "share/man/man1/test.1"))
+	assert.NoDirExists(t, filepath.Join(dir, "share/doc"))
+
+	err = app.GenerateArtifacts(dir, ArtifactOptions{Formats: []string{"pdf"}})
+	assert.EqualError(t, err, `unknown documentation format "pdf"`)
+}
@@ -0,0 +1,76 @@
+package fisk
+
+import (
+	"strings"
+)
+
+// DryRunResult is the outcome of ParseDryRun()
+type DryRunResult struct {
+	// Command is the selected command, space separated
+	Command string
+	// Flags are the resolved values of all flags in scope for the selected command,
+	// values of Secret() flags are masked and command or secret references are not resolved
+	Flags map[string]string
+	// Args are the resolved values of all arguments in scope for the selected command,
+	// values of Secret() arguments are masked
+	Args map[string]string
+	// Context is the read only parse context the values were resolved from
+	Context *ParseContext
+}
+
+// ParseDryRun resolves args like Resolve() and reports the values Parse() would
+// set, it never writes to the bound variables or runs any PreActions, Actions,
+// prompts or editors.
+//
+// Commands for AllowCommandValues() flags and SecretResolver() lookups are
+// not run, those values are reported as given. Values are reported as resolved
+// rather than formatted by their type and cumulative values are joined by commas.
+func (a *Application) ParseDryRun(args []string) (*DryRunResult, error) {
+	resolved, err := a.Resolve(args)
+	if err != nil && resolved == nil {
+		return nil, err
+	}
+
+	result := &DryRunResult{
+		Command: resolved.Command,
+		Flags:   map[string]string{},
+		Args:    map[string]string{},
+		Context: resolved.Context,
+	}
+
+	if err != nil {
+		return result, err
+	}
+
+	// command and application validators see the bound variables as they were before
+	if err = a.applyValidators(resolved.Context); err != nil {
+		return nil, err
+	}
+
+	for _, flag := range resolved.Context.flags.long {
+		if ignoreInCount[flag.name] || flag.value == nil {
+			continue
+		}
+
+		result.Flags[flag.name] = dryRunValue(resolved.FlagValues(flag), flag.secret)
+	}
+
+	for _, arg := range resolved.Context.arguments.args {
+		if arg.value == nil {
+			continue
+		}
+
+		result.Args[arg.name] = dryRunValue(resolved.ArgValues(arg), arg.secret)
+	}
+
+	return result, nil
+}
+
+func dryRunValue(values []string, secret bool) string {
+	value := strings.Join(values, ",")
+	if secret && value != "" {
+		return redactedValue
+	}
+
+	return value
+}
@@ -0,0 +1,82 @@
+package fisk
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDryRun(t *testing.T) {
+	os.Setenv("TEST_DRY_RUN_REPLICAS", "3")
+	defer os.Unsetenv("TEST_DRY_RUN_REPLICAS")
+
+	ran := false
+	app := newTestApp()
+	app.PreAction(func(*ParseContext) error { ran = true; return nil })
+	add := app.Command("add", "").Action(func(*ParseContext) error { ran = true; return nil })
+	add.Flag("replicas", "").Envar("TEST_DRY_RUN_REPLICAS").Int()
+	add.Flag("retention", "").Default("1h").PreAction(func(*ParseContext) error { ran = true; return nil }).Duration()
+	add.Arg("name", "").Required().String()
+	app.Command("rm", "")
+
+	res, err := app.ParseDryRun([]string{"add", "ORDERS"})
+	assert.NoError(t, err)
+	assert.False(t, ran)
+	assert.Equal(t, "add", res.Command)
+	assert.Equal(t, "3", res.Flags["replicas"])
+	assert.Equal(t, "1h", res.Flags["retention"])
+	assert.Equal(t, "ORDERS", res.Args["name"])
+	assert.NotContains(t, res.Flags, "help")
+
+	_, err = app.ParseDryRun([]string{"add"})
+	assert.Error(t, err)
+
+	_, err = app.ParseDryRun([]string{})
+	assert.ErrorIs(t, err, ErrCommandNotSpecified)
+
+	app.Validate(func(*Application) error { return fmt.Errorf("invalid") })
+	_, err = app.ParseDryRun([]string{"rm"})
+	assert.EqualError(t, err, "invalid")
+	assert.False(t, ran)
+}
+
+func TestParseDryRunSecrets(t *testing.T) {
+	// resolving would fail as the resolver holds no secrets and false exits 1
+	app := newTestApp().SecretResolver("vault", mapSecretResolver{})
+	app.Flag("token", "").Secret().String()
+	app.Flag("password", "").Default("vault://secret/password").Secret().String()
+	app.Flag("user", "").AllowCommandValues().String()
+	app.Arg("key", "").Secret().String()
+
+	res, err := app.ParseDryRun([]string{"--token", "s3cret", "--user", "cmd://false", "k3y"})
+	assert.NoError(t, err)
+	assert.Equal(t, "*****", res.Flags["token"])
+	assert.Equal(t, "*****", res.Flags["password"])
+	assert.Equal(t, "cmd://false", res.Flags["user"])
+	assert.Equal(t, "*****", res.Args["key"])
+}
+
+func TestParseDryRunDoesNotSetValues(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "false")
+
+	app := newTestApp()
+	replicas := app.Flag("replicas", "").Default("1").Int()
+	subjects := app.Flag("subject", "").Strings()
+	body := app.Flag("body", "").EditorValue("")
+	name := app.Arg("name", "").Required().String()
+
+	res, err := app.ParseDryRun([]string{"--replicas", "3", "--subject", "a", "--subject", "b", "--body", EditorMarker, "ORDERS"})
+	assert.NoError(t, err)
+	assert.Equal(t, "3", res.Flags["replicas"])
+	assert.Equal(t, "a,b", res.Flags["subject"])
+	assert.Equal(t, EditorMarker, res.Flags["body"])
+	assert.Equal(t, "ORDERS", res.Args["name"])
+
+	assert.Equal(t, 0, *replicas)
+	assert.Empty(t, *subjects)
+	assert.Empty(t, *body)
+	assert.Empty(t, *name)
+}
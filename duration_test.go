@@ -21,6 +21,18 @@ func TestDurationParser(t *testing.T) {
 		{"1xX", 0, fmt.Errorf("%w: invalid unit xX", errInvalidDuration)},
 		{"-1", 0, fmt.Errorf("invalid duration")},
 		{"0", 0, nil},
+		{"1.005s", 1005 * time.Millisecond, nil},
+		{"0.29h", 1044 * time.Second, nil},
+		{"1.5ms2ns", 1500*time.Microsecond + 2*time.Nanosecond, nil},
+		{"1..5s", 0, fmt.Errorf("%w: invalid number 1..5", errInvalidDuration)},
+		{"PT1H30M", 90 * time.Minute, nil},
+		{"P2DT3H", 51 * time.Hour, nil},
+		{"-P1W", -7 * 24 * time.Hour, nil},
+		{"P1Y2M", (365 + 60) * 24 * time.Hour, nil},
+		{"PT0.5S", 500 * time.Millisecond, nil},
+		{"PT1,5M", 90 * time.Second, nil},
+		{"P", 0, fmt.Errorf("%w: incomplete ISO-8601 duration", errInvalidDuration)},
+		{"P1DT", 0, fmt.Errorf("%w: incomplete ISO-8601 duration", errInvalidDuration)},
 	}
 
 	for _, c := range cases {
@@ -33,3 +45,29 @@ func TestDurationParser(t *testing.T) {
 		assert.Equal(t, c.d, d, c.s)
 	}
 }
+
+func TestDurationUnits(t *testing.T) {
+	units := NewDurationUnits().Add("q", 91*24*time.Hour).Add("bd", 24*time.Hour).MinutesForM().Remove("Y")
+
+	d, err := units.Parse("1q2bd")
+	assert.NoError(t, err)
+	assert.Equal(t, 93*24*time.Hour, d)
+
+	d, err = units.Parse("90M")
+	assert.NoError(t, err)
+	assert.Equal(t, 90*time.Minute, d)
+
+	d, err = units.Parse("1µs")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Microsecond, d)
+
+	_, err = units.Parse("1Y")
+	assert.EqualError(t, err, "invalid duration: invalid unit Y")
+
+	d, err = ParseDuration("1M")
+	assert.NoError(t, err)
+	assert.Equal(t, 30*24*time.Hour, d)
+
+	_, err = ParseDuration("1q")
+	assert.EqualError(t, err, "invalid duration: invalid unit q")
+}
@@ -2,17 +2,88 @@ package fisk
 
 import (
 	"fmt"
+	"math/big"
 	"regexp"
-	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
 var (
-	durationMatcher    = regexp.MustCompile(`([-+]?)(([\d\.]+)([a-zA-Z]+))`)
+	durationMatcher    = regexp.MustCompile(`([-+]?)(([\d\.]+)(\pL+))`)
+	isoDurationMatcher = regexp.MustCompile(`^([-+]?)P(?:([\d.,]+)Y)?(?:([\d.,]+)M)?(?:([\d.,]+)W)?(?:([\d.,]+)D)?(?:T(?:([\d.,]+)H)?(?:([\d.,]+)M)?(?:([\d.,]+)S)?)?$`)
 	errInvalidDuration = fmt.Errorf("invalid duration")
 )
 
+// DurationUnits are the units understood by ParseDuration() and their lengths
+type DurationUnits struct {
+	mu    sync.Mutex
+	units map[string]time.Duration
+}
+
+// DefaultDurationUnits are the units used by ParseDuration()
+var DefaultDurationUnits = NewDurationUnits()
+
+// NewDurationUnits creates units with the go time units and the additional
+// units described in ParseDuration()
+func NewDurationUnits() *DurationUnits {
+	u := &DurationUnits{units: map[string]time.Duration{}}
+
+	for unit, length := range map[string]time.Duration{
+		"ns": time.Nanosecond,
+		"us": time.Microsecond,
+		"µs": time.Microsecond,
+		"μs": time.Microsecond,
+		"ms": time.Millisecond,
+		"s":  time.Second,
+		"m":  time.Minute,
+		"h":  time.Hour,
+		"d":  24 * time.Hour,
+		"D":  24 * time.Hour,
+		"w":  7 * 24 * time.Hour,
+		"W":  7 * 24 * time.Hour,
+		"M":  30 * 24 * time.Hour,
+		"y":  365 * 24 * time.Hour,
+		"Y":  365 * 24 * time.Hour,
+	} {
+		u.units[unit] = length
+	}
+
+	return u
+}
+
+// Add adds unit, or changes the length of an existing unit, for example
+// Add("q", 91*24*time.Hour) for quarters
+func (u *DurationUnits) Add(unit string, length time.Duration) *DurationUnits {
+	u.mu.Lock()
+	u.units[unit] = length
+	u.mu.Unlock()
+
+	return u
+}
+
+// Remove removes unit so it is no longer accepted
+func (u *DurationUnits) Remove(unit string) *DurationUnits {
+	u.mu.Lock()
+	delete(u.units, unit)
+	u.mu.Unlock()
+
+	return u
+}
+
+// MinutesForM makes M mean minutes rather than months
+func (u *DurationUnits) MinutesForM() *DurationUnits {
+	return u.Add("M", time.Minute)
+}
+
+func (u *DurationUnits) length(unit string) (time.Duration, bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	length, ok := u.units[unit]
+	return length, ok
+}
+
 // ParseDuration parse durations with additional units over those from
 // standard go parser.
 //
@@ -28,8 +99,19 @@ var (
 // * "M" - a month made of 30 days of 24 hours
 // * "y", "Y" - a year made of 365 days of 24 hours each
 //
-// Valid duration strings can be -1y1d1µs
+// Valid duration strings can be -1y1d1µs.
+//
+// ISO-8601 durations like PT1H30M, P2DT3H and -P1W are also accepted using
+// the same lengths for years, months, weeks and days.
+//
+// Units can be added or changed using DefaultDurationUnits.
 func ParseDuration(d string) (time.Duration, error) {
+	return DefaultDurationUnits.Parse(d)
+}
+
+// Parse parses d like ParseDuration() using these units, fractions of units
+// of a day or longer are truncated to whole hours
+func (u *DurationUnits) Parse(d string) (time.Duration, error) {
 	// golang time.ParseDuration has a special case for 0
 	if d == "0" {
 		return 0 * time.Second, nil
@@ -46,6 +128,10 @@ func ParseDuration(d string) (time.Duration, error) {
 		return r, errInvalidDuration
 	}
 
+	if isoDurationMatcher.MatchString(d) {
+		return parseISODuration(d)
+	}
+
 	parts := durationMatcher.FindAllStringSubmatch(d, -1)
 	if len(parts) == 0 {
 		return r, errInvalidDuration
@@ -60,50 +146,78 @@ func ParseDuration(d string) (time.Duration, error) {
 			neg = -1
 		}
 
-		switch p[4] {
-		case "w", "W":
-			val, err := strconv.ParseFloat(p[3], 32)
-			if err != nil {
-				return 0, fmt.Errorf("%w: %v", errInvalidDuration, err)
-			}
-
-			r += time.Duration(val*7*24) * time.Hour
+		length, ok := u.length(p[4])
+		if !ok {
+			return 0, fmt.Errorf("%w: invalid unit %v", errInvalidDuration, p[4])
+		}
 
-		case "d", "D":
-			val, err := strconv.ParseFloat(p[3], 32)
-			if err != nil {
-				return 0, fmt.Errorf("%w: %v", errInvalidDuration, err)
-			}
+		part, err := scaleDuration(p[3], length)
+		if err != nil {
+			return 0, err
+		}
 
-			r += time.Duration(val*24) * time.Hour
+		if length >= 24*time.Hour {
+			part = part.Truncate(time.Hour)
+		}
 
-		case "M":
-			val, err := strconv.ParseFloat(p[3], 32)
-			if err != nil {
-				return 0, fmt.Errorf("%w: %v", errInvalidDuration, err)
-			}
+		r += part
+	}
 
-			r += time.Duration(val*24*30) * time.Hour
+	return time.Duration(neg) * r, nil
+}
 
-		case "Y", "y":
-			val, err := strconv.ParseFloat(p[3], 32)
-			if err != nil {
-				return 0, fmt.Errorf("%w: %v", errInvalidDuration, err)
-			}
+// parseISODuration parses ISO-8601 durations, d must match isoDurationMatcher
+func parseISODuration(d string) (time.Duration, error) {
+	parts := isoDurationMatcher.FindStringSubmatch(d)
+	if strings.HasSuffix(d, "T") || strings.Join(parts[2:], "") == "" {
+		return 0, fmt.Errorf("%w: incomplete ISO-8601 duration", errInvalidDuration)
+	}
 
-			r += time.Duration(val*24*365) * time.Hour
+	units := []time.Duration{
+		365 * 24 * time.Hour,
+		30 * 24 * time.Hour,
+		7 * 24 * time.Hour,
+		24 * time.Hour,
+		time.Hour,
+		time.Minute,
+		time.Second,
+	}
 
-		case "ns", "us", "µs", "ms", "s", "m", "h":
-			dur, err := time.ParseDuration(p[2])
-			if err != nil {
-				return 0, fmt.Errorf("%w: %v", errInvalidDuration, err)
-			}
+	var r time.Duration
+	for i, unit := range units {
+		v := parts[i+2]
+		if v == "" {
+			continue
+		}
 
-			r += dur
-		default:
-			return 0, fmt.Errorf("%w: invalid unit %v", errInvalidDuration, p[4])
+		part, err := scaleDuration(strings.Replace(v, ",", ".", 1), unit)
+		if err != nil {
+			return 0, err
 		}
+
+		r += part
 	}
 
-	return time.Duration(neg) * r, nil
+	if parts[1] == "-" {
+		r = -r
+	}
+
+	return r, nil
+}
+
+// scaleDuration multiplies length by the decimal number v without the rounding
+// errors of floats, fractions of a nanosecond are truncated like time.ParseDuration
+func scaleDuration(v string, length time.Duration) (time.Duration, error) {
+	val, ok := new(big.Rat).SetString(v)
+	if !ok || strings.ContainsAny(v, "eE/") {
+		return 0, fmt.Errorf("%w: invalid number %v", errInvalidDuration, v)
+	}
+
+	val.Mul(val, new(big.Rat).SetInt64(int64(length)))
+	scaled := new(big.Int).Quo(val.Num(), val.Denom())
+	if !scaled.IsInt64() {
+		return 0, fmt.Errorf("%w: %v overflows", errInvalidDuration, v)
+	}
+
+	return time.Duration(scaled.Int64()), nil
 }
@@ -0,0 +1,122 @@
+package fisk
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// EditorMarker is the value that causes EditorValue() to open the users editor
+const EditorMarker = "@editor"
+
+// -- editor Value
+type editorValue struct {
+	v        *string
+	template string
+}
+
+func newEditorValue(p *string, template string) *editorValue {
+	return &editorValue{v: p, template: template}
+}
+
+func (e *editorValue) Set(s string) error {
+	if s != "" && s != EditorMarker {
+		*e.v = s
+		return nil
+	}
+
+	body, err := editInEditor(e.template)
+	if err != nil {
+		return err
+	}
+
+	*e.v = body
+	return nil
+}
+
+func (e *editorValue) Get() interface{} { return *e.v }
+
+func (e *editorValue) String() string { return *e.v }
+
+func (e *editorValue) Reset() { *e.v = "" }
+
+// EditorValue is a string value that opens the users $EDITOR when the value
+// is empty or "@editor", the editor starts with template and the saved content
+// becomes the value.
+func (p *parserMixin) EditorValue(template string) (target *string) {
+	target = new(string)
+	p.EditorValueVar(target, template)
+	return
+}
+
+// EditorValueVar is a string value that opens the users $EDITOR when the value
+// is empty or "@editor", the editor starts with template and the saved content
+// becomes the value.
+func (p *parserMixin) EditorValueVar(target *string, template string) {
+	p.SetValue(newEditorValue(target, template))
+}
+
+func editorCommand() ([]string, error) {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		if runtime.GOOS == "windows" {
+			editor = "notepad"
+		} else {
+			editor = "vi"
+		}
+	}
+
+	parts, err := splitCommandLine(editor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid editor %q: %w", editor, err)
+	}
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("no editor configured, set EDITOR")
+	}
+
+	return parts, nil
+}
+
+func editInEditor(template string) (string, error) {
+	editor, err := editorCommand()
+	if err != nil {
+		return "", err
+	}
+
+	f, err := os.CreateTemp("", "fisk-edit-*.txt")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(f.Name())
+
+	_, err = f.WriteString(template)
+	f.Close()
+	if err != nil {
+		return "", err
+	}
+
+	cmd := exec.Command(editor[0], append(editor[1:], f.Name())...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("editor failed: %w", err)
+	}
+
+	body, err := os.ReadFile(f.Name())
+	if err != nil {
+		return "", err
+	}
+
+	content := strings.TrimRight(string(body), "\r\n")
+	if strings.TrimSpace(content) == "" {
+		return "", fmt.Errorf("no content was saved in the editor")
+	}
+
+	return content, nil
+}
@@ -0,0 +1,35 @@
+package fisk
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEditorValue(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a shell script editor")
+	}
+
+	script := filepath.Join(t.TempDir(), "editor.sh")
+	assert.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\ncat \"$1\" > \"$1.tmp\"\necho 'edited body' >> \"$1.tmp\"\nmv \"$1.tmp\" \"$1\"\n"), 0700))
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", script)
+
+	app := newTestApp()
+	body := app.Flag("body", "").EditorValue("# template\n")
+	other := app.Arg("other", "").EditorValue("")
+
+	_, err := app.Parse([]string{"--body", "@editor", "given"})
+	assert.NoError(t, err)
+	assert.Equal(t, "# template\nedited body", *body)
+	assert.Equal(t, "given", *other)
+
+	_, err = app.Parse([]string{"--body=inline", ""})
+	assert.NoError(t, err)
+	assert.Equal(t, "inline", *body)
+	assert.Equal(t, "edited body", *other)
+}
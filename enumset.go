@@ -0,0 +1,111 @@
+package fisk
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EnumSet is a combination of options selected using EnumSet()
+type EnumSet struct {
+	members []string
+}
+
+// Has determines if option is in the set
+func (s *EnumSet) Has(option string) bool {
+	for _, m := range s.members {
+		if m == option {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasAll determines if every one of options is in the set
+func (s *EnumSet) HasAll(options ...string) bool {
+	for _, o := range options {
+		if !s.Has(o) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// HasAny determines if at least one of options is in the set
+func (s *EnumSet) HasAny(options ...string) bool {
+	for _, o := range options {
+		if s.Has(o) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Members are the options in the set, in the order the options were declared
+func (s *EnumSet) Members() []string {
+	return append([]string{}, s.members...)
+}
+
+// String formats the set like read|write
+func (s *EnumSet) String() string {
+	return strings.Join(s.members, "|")
+}
+
+// -- EnumSet Value
+type enumSetValue struct {
+	set     *EnumSet
+	options []string
+}
+
+func (e *enumSetValue) Set(value string) error {
+	selected := map[string]bool{}
+	for _, o := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == '|' }) {
+		o = strings.TrimSpace(o)
+		if !e.isOption(o) {
+			return fmt.Errorf("enum set value must be a combination of %s, got '%s'", strings.Join(e.options, ","), o)
+		}
+		selected[o] = true
+	}
+
+	var members []string
+	for _, o := range e.options {
+		if selected[o] {
+			members = append(members, o)
+		}
+	}
+
+	e.set.members = members
+	return nil
+}
+
+func (e *enumSetValue) isOption(value string) bool {
+	for _, o := range e.options {
+		if o == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e *enumSetValue) Get() interface{} { return e.set }
+
+func (e *enumSetValue) String() string { return e.set.String() }
+
+func (e *enumSetValue) Reset() { e.set.members = nil }
+
+func (e *enumSetValue) PlaceHolder() string { return strings.Join(e.options, "|") }
+
+// EnumSet allows a combination of options separated by commas or pipes, like read|write.
+func (p *parserMixin) EnumSet(options ...string) (target *EnumSet) {
+	target = new(EnumSet)
+	p.EnumSetVar(target, options...)
+	return
+}
+
+// EnumSetVar allows a combination of options separated by commas or pipes, like read|write.
+func (p *parserMixin) EnumSetVar(target *EnumSet, options ...string) {
+	p.SetValue(&enumSetValue{set: target, options: options})
+}
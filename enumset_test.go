@@ -0,0 +1,32 @@
+package fisk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnumSet(t *testing.T) {
+	app := newTestApp()
+	caps := app.Flag("caps", "").EnumSet("read", "write", "admin")
+
+	_, err := app.Parse([]string{"--caps", "admin|read"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"read", "admin"}, caps.Members())
+	assert.True(t, caps.Has("admin"))
+	assert.False(t, caps.Has("write"))
+	assert.True(t, caps.HasAll("read", "admin"))
+	assert.False(t, caps.HasAll("read", "write"))
+	assert.True(t, caps.HasAny("write", "read"))
+	assert.Equal(t, "read|admin", caps.String())
+
+	_, err = app.Parse([]string{"--caps", "read, write,read"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"read", "write"}, caps.Members())
+
+	_, err = app.Parse([]string{"--caps", "read|delete"})
+	assert.EqualError(t, err, "enum set value must be a combination of read,write,admin, got 'delete'")
+
+	app.Reset()
+	assert.Empty(t, caps.Members())
+}
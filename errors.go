@@ -38,4 +38,27 @@ var (
 
 	// ErrDuplicateCommand indicates that a command was defined multiple times
 	ErrDuplicateCommand = errors.New("duplicate command")
+
+	// ErrConfirmationRequired indicates a confirmation was needed but could not be asked interactively
+	ErrConfirmationRequired = errors.New("confirmation required")
 )
+
+// ExitCoder is implemented by errors that want the application to exit with a specific code
+type ExitCoder interface {
+	ExitCode() int
+}
+
+// ExitCodeFor is the code the application exits with for err, 0 when err is
+// nil, the code from the first ExitCoder in the chain of err or 1
+func ExitCodeFor(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	var coder ExitCoder
+	if errors.As(err, &coder) {
+		return coder.ExitCode()
+	}
+
+	return 1
+}
@@ -0,0 +1,60 @@
+package fisk
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	exportEnvFlag     = "fisk-export-env"
+	exportSecretsFlag = "fisk-export-secrets"
+)
+
+// writeEnvExport writes export lines for every flag in context that has an
+// environment variable and a value from the command line, environment or
+// default, including those from DefaultFunc(), secrets are masked unless unmask is set
+func (a *Application) writeEnvExport(w io.Writer, context *ParseContext, unmask bool) error {
+	given := map[*FlagClause][]string{}
+	for _, element := range context.Elements {
+		if flag, ok := element.Clause.(*FlagClause); ok && element.Value != nil {
+			given[flag] = append(given[flag], *element.Value)
+		}
+	}
+
+	for _, flag := range context.flags.flagOrder {
+		if flag.noEnvar || flag.envar == "" || flag.hidden || ignoreInCount[flag.name] {
+			continue
+		}
+
+		values, ok := given[flag]
+		switch {
+		case ok:
+		case flag.HasEnvarValue():
+			values = []string{flag.GetEnvarValue()}
+		case flag.hasDefault():
+			var err error
+			values, err = flag.defaults()
+			if err != nil {
+				return fmt.Errorf("default for --%s: %w", flag.name, err)
+			}
+		default:
+			continue
+		}
+
+		// cumulative values are split on new lines when read from the environment
+		value := strings.Join(values, "\n")
+		if flag.secret && !unmask {
+			value = redactedValue
+		}
+
+		fmt.Fprintf(w, "export %s=%s\n", flag.envar, shellQuote(value))
+	}
+
+	return nil
+}
+
+// shellQuote single quotes s for POSIX shells
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,62 @@
+package fisk
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportEnv(t *testing.T) {
+	out := &bytes.Buffer{}
+	ran := false
+
+	app := newTestApp().DefaultEnvars()
+	app.UsageWriter(out)
+	app.Flag("server", "").Default("localhost").String()
+	app.Flag("token", "").Secret().String()
+	app.Flag("unset", "").String()
+	app.Flag("subject", "").Strings()
+	app.Flag("local", "").NoEnvar().String()
+	app.Command("run", "").Action(func(*ParseContext) error { ran = true; return nil })
+	t.Setenv("TEST_TOKEN", "s3cret")
+
+	_, err := app.Parse([]string{"run", "--fisk-export-env", "--subject", "a", "--subject", "b'c", "--local", "x"})
+	assert.NoError(t, err)
+	assert.False(t, ran)
+	assert.Equal(t, "export TEST_SERVER='localhost'\nexport TEST_TOKEN='*****'\nexport TEST_SUBJECT='a\nb'\\''c'\n", out.String())
+
+	out.Reset()
+	app.Reset()
+	_, err = app.Parse([]string{"run", "--fisk-export-env", "--fisk-export-secrets"})
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "export TEST_TOKEN='s3cret'\n")
+}
+
+func TestExportEnvDefaultFunc(t *testing.T) {
+	out := &bytes.Buffer{}
+	calls := 0
+
+	app := newTestApp().DefaultEnvars()
+	app.UsageWriter(out)
+	app.Flag("server", "").DefaultFunc(func() (string, error) { calls++; return "nats://computed", nil }).String()
+
+	_, err := app.Parse([]string{"--fisk-export-env"})
+	assert.NoError(t, err)
+	assert.Equal(t, "export TEST_SERVER='nats://computed'\n", out.String())
+	assert.Greater(t, calls, 0)
+
+	out.Reset()
+	app.Reset()
+	t.Setenv("TEST_SERVER", "nats://env")
+	_, err = app.Parse([]string{"--fisk-export-env"})
+	assert.NoError(t, err)
+	assert.Equal(t, "export TEST_SERVER='nats://env'\n", out.String())
+
+	app = newTestApp().DefaultEnvars()
+	app.UsageWriter(out)
+	app.Flag("fail", "").DefaultFunc(func() (string, error) { return "", errors.New("no default") }).String()
+	_, err = app.Parse([]string{"--fisk-export-env"})
+	assert.ErrorContains(t, err, "no default")
+}
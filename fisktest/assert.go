@@ -0,0 +1,43 @@
+package fisktest
+
+import (
+	"errors"
+	"testing"
+)
+
+// AssertNoError fails t when the run failed
+func AssertNoError(t testing.TB, res Result) bool {
+	t.Helper()
+
+	if res.Err != nil {
+		t.Errorf("expected no error, got: %v", res.Err)
+		return false
+	}
+
+	return true
+}
+
+// AssertErrorIs fails t unless the run failed with an error matching target
+// using errors.Is(), for example fisk.ErrRequiredFlag
+func AssertErrorIs(t testing.TB, res Result, target error) bool {
+	t.Helper()
+
+	if !errors.Is(res.Err, target) {
+		t.Errorf("expected error matching %q, got: %v", target, res.Err)
+		return false
+	}
+
+	return true
+}
+
+// AssertExitCode fails t unless the run exited, or would exit, with code
+func AssertExitCode(t testing.TB, res Result, code int) bool {
+	t.Helper()
+
+	if res.ExitCode != code {
+		t.Errorf("expected exit code %d, got %d (error: %v)", code, res.ExitCode, res.Err)
+		return false
+	}
+
+	return true
+}
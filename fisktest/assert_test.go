@@ -0,0 +1,39 @@
+package fisktest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/choria-io/fisk"
+	"github.com/stretchr/testify/assert"
+)
+
+type exitError struct{ code int }
+
+func (e exitError) Error() string { return "exit" }
+func (e exitError) ExitCode() int { return e.code }
+
+func TestAssertions(t *testing.T) {
+	app := fisk.New("test", "")
+	app.Command("add", "").Flag("name", "").Required().String()
+	app.Command("fail", "").Action(func(_ *fisk.ParseContext) error {
+		return exitError{code: 3}
+	})
+
+	res := Run(app, "add")
+	AssertErrorIs(t, res, fisk.ErrRequiredFlag)
+	AssertExitCode(t, res, 1)
+
+	res = Run(app, "fail")
+	AssertExitCode(t, res, 3)
+	assert.False(t, res.Exited)
+
+	res = Run(app, "add", "--name", "x")
+	AssertNoError(t, res)
+	AssertExitCode(t, res, 0)
+
+	mock := &testing.T{}
+	assert.False(t, AssertErrorIs(mock, res, fisk.ErrRequiredFlag))
+	assert.False(t, AssertExitCode(mock, res, 2))
+	assert.Equal(t, 2, fisk.ExitCodeFor(errors.Join(errors.New("x"), exitError{code: 2})))
+}
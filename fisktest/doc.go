@@ -0,0 +1,12 @@
+// Package fisktest helps testing command line applications built using fisk.
+//
+// It allows for code like this:
+//
+//	res := fisktest.Run(app, "stream", "add", "--replicas", "3")
+//	if res.Err != nil {
+//		t.Fatalf("parse failed: %v", res.Err)
+//	}
+//	if res.Command != "stream add" {
+//		t.Fatalf("unexpected command %q", res.Command)
+//	}
+package fisktest
@@ -0,0 +1,71 @@
+package fisktest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/choria-io/fisk"
+)
+
+// CheckExamples parses every example added using Example() with
+// ParseDryRun(), failing t when an example does not parse or selects a
+// different command than the one it was added to.
+//
+// Values of flags and arguments are written while checking and app is
+// Reset() before every example.
+func CheckExamples(t testing.TB, app *fisk.Application) bool {
+	t.Helper()
+
+	model, err := app.IntrospectionModel()
+	if err != nil {
+		t.Errorf("could not initialize application: %v", err)
+		return false
+	}
+
+	ok := true
+	var check func(cmds []*fisk.CmdModel)
+	check = func(cmds []*fisk.CmdModel) {
+		for _, cmd := range cmds {
+			for _, example := range cmd.Examples {
+				if !checkExample(t, app, cmd, example) {
+					ok = false
+				}
+			}
+
+			if cmd.CmdGroupModel != nil {
+				check(cmd.Commands)
+			}
+		}
+	}
+
+	if model.CmdGroupModel != nil {
+		check(model.Commands)
+	}
+
+	return ok
+}
+
+func checkExample(t testing.TB, app *fisk.Application, cmd *fisk.CmdModel, example *fisk.CmdExample) bool {
+	t.Helper()
+
+	args, err := example.Args(app.Name)
+	if err != nil {
+		t.Errorf("example %q of %s is invalid: %v", example.Command, cmd.FullCommand, err)
+		return false
+	}
+
+	app.Reset()
+	res, err := app.ParseDryRun(args)
+	if err != nil {
+		t.Errorf("example %q of %s does not parse: %v", example.Command, cmd.FullCommand, err)
+		return false
+	}
+
+	// parents with subcommands may show how to reach any of them
+	if res.Command != cmd.FullCommand && !strings.HasPrefix(res.Command, cmd.FullCommand+" ") {
+		t.Errorf("example %q of %s selects %q", example.Command, cmd.FullCommand, res.Command)
+		return false
+	}
+
+	return true
+}
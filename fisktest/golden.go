@@ -0,0 +1,87 @@
+package fisktest
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/choria-io/fisk"
+)
+
+// GoldenWidth is the terminal width usage is rendered at by AssertUsageGolden()
+const GoldenWidth = 80
+
+// UpdateGoldenEnv is the environment variable that, when set to 1, makes
+// AssertUsageGolden() write golden files rather than compare against them
+const UpdateGoldenEnv = "FISKTEST_UPDATE_GOLDEN"
+
+// AssertUsageGolden renders the help for the command selected by args at a
+// fixed width and compares it to the content of goldenPath, failing t when
+// they differ.
+//
+// Run the tests with FISKTEST_UPDATE_GOLDEN=1 to create or update the golden
+// files after intended changes to the help.
+func AssertUsageGolden(t testing.TB, app *fisk.Application, args []string, goldenPath string) bool {
+	t.Helper()
+
+	app.TerminalWidth(GoldenWidth)
+
+	res := Run(app, append(append([]string{}, args...), "--help")...)
+	if res.UsageOutput == "" {
+		t.Errorf("no usage rendered for %q: %v", strings.Join(args, " "), res.Err)
+		return false
+	}
+
+	if os.Getenv(UpdateGoldenEnv) == "1" {
+		err := os.MkdirAll(filepath.Dir(goldenPath), 0755)
+		if err == nil {
+			err = os.WriteFile(goldenPath, []byte(res.UsageOutput), 0644)
+		}
+		if err != nil {
+			t.Errorf("could not update golden file: %v", err)
+			return false
+		}
+
+		return true
+	}
+
+	golden, err := os.ReadFile(goldenPath)
+	if errors.Is(err, os.ErrNotExist) {
+		t.Errorf("golden file %s does not exist, run with %s=1 to create it", goldenPath, UpdateGoldenEnv)
+		return false
+	}
+	if err != nil {
+		t.Errorf("could not read golden file: %v", err)
+		return false
+	}
+
+	if line, want, got, ok := firstDifference(string(golden), res.UsageOutput); !ok {
+		t.Errorf("usage for %q does not match %s at line %d\nwant: %q\n got: %q", strings.Join(args, " "), goldenPath, line, want, got)
+		return false
+	}
+
+	return true
+}
+
+// firstDifference compares want and got line by line, returning the first line that differs
+func firstDifference(want string, got string) (int, string, string, bool) {
+	wantLines := strings.Split(want, "\n")
+	gotLines := strings.Split(got, "\n")
+
+	for i := 0; i < len(wantLines) || i < len(gotLines); i++ {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if w != g || i >= len(wantLines) || i >= len(gotLines) {
+			return i + 1, w, g, false
+		}
+	}
+
+	return 0, "", "", true
+}
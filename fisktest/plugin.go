@@ -0,0 +1,61 @@
+package fisktest
+
+import (
+	"sync"
+
+	"github.com/choria-io/fisk"
+)
+
+var _ fisk.PluginExecutor = (*PluginRecorder)(nil)
+
+// PluginRecorder is a fisk.PluginExecutor that records plugin executions
+// rather than running the plugin, replaying scripted results
+//
+//	rec := &fisktest.PluginRecorder{}
+//	app.PluginExecutor(rec)
+//	fisktest.Run(app, "plugin", "add", "--token", "s3cret")
+//	rec.Last().RedactedArgs // ["add", "--token=*****"]
+type PluginRecorder struct {
+	// Results are returned in order by successive executions, nil once exhausted
+	Results []error
+
+	executions []*fisk.PluginExecution
+	mu         sync.Mutex
+}
+
+// ExecutePlugin records execution and returns the next result
+func (r *PluginRecorder) ExecutePlugin(execution *fisk.PluginExecution) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.executions = append(r.executions, execution)
+
+	if len(r.Results) == 0 {
+		return nil
+	}
+
+	err := r.Results[0]
+	r.Results = r.Results[1:]
+
+	return err
+}
+
+// Executions are all recorded executions in order
+func (r *PluginRecorder) Executions() []*fisk.PluginExecution {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]*fisk.PluginExecution{}, r.executions...)
+}
+
+// Last is the most recent execution, nil when the plugin was not run
+func (r *PluginRecorder) Last() *fisk.PluginExecution {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.executions) == 0 {
+		return nil
+	}
+
+	return r.executions[len(r.executions)-1]
+}
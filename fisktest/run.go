@@ -0,0 +1,70 @@
+package fisktest
+
+import (
+	"bytes"
+	"os"
+	"strings"
+
+	"github.com/choria-io/fisk"
+)
+
+// Result is the outcome of Run()
+type Result struct {
+	// Command is the selected command, space separated
+	Command string
+	// Err is the error returned by Parse()
+	Err error
+	// UsageOutput is everything written to the usage writer
+	UsageOutput string
+	// ErrorOutput is everything written to the error writer
+	ErrorOutput string
+	// Exited indicates the application called Terminate
+	Exited bool
+	// ExitCode is the code passed to the first call to Terminate, else the code Err would exit with
+	ExitCode int
+	// Context is the parse context of the run, nil when args could not be tokenized
+	Context *fisk.ParseContext
+}
+
+// Run resets and parses app using args, capturing everything written to its
+// usage and error writers and recording calls to Terminate rather than exiting.
+//
+// The writers and Terminate function of app are replaced, any environment
+// variables changed while parsing and running actions are restored.
+func Run(app *fisk.Application, args ...string) Result {
+	var (
+		res   Result
+		usage bytes.Buffer
+		errs  bytes.Buffer
+	)
+
+	env := os.Environ()
+	defer restoreEnv(env)
+
+	app.Reset()
+	app.UsageWriter(&usage).ErrorWriter(&errs).Terminate(func(code int) {
+		if res.Exited {
+			return
+		}
+		res.Exited = true
+		res.ExitCode = code
+	})
+
+	res.Command, res.Err = app.Parse(args)
+	if !res.Exited {
+		res.ExitCode = fisk.ExitCodeFor(res.Err)
+	}
+	res.Context = app.LastParseContext()
+	res.UsageOutput = usage.String()
+	res.ErrorOutput = errs.String()
+
+	return res
+}
+
+func restoreEnv(env []string) {
+	os.Clearenv()
+	for _, kv := range env {
+		k, v, _ := strings.Cut(kv, "=")
+		os.Setenv(k, v)
+	}
+}
@@ -0,0 +1,146 @@
+package fisktest
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/choria-io/fisk"
+	"github.com/stretchr/testify/assert"
+)
+
+func newApp() (*fisk.Application, *string) {
+	app := fisk.New("test", "A test application")
+	add := app.Command("add", "Adds a thing")
+	name := add.Arg("name", "The name").Required().String()
+	add.Action(func(_ *fisk.ParseContext) error {
+		return os.Setenv("FISKTEST_RUN", "changed")
+	})
+
+	return app, name
+}
+
+func TestRun(t *testing.T) {
+	t.Setenv("FISKTEST_RUN", "original")
+
+	app, name := newApp()
+	res := Run(app, "add", "x")
+	assert.NoError(t, res.Err)
+	assert.Equal(t, "add", res.Command)
+	assert.Equal(t, "x", *name)
+	assert.False(t, res.Exited)
+	assert.NotNil(t, res.Context)
+	assert.Equal(t, "original", os.Getenv("FISKTEST_RUN"))
+
+	res = Run(app, "--help")
+	assert.True(t, res.Exited)
+	assert.Equal(t, 0, res.ExitCode)
+	assert.Contains(t, res.UsageOutput, "A test application")
+	assert.Empty(t, *name)
+
+	res = Run(app, "add")
+	assert.EqualError(t, res.Err, "required argument 'name' not provided")
+}
+
+func TestAssertUsageGolden(t *testing.T) {
+	app, _ := newApp()
+	assert.True(t, AssertUsageGolden(t, app, []string{"add"}, "testdata/add.golden"))
+
+	line, want, got, ok := firstDifference("a\nb\nc", "a\nx\nc")
+	assert.False(t, ok)
+	assert.Equal(t, 2, line)
+	assert.Equal(t, "b", want)
+	assert.Equal(t, "x", got)
+
+	line, _, _, ok = firstDifference("a\nb", "a\nb\n")
+	assert.False(t, ok)
+	assert.Equal(t, 3, line)
+
+	_, _, _, ok = firstDifference("a\nb\n", "a\nb\n")
+	assert.True(t, ok)
+}
+
+func TestTerminal(t *testing.T) {
+	app := fisk.New("test", "").PromptMissing()
+	add := app.Command("add", "")
+	name := add.Arg("name", "").Required().String()
+	pass := add.Flag("password", "Password").PasswordPrompt()
+	yes := false
+	add.Action(func(pc *fisk.ParseContext) (err error) {
+		yes, err = fisk.Confirm(pc, "Sure?")
+		return err
+	})
+
+	term := NewTerminal("ORDERS\ny\n")
+	term.Passwords = []string{"s3cret"}
+	app.Terminal(term)
+
+	res := Run(app, "add")
+	assert.NoError(t, res.Err)
+	assert.Equal(t, "ORDERS", *name)
+	assert.Equal(t, "s3cret", *pass)
+	assert.True(t, yes)
+
+	term.TTY = false
+	res = Run(app, "add")
+	assert.EqualError(t, res.Err, "required argument 'name' not provided")
+}
+
+func TestSnapshotModel(t *testing.T) {
+	app, _ := newApp()
+	app.Flag("token", "").Default("s3cret").Secret().String()
+	assert.True(t, SnapshotModel(t, app, "testdata/cli.json"))
+
+	if os.Getenv(UpdateGoldenEnv) == "1" {
+		return
+	}
+
+	app.Command("rm", "Removes a thing")
+	mock := &testing.T{}
+	assert.False(t, SnapshotModel(mock, app, "testdata/cli.json"))
+}
+
+func TestCheckExamples(t *testing.T) {
+	app := fisk.New("nats", "")
+	stream := app.Command("stream", "").Example("nats stream add ORDERS", "")
+	add := stream.Command("add", "").Alias("new").Example("nats stream add 'ORDERS NEW' --replicas 3", "Adds a stream")
+	add.Arg("name", "").Required().String()
+	add.Flag("replicas", "").Int()
+	stream.Command("rm", "").Example("stream rm", "")
+
+	assert.True(t, CheckExamples(t, app))
+
+	add.Example("nats stream add --replicas x ORDERS", "")
+	app.Command("ls", "").Example("nats stream add ORDERS", "")
+	mock := &testing.T{}
+	assert.False(t, CheckExamples(mock, app))
+}
+
+func TestPluginRecorder(t *testing.T) {
+	plugin := fisk.New("plugin", "A plugin")
+	add := plugin.Command("add", "")
+	add.Flag("token", "").Secret().String()
+	add.Arg("name", "").Required().String()
+	model, err := plugin.IntrospectionModel()
+	assert.NoError(t, err)
+	j, err := json.Marshal(model)
+	assert.NoError(t, err)
+
+	app := fisk.New("host", "")
+	_, err = app.ExternalPluginCommand("/nonexisting/plugin", j, "", "")
+	assert.NoError(t, err)
+
+	rec := &PluginRecorder{Results: []error{errors.New("failed")}}
+	app.PluginExecutor(rec)
+
+	res := Run(app, "plugin", "add", "ORDERS", "--token", "s3cret")
+	assert.EqualError(t, res.Err, "failed")
+	assert.Equal(t, "/nonexisting/plugin", rec.Last().Command)
+	assert.Equal(t, []string{"add", "ORDERS", "--token=s3cret"}, rec.Last().Args)
+	assert.Equal(t, []string{"add", "ORDERS", "--token=*****"}, rec.Last().RedactedArgs)
+
+	res = Run(app, "plugin", "add", "ORDERS")
+	assert.NoError(t, res.Err)
+	assert.Len(t, rec.Executions(), 2)
+}
@@ -0,0 +1,64 @@
+package fisktest
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/choria-io/fisk"
+)
+
+// SnapshotModel compares the introspection model of app, as JSON, to the
+// snapshot stored in path and fails t when commands, flags, arguments or
+// their defaults changed.
+//
+// Run the tests with FISKTEST_UPDATE_GOLDEN=1 to create or approve changes
+// to the snapshot, the diff of the snapshot then documents the change.
+func SnapshotModel(t testing.TB, app *fisk.Application, path string) bool {
+	t.Helper()
+
+	model, err := app.IntrospectionModel()
+	if err != nil {
+		t.Errorf("could not initialize application: %v", err)
+		return false
+	}
+
+	j, err := json.MarshalIndent(model, "", "  ")
+	if err != nil {
+		t.Errorf("could not serialize model: %v", err)
+		return false
+	}
+	j = append(j, '\n')
+
+	if os.Getenv(UpdateGoldenEnv) == "1" {
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		if err == nil {
+			err = os.WriteFile(path, j, 0644)
+		}
+		if err != nil {
+			t.Errorf("could not update model snapshot: %v", err)
+			return false
+		}
+
+		return true
+	}
+
+	snapshot, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		t.Errorf("model snapshot %s does not exist, run with %s=1 to create it", path, UpdateGoldenEnv)
+		return false
+	}
+	if err != nil {
+		t.Errorf("could not read model snapshot: %v", err)
+		return false
+	}
+
+	if line, want, got, ok := firstDifference(string(snapshot), string(j)); !ok {
+		t.Errorf("model does not match snapshot %s at line %d\nwant: %q\n got: %q", path, line, want, got)
+		return false
+	}
+
+	return true
+}
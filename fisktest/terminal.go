@@ -0,0 +1,62 @@
+package fisktest
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/choria-io/fisk"
+)
+
+var _ fisk.Terminal = (*Terminal)(nil)
+
+// Terminal is a fake fisk.Terminal that answers prompts from a script
+type Terminal struct {
+	// TTY indicates the terminal is interactive, prompts are only shown when true
+	TTY bool
+	// Columns is the width reported for the terminal
+	Columns int
+	// Color indicates colored output is enabled
+	Color bool
+	// Passwords are returned in order by ReadPassword
+	Passwords []string
+
+	input *bufio.Reader
+}
+
+// NewTerminal creates an interactive 80 column terminal that reads answers
+// to prompts from input, one per line
+func NewTerminal(input string) *Terminal {
+	return &Terminal{
+		TTY:     true,
+		Columns: 80,
+		input:   bufio.NewReader(strings.NewReader(input)),
+	}
+}
+
+func (t *Terminal) IsTTY() bool { return t.TTY }
+
+func (t *Terminal) Width() int { return t.Columns }
+
+func (t *Terminal) ColorEnabled() bool { return t.Color }
+
+// Input is shared by all prompts so that answers are consumed in order
+func (t *Terminal) Input() io.Reader {
+	if t.input == nil {
+		t.input = bufio.NewReader(strings.NewReader(""))
+	}
+
+	return t.input
+}
+
+func (t *Terminal) ReadPassword() (string, error) {
+	if len(t.Passwords) == 0 {
+		return "", errors.New("no passwords left")
+	}
+
+	pass := t.Passwords[0]
+	t.Passwords = t.Passwords[1:]
+
+	return pass, nil
+}
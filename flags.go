@@ -6,6 +6,7 @@ import (
 )
 
 type flagGroup struct {
+	app       *Application // nil for groups built while parsing
 	short     map[string]*FlagClause
 	long      map[string]*FlagClause
 	flagOrder []*FlagClause
@@ -18,26 +19,76 @@ func newFlagGroup() *flagGroup {
 	}
 }
 
+// mergeFlagGroups creates a new group holding the flags of parent followed by
+// those of flags, every flag that is already in the merged group is an error
+func mergeFlagGroups(parent *flagGroup, flags *flagGroup) (*flagGroup, []error) {
+	merged := &flagGroup{
+		short:     make(map[string]*FlagClause, len(parent.short)+len(flags.short)),
+		long:      make(map[string]*FlagClause, len(parent.long)+len(flags.long)),
+		flagOrder: make([]*FlagClause, 0, len(parent.flagOrder)+len(flags.flagOrder)),
+	}
+	for k, v := range parent.short {
+		merged.short[k] = v
+	}
+	for k, v := range parent.long {
+		merged.long[k] = v
+	}
+	merged.flagOrder = append(merged.flagOrder, parent.flagOrder...)
+
+	var errs []error
+	for _, flag := range flags.flagOrder {
+		for _, short := range flag.shorts() {
+			if _, ok := merged.short[string(short)]; ok {
+				errs = append(errs, fmt.Errorf("duplicate short flag -%c", short))
+			}
+			merged.short[string(short)] = flag
+		}
+		if _, ok := merged.long[flag.name]; ok {
+			errs = append(errs, fmt.Errorf("duplicate long flag --%s", flag.name))
+		}
+		merged.long[flag.name] = flag
+		merged.flagOrder = append(merged.flagOrder, flag)
+	}
+
+	return merged, errs
+}
+
 // GetFlag gets a flag definition.
 //
 // This allows existing flags to be modified after definition but before parsing. Useful for
 // modular applications.
 func (f *flagGroup) GetFlag(name string) *FlagClause {
+	f.app.modelChanged()
 	return f.long[name]
 }
 
 // Flag defines a new flag with the given long name and help.
 func (f *flagGroup) Flag(name, help string) *FlagClause {
 	flag := newFlag(name, help)
+	f.app.modelChanged()
 	f.long[name] = flag
 	f.flagOrder = append(f.flagOrder, flag)
 	return flag
 }
 
-func (f *flagGroup) init(defaultEnvarPrefix string) error {
-	if err := f.checkDuplicates(); err != nil {
-		return err
+// removeFlag removes a flag before the group is initialized
+func (f *flagGroup) removeFlag(name string) {
+	flag, ok := f.long[name]
+	if !ok {
+		return
 	}
+
+	f.app.modelChanged()
+	delete(f.long, name)
+	for i, o := range f.flagOrder {
+		if o == flag {
+			f.flagOrder = append(f.flagOrder[:i], f.flagOrder[i+1:]...)
+			break
+		}
+	}
+}
+
+func (f *flagGroup) init(defaultEnvarPrefix string) error {
 	for _, flag := range f.long {
 		if defaultEnvarPrefix != "" && !flag.noEnvar && flag.envar == "" {
 			flag.envar = envarTransform(defaultEnvarPrefix + "_" + flag.name)
@@ -45,27 +96,9 @@ func (f *flagGroup) init(defaultEnvarPrefix string) error {
 		if err := flag.init(); err != nil {
 			return err
 		}
-		if flag.shorthand != 0 {
-			f.short[string(flag.shorthand)] = flag
-		}
-	}
-	return nil
-}
-
-func (f *flagGroup) checkDuplicates() error {
-	seenShort := map[rune]bool{}
-	seenLong := map[string]bool{}
-	for _, flag := range f.flagOrder {
-		if flag.shorthand != 0 {
-			if _, ok := seenShort[flag.shorthand]; ok {
-				return fmt.Errorf("duplicate short flag -%c", flag.shorthand)
-			}
-			seenShort[flag.shorthand] = true
-		}
-		if _, ok := seenLong[flag.name]; ok {
-			return fmt.Errorf("duplicate long flag --%s", flag.name)
+		for _, short := range flag.shorts() {
+			f.short[string(short)] = flag
 		}
-		seenLong[flag.name] = true
 	}
 	return nil
 }
@@ -113,7 +146,9 @@ loop:
 
 			context.Next()
 
-			flag.isSetByUser()
+			if !context.readOnly {
+				flag.isSetByUser()
+			}
 
 			if isBoolFlag(flag.value) {
 				if invert {
@@ -121,6 +156,13 @@ loop:
 				} else {
 					defaultValue = "true"
 				}
+				if _, ok := flag.value.(optionalValueFlag); ok {
+					// --flag=value given to a flag that does not require a value
+					if next := context.Peek(); next.Type == TokenArg && next.Index == flagToken.Index {
+						context.Next()
+						defaultValue = next.Value
+					}
+				}
 			} else {
 				if invert {
 					context.Push(token)
@@ -151,14 +193,26 @@ type FlagClause struct {
 	actionMixin
 	completionsMixin
 	envarMixin
+	normalizeMixin
+	deprecationMixin
+	conditionalHiddenMixin
 	name          string
 	shorthand     rune
+	shortAliases  []rune
 	help          string
 	defaultValues []string
+	defaultFunc   func() (string, error)
 	placeholder   string
 	hidden        bool
+	secret        bool
 	setByUser     *bool
 	validator     OptionValidator
+	setHooks      []func(raw string) (string, error)
+	commandValues bool
+	stability     Stability
+	category      string
+	helpLong      string
+	group         string
 }
 
 func newFlag(name, help string) *FlagClause {
@@ -169,33 +223,103 @@ func newFlag(name, help string) *FlagClause {
 	return f
 }
 
-func (f *FlagClause) setDefault() error {
+// HelpLong sets a longer description of the flag, shown by --help-long and
+// in man pages and markdown docs while the short help is used elsewhere
+func (f *FlagClause) HelpLong(help string) *FlagClause {
+	f.helpLong = help
+	return f
+}
+
+// Group shows the flag under a heading of its own in help, like "TLS", for commands with many flags
+func (f *FlagClause) Group(name string) *FlagClause {
+	f.group = name
+	return f
+}
+
+// Category groups the flag with others of the same category in docs generated from the model
+func (f *FlagClause) Category(category string) *FlagClause {
+	f.category = category
+	return f
+}
+
+func (f *FlagClause) setDefault(context *ParseContext) error {
 	if f.HasEnvarValue() {
 		if v, ok := f.value.(repeatableFlag); !ok || !v.IsCumulative() {
 			// Use the value as-is
-			return f.value.Set(f.GetEnvarValue())
+			return redactError(f.set(context, f.GetEnvarValue()), f.secret, f.GetEnvarValue())
 		} else {
 			for _, value := range f.GetSplitEnvarValue() {
-				if err := f.value.Set(value); err != nil {
-					return err
+				if err := f.set(context, value); err != nil {
+					return redactError(err, f.secret, value)
 				}
 			}
 			return nil
 		}
 	}
 
-	if len(f.defaultValues) > 0 {
-		for _, defaultValue := range f.defaultValues {
-			if err := f.value.Set(defaultValue); err != nil {
-				return err
-			}
+	defaults, err := f.defaults()
+	if err != nil {
+		return fmt.Errorf("default for --%s: %w", f.name, err)
+	}
+	for _, defaultValue := range defaults {
+		if err := f.set(context, defaultValue); err != nil {
+			return err
 		}
-		return nil
 	}
 
 	return nil
 }
 
+// set normalizes raw, resolves command values and secrets and passes it through the SetHook() functions before setting the value
+func (f *FlagClause) set(context *ParseContext, raw string) error {
+	v, err := f.commandValue(f.normalize(raw))
+	if err != nil {
+		return err
+	}
+
+	v, err = f.secretValue(context, v)
+	if err != nil {
+		return err
+	}
+
+	v, err = f.hooked(v)
+	if err != nil {
+		return err
+	}
+
+	return f.value.Set(v)
+}
+
+func (f *FlagClause) hooked(raw string) (string, error) {
+	var err error
+	for _, hook := range f.setHooks {
+		raw, err = hook(raw)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return raw, nil
+}
+
+// defaults are the Default() values or the value from DefaultFunc()
+func (f *FlagClause) defaults() ([]string, error) {
+	if len(f.defaultValues) > 0 || f.defaultFunc == nil {
+		return f.defaultValues, nil
+	}
+
+	v, err := f.defaultFunc()
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{v}, nil
+}
+
+func (f *FlagClause) hasDefault() bool {
+	return len(f.defaultValues) > 0 || f.defaultFunc != nil
+}
+
 func (f *FlagClause) isSetByUser() {
 	if f.setByUser != nil {
 		*f.setByUser = true
@@ -203,12 +327,14 @@ func (f *FlagClause) isSetByUser() {
 }
 
 func (f *FlagClause) needsValue() bool {
-	haveDefault := len(f.defaultValues) > 0
-	return f.required && !(haveDefault || f.HasEnvarValue())
+	return f.required && !(f.hasDefault() || f.HasEnvarValue())
 }
 
 func (f *FlagClause) init() error {
-	if f.required && len(f.defaultValues) > 0 {
+	if f.hiddenByCondition() {
+		f.hidden = true
+	}
+	if f.required && f.hasDefault() {
 		return fmt.Errorf("required flag '--%s' with default value that will never be used", f.name)
 	}
 	if f.value == nil {
@@ -236,6 +362,13 @@ func (f *FlagClause) PreAction(action Action) *FlagClause {
 	return f
 }
 
+// Priority sets when the actions of this flag run relative to other actions,
+// for example PrioritySetup for a flag loading configuration
+func (f *FlagClause) Priority(priority int) *FlagClause {
+	f.priority = priority
+	return f
+}
+
 // HintAction registers a HintAction (function) for the flag to provide completions
 func (a *FlagClause) HintAction(action HintAction) *FlagClause {
 	a.addHintAction(action)
@@ -250,6 +383,24 @@ func (a *FlagClause) HintOptions(options ...string) *FlagClause {
 	return a
 }
 
+// HintFiles completes the flag using paths of files and directories
+func (a *FlagClause) HintFiles() *FlagClause {
+	a.hintPaths(false)
+	return a
+}
+
+// HintDirectories completes the flag using paths of directories
+func (a *FlagClause) HintDirectories() *FlagClause {
+	a.hintPaths(true)
+	return a
+}
+
+// HintFilesWithExt completes the flag using paths of directories and files with any of the extensions
+func (a *FlagClause) HintFilesWithExt(ext ...string) *FlagClause {
+	a.hintPaths(false, ext...)
+	return a
+}
+
 func (a *FlagClause) EnumVar(target *string, options ...string) {
 	a.parserMixin.EnumVar(target, options...)
 	a.addHintActionBuiltin(func() []string {
@@ -279,6 +430,22 @@ func (f *FlagClause) Default(values ...string) *FlagClause {
 	return f
 }
 
+// SetHook transforms every value before it is set, whether from the command
+// line, environment, defaults or prompts, for example to normalize or coerce
+// units. Hooks run in the order they were added, an error rejects the value.
+func (f *FlagClause) SetHook(hook func(raw string) (string, error)) *FlagClause {
+	f.setHooks = append(f.setHooks, hook)
+	return f
+}
+
+// DefaultFunc computes the default value when the flag is parsed without it
+// or its environment variable, for defaults like the current hostname that
+// are expensive or change. Values set using Default() take precedence.
+func (f *FlagClause) DefaultFunc(fn func() (string, error)) *FlagClause {
+	f.defaultFunc = fn
+	return f
+}
+
 // DEPRECATED: Use Envar(name) instead.
 func (f *FlagClause) OverrideDefaultFromEnvar(envar string) *FlagClause {
 	return f.Envar(envar)
@@ -315,6 +482,28 @@ func (f *FlagClause) Hidden() *FlagClause {
 	return f
 }
 
+// Secret marks the flag as holding sensitive data, its default values will
+// not be shown in help and its value will be masked in debug output, audit
+// logs, error messages and introspection.
+func (f *FlagClause) Secret() *FlagClause {
+	f.secret = true
+	return f
+}
+
+// PasswordPrompt marks the flag as Secret() and reads its value interactively
+// without echo when it is not supplied on the command line or environment.
+func (f *FlagClause) PasswordPrompt() (target *string) {
+	f.Secret()
+	return f.parserMixin.PasswordPrompt()
+}
+
+// PasswordPromptVar marks the flag as Secret() and reads its value interactively
+// without echo when it is not supplied on the command line or environment.
+func (f *FlagClause) PasswordPromptVar(target *string) {
+	f.Secret()
+	f.parserMixin.PasswordPromptVar(target)
+}
+
 // Required makes the flag required. You can not provide a Default() value to a Required() flag.
 func (f *FlagClause) Required() *FlagClause {
 	f.required = true
@@ -327,6 +516,29 @@ func (f *FlagClause) Short(name rune) *FlagClause {
 	return f
 }
 
+// ShortAliases adds further short flag names, for example to stay compatible
+// with the single letter flags of a CLI being replaced. Short() is set to the
+// first name when not already set.
+func (f *FlagClause) ShortAliases(names ...rune) *FlagClause {
+	if f.shorthand == 0 && len(names) > 0 {
+		f.shorthand = names[0]
+		names = names[1:]
+	}
+
+	f.shortAliases = append(f.shortAliases, names...)
+
+	return f
+}
+
+// shorts are all the short names of the flag
+func (f *FlagClause) shorts() []rune {
+	if f.shorthand == 0 {
+		return nil
+	}
+
+	return append([]rune{f.shorthand}, f.shortAliases...)
+}
+
 // Help sets the help message.
 func (f *FlagClause) Help(help string) *FlagClause {
 	f.help = help
@@ -6,6 +6,7 @@ import (
 	"io"
 	"os"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -188,6 +189,28 @@ func TestDuplicateShortFlag(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestShortAliases(t *testing.T) {
+	app := newTestApp()
+	count := app.Flag("count", "Messages to send").ShortAliases('n', 'c').Int()
+	app.Flag("verbose", "").Short('v').Bool()
+
+	_, err := app.Parse([]string{"-c", "2"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, *count)
+
+	_, err = app.Parse([]string{"-n3"})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, *count)
+
+	assert.Equal(t, "-n, -c, --count=INT", formatFlag(true, app.GetFlag("count").Model()))
+
+	app = newTestApp()
+	app.Flag("a", "").Short('a').String()
+	app.Flag("b", "").ShortAliases('b', 'a').String()
+	_, err = app.Parse([]string{})
+	assert.EqualError(t, err, "duplicate short flag -a")
+}
+
 func TestDuplicateLongFlag(t *testing.T) {
 	app := newTestApp()
 	app.Flag("a", "").String()
@@ -475,3 +498,130 @@ func TestHelpForBoolFlags(t *testing.T) {
 	assert.Contains(t, w.String(), "--[no-]no")
 	assert.Contains(t, w.String(), "--nonneg")
 }
+
+func TestSecretFlagHidesDefault(t *testing.T) {
+	app := newTestApp()
+	app.Flag("token", "").Default("s3cret").Secret().String()
+	app.Flag("user", "").Default("bob").String()
+
+	w := bytes.NewBuffer(nil)
+	app.Writer(w).Usage(nil)
+	assert.NotContains(t, w.String(), "s3cret")
+	assert.Contains(t, w.String(), "--token=TOKEN")
+	assert.Contains(t, w.String(), `--user="bob"`)
+}
+
+func TestPasswordPrompt(t *testing.T) {
+	app := newTestApp()
+	pass := app.Flag("password", "").Required().PasswordPrompt()
+
+	_, err := app.Parse([]string{"--password", "s3cret"})
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cret", *pass)
+	assert.True(t, app.GetFlag("password").secret)
+
+	// stdin is not a terminal during tests so no prompt is attempted
+	app = newTestApp()
+	app.Flag("password", "").Required().PasswordPrompt()
+	_, err = app.Parse([]string{})
+	assert.ErrorIs(t, err, ErrRequiredFlag)
+}
+
+func TestFlagDefaultFunc(t *testing.T) {
+	calls := 0
+	app := newTestApp()
+	branch := app.Flag("branch", "").Envar("TEST_DEFAULT_FUNC_BRANCH").DefaultFunc(func() (string, error) {
+		calls++
+		return "main", nil
+	}).String()
+
+	_, err := app.Parse([]string{"--branch", "dev"})
+	assert.NoError(t, err)
+	assert.Equal(t, "dev", *branch)
+	assert.Equal(t, 0, calls)
+
+	_, err = app.Parse([]string{})
+	assert.NoError(t, err)
+	assert.Equal(t, "main", *branch)
+	assert.Equal(t, 1, calls)
+
+	t.Setenv("TEST_DEFAULT_FUNC_BRANCH", "env")
+	_, err = app.Parse([]string{})
+	assert.NoError(t, err)
+	assert.Equal(t, "env", *branch)
+	assert.Equal(t, 1, calls)
+
+	app = newTestApp()
+	app.Flag("branch", "").DefaultFunc(func() (string, error) { return "", fmt.Errorf("no git") }).String()
+	_, err = app.Parse([]string{})
+	assert.EqualError(t, err, "default for --branch: no git")
+
+	app = newTestApp()
+	app.Flag("host", "").Required().DefaultFunc(func() (string, error) { return "", nil }).String()
+	_, err = app.Parse([]string{})
+	assert.EqualError(t, err, "required flag '--host' with default value that will never be used")
+}
+
+func TestFlagSetHook(t *testing.T) {
+	app := newTestApp()
+	flag := app.Flag("server", "").Default("NATS://Demo.example.net").SetHook(func(raw string) (string, error) {
+		return strings.TrimPrefix(raw, "nats://"), nil
+	}).SetHook(func(raw string) (string, error) {
+		if raw == "" {
+			return "", fmt.Errorf("server cannot be empty")
+		}
+		return strings.ToLower(raw), nil
+	})
+	server := flag.String()
+
+	_, err := app.Parse([]string{"--server", "nats://Local.example.net"})
+	assert.NoError(t, err)
+	assert.Equal(t, "local.example.net", *server)
+
+	_, err = app.Parse([]string{})
+	assert.NoError(t, err)
+	assert.Equal(t, "nats://demo.example.net", *server)
+
+	_, err = app.Parse([]string{"--server", "nats://"})
+	assert.EqualError(t, err, "server cannot be empty")
+
+	res, err := app.Resolve([]string{"--server", "nats://Other"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"other"}, res.FlagValues(flag))
+}
+
+func TestFlagNormalization(t *testing.T) {
+	app := newTestApp()
+	flag := app.Flag("region", "").Default(" EU-West ").TrimSpace().ToLower()
+	region := flag.Enum("eu-west", "us-east")
+
+	_, err := app.Parse([]string{"--region", "US-East  "})
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east", *region)
+
+	_, err = app.Parse([]string{})
+	assert.NoError(t, err)
+	assert.Equal(t, "eu-west", *region)
+
+	res, err := app.Resolve([]string{"--region", " US-EAST"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"us-east"}, res.FlagValues(flag))
+}
+
+func TestFlagLenientNumbers(t *testing.T) {
+	app := newTestApp()
+	limit := app.Flag("limit", "").LenientNumbers().Int64()
+	ratio := app.Flag("ratio", "").LenientNumbers().Float64()
+	count := app.Arg("count", "").LenientNumbers().Uint()
+
+	for v, expected := range map[string]int64{"1_000_000": 1_000_000, "1,000,000": 1_000_000, "1e6": 1_000_000, "1.5e6": 1_500_000} {
+		_, err := app.Parse([]string{"--limit", v, "--ratio", "2.5e-1", "0x1e"})
+		assert.NoError(t, err, v)
+		assert.Equal(t, expected, *limit, v)
+		assert.InEpsilon(t, 0.25, *ratio, 0.001)
+		assert.Equal(t, uint(30), *count)
+	}
+
+	_, err := app.Parse([]string{"--limit", "1.5", "1"})
+	assert.Error(t, err)
+}
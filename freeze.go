@@ -0,0 +1,275 @@
+package fisk
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Freeze serializes the definition of the application and all its commands,
+// flags and arguments so that it can be restored using Thaw() without
+// building it in Go.
+//
+// Actions, validators, hints and value types are not serialized, these are
+// supplied when thawing using bindings.
+func (a *Application) Freeze() ([]byte, error) {
+	return json.Marshal(a.buildModel())
+}
+
+// Thaw creates an application from data produced by Freeze(), bindings
+// attach values and actions to the restored definition.
+//
+// Keys in bindings are the command path followed by the flag or argument,
+// for example "--server", "stream add --replicas" or "stream add <name>",
+// these can be bound to a Value or to a *string, *bool, *int, *[]string or
+// *time.Duration. A command path like "stream add" can be bound to an Action.
+// Unbound flags and arguments store their values as strings.
+func Thaw(data []byte, bindings map[string]interface{}) (*Application, error) {
+	var model ApplicationModel
+	err := json.Unmarshal(data, &model)
+	if err != nil {
+		return nil, err
+	}
+
+	if model.Name == "" {
+		return nil, fmt.Errorf("frozen application has no name")
+	}
+
+	used := map[string]bool{}
+	app, err := fromModel(&model, func(key string) interface{} {
+		binding, ok := bindings[key]
+		if ok {
+			used[key] = true
+		}
+		return binding
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var unused []string
+	for k := range bindings {
+		if !used[k] {
+			unused = append(unused, k)
+		}
+	}
+	if len(unused) > 0 {
+		sort.Strings(unused)
+		return nil, fmt.Errorf("unknown bindings: %s", strings.Join(unused, ", "))
+	}
+
+	return app, nil
+}
+
+// ValueBinder supplies the binding for a flag, argument or command restored
+// by FromModel(), key and the supported bindings are as described in Thaw().
+// Returning nil stores values as strings and leaves commands without actions.
+type ValueBinder func(key string) interface{}
+
+// FromModel creates a runnable application from a model, typically one
+// produced by --fisk-introspect or IntrospectionModel(), so that any model
+// can be hosted, proxied or mocked. A nil binder stores all values as strings.
+//
+// FromModel panics when the binder returns an unsupported binding.
+func FromModel(m *ApplicationModel, binder ValueBinder) *Application {
+	app, err := fromModel(m, binder)
+	if err != nil {
+		panic(err)
+	}
+
+	return app
+}
+
+func fromModel(model *ApplicationModel, binder ValueBinder) (*Application, error) {
+	t := &thawer{bind: binder}
+
+	app := New(model.Name, model.Help)
+	if model.Version != "" {
+		app.Version(model.Version)
+	}
+	app.Author(model.Author)
+	app.helpHeader = model.HelpHeader
+	app.helpFooter = model.HelpFooter
+	if len(model.CheatTags) > 0 {
+		app.cheatTags = model.CheatTags
+	}
+	for k, v := range model.Cheats {
+		app.Cheat(k, v)
+	}
+	for k, v := range model.Shortcuts {
+		app.Shortcut(k, v)
+	}
+
+	if err := t.flags(nil, app.flagGroup, model.FlagGroupModel); err != nil {
+		return nil, err
+	}
+	if err := t.args(nil, app.argGroup, model.ArgGroupModel); err != nil {
+		return nil, err
+	}
+	if err := t.commands(nil, app.cmdGroup, app.Command, model.CmdGroupModel); err != nil {
+		return nil, err
+	}
+
+	return app, nil
+}
+
+type thawer struct {
+	bind ValueBinder
+}
+
+func (t *thawer) binding(path []string, name string) (interface{}, string) {
+	key := strings.Join(append(append([]string{}, path...), name), " ")
+	if t.bind == nil {
+		return nil, key
+	}
+
+	return t.bind(key), key
+}
+
+func (t *thawer) commands(path []string, group *cmdGroup, add func(string, string) *CmdClause, model *CmdGroupModel) error {
+	if model == nil {
+		return nil
+	}
+
+	for _, cm := range model.Commands {
+		// help is added during init and commands like cheat by their builders
+		if cm.Name == "help" || group.commands[cm.Name] != nil {
+			continue
+		}
+
+		cmd := add(cm.Name, cm.Help)
+		cmd.aliases = cm.Aliases
+		cmd.helpLong = cm.HelpLong
+		cmd.hidden = cm.Hidden
+		cmd.category = cm.Category
+		cmd.stability = cm.Stability
+		cmd.deprecated = cm.Deprecated
+		cmd.removedIn = cm.RemovedIn
+		cmd.helpFooter = cm.HelpFooter
+		cmd.isDefault = cm.Default
+		cmd.examples = cm.Examples
+		cmd.cheat = cm.Cheat
+
+		cmdPath := append(append([]string{}, path...), cm.Name)
+		binding, key := t.binding(path, cm.Name)
+		switch action := binding.(type) {
+		case nil:
+		case Action:
+			cmd.Action(action)
+		case func(*ParseContext) error:
+			cmd.Action(action)
+		default:
+			return fmt.Errorf("invalid binding %q: commands can only be bound to an Action", key)
+		}
+
+		if err := t.flags(cmdPath, cmd.flagGroup, cm.FlagGroupModel); err != nil {
+			return err
+		}
+		if err := t.args(cmdPath, cmd.argGroup, cm.ArgGroupModel); err != nil {
+			return err
+		}
+		if err := t.commands(cmdPath, cmd.cmdGroup, cmd.Command, cm.CmdGroupModel); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *thawer) flags(path []string, group *flagGroup, model *FlagGroupModel) error {
+	if model == nil {
+		return nil
+	}
+
+	for _, fm := range model.Flags {
+		// built in flags like help and version already exist
+		if group.long[fm.Name] != nil {
+			continue
+		}
+
+		flag := group.Flag(fm.Name, fm.Help)
+		flag.shorthand = fm.Short
+		flag.shortAliases = fm.ShortAliases
+		flag.defaultValues = fm.Default
+		flag.envar = fm.Envar
+		flag.placeholder = fm.PlaceHolder
+		flag.required = fm.Required
+		flag.hidden = fm.Hidden
+		flag.helpLong = fm.HelpLong
+		flag.category = fm.Category
+		flag.group = fm.Group
+		flag.stability = fm.Stability
+		flag.deprecated = fm.Deprecated
+		flag.removedIn = fm.RemovedIn
+		flag.secret = fm.Secret
+
+		binding, key := t.binding(path, "--"+fm.Name)
+		if err := bindValue(&flag.parserMixin, binding, key, fm.Boolean, fm.Negatable, fm.Cumulative); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *thawer) args(path []string, group *argGroup, model *ArgGroupModel) error {
+	if model == nil {
+		return nil
+	}
+
+	for _, am := range model.Args {
+		arg := group.Arg(am.Name, am.Help)
+		arg.defaultValues = am.Default
+		arg.envar = am.Envar
+		arg.placeholder = am.PlaceHolder
+		arg.required = am.Required
+		arg.hidden = am.Hidden
+		arg.secret = am.Secret
+		arg.group = am.Group
+
+		binding, key := t.binding(path, "<"+am.Name+">")
+		if err := bindValue(&arg.parserMixin, binding, key, false, false, am.Cumulative); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func bindValue(p *parserMixin, binding interface{}, key string, boolean bool, negatable bool, cumulative bool) error {
+	switch v := binding.(type) {
+	case nil:
+		switch {
+		case boolean && negatable:
+			p.Bool()
+		case boolean:
+			p.UnNegatableBool()
+		case cumulative:
+			p.Strings()
+		default:
+			p.String()
+		}
+	case Value:
+		p.SetValue(v)
+	case *string:
+		p.StringVar(v)
+	case *bool:
+		if boolean && !negatable {
+			p.UnNegatableBoolVar(v)
+		} else {
+			p.BoolVar(v)
+		}
+	case *int:
+		p.IntVar(v)
+	case *[]string:
+		p.StringsVar(v)
+	case *time.Duration:
+		p.DurationVar(v)
+	default:
+		return fmt.Errorf("invalid binding %q: unsupported type %T", key, binding)
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package fisk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFreezeThaw(t *testing.T) {
+	app := newTestApp().Version("1.2.3")
+	app.Flag("server", "The server").Short('s').Default("localhost").String()
+	app.Flag("trace", "Trace").UnNegatableBool()
+	stream := app.Command("stream", "Streams").Alias("str")
+	add := stream.Command("add", "Adds a stream")
+	add.Flag("replicas", "Replicas").Int()
+	add.Flag("max-age", "Age").Duration()
+	add.Flag("subject", "Subjects").Secret().Strings()
+	add.Arg("name", "The name").Required().String()
+
+	data, err := app.Freeze()
+	assert.NoError(t, err)
+
+	var (
+		server   string
+		trace    bool
+		replicas int
+		age      time.Duration
+		name     string
+		ran      bool
+	)
+
+	thawed, err := Thaw(data, map[string]interface{}{
+		"--server":              &server,
+		"--trace":               &trace,
+		"stream add --replicas": &replicas,
+		"stream add --max-age":  &age,
+		"stream add <name>":     &name,
+		"stream add":            func(*ParseContext) error { ran = true; return nil },
+	})
+	assert.NoError(t, err)
+	thawed.Terminate(nil)
+
+	cmd, err := thawed.Parse([]string{"str", "add", "ORDERS", "--replicas", "3", "--max-age=1h", "--subject", "a", "--trace"})
+	assert.NoError(t, err)
+	assert.Equal(t, "stream add", cmd)
+	assert.Equal(t, "localhost", server)
+	assert.True(t, trace)
+	assert.Equal(t, 3, replicas)
+	assert.Equal(t, time.Hour, age)
+	assert.Equal(t, "ORDERS", name)
+	assert.True(t, ran)
+
+	subject := thawed.GetCommand("stream").GetCommand("add").GetFlag("subject")
+	assert.True(t, subject.secret)
+	assert.Equal(t, "a", subject.value.String())
+	assert.Equal(t, "1.2.3", thawed.version)
+
+	_, err = Thaw(data, map[string]interface{}{"stream rm": func(*ParseContext) error { return nil }})
+	assert.EqualError(t, err, "unknown bindings: stream rm")
+
+	_, err = Thaw(data, map[string]interface{}{"--server": 1})
+	assert.EqualError(t, err, `invalid binding "--server": unsupported type int`)
+}
+
+func TestFromModel(t *testing.T) {
+	plugin := newTestApp()
+	plugin.Flag("server", "The server").Default("localhost").String()
+	add := plugin.Command("add", "Adds")
+	add.Arg("name", "The name").Required().String()
+	add.Flag("force", "Force").Bool()
+
+	model, err := plugin.IntrospectionModel()
+	assert.NoError(t, err)
+
+	var keys []string
+	app := FromModel(model, func(key string) interface{} {
+		keys = append(keys, key)
+		return nil
+	})
+	app.Terminate(nil)
+	assert.ElementsMatch(t, []string{"--server", "add", "add --force", "add <name>"}, keys)
+
+	_, err = app.Parse([]string{"add", "x", "--force"})
+	assert.NoError(t, err)
+	assert.Equal(t, "localhost", app.GetFlag("server").value.String())
+	assert.Equal(t, "x", app.GetCommand("add").GetArg("name").value.String())
+	assert.Equal(t, "true", app.GetCommand("add").GetFlag("force").value.String())
+
+	assert.Panics(t, func() {
+		FromModel(model, func(key string) interface{} { return 1 })
+	})
+}
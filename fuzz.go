@@ -0,0 +1,37 @@
+package fisk
+
+import (
+	"bytes"
+)
+
+// FuzzParse exercises the tokenizer, parser and completion of app using
+// arbitrary input, intended to be called from native Go fuzz targets:
+//
+//	func FuzzCLI(f *testing.F) {
+//		app := buildApp()
+//		f.Fuzz(func(t *testing.T, data []byte) {
+//			fisk.FuzzParse(app, data)
+//		})
+//	}
+//
+// Data is split into arguments on NUL bytes. Values are resolved like Resolve()
+// so flags and arguments are not modified and no actions are run, hint actions
+// are called to produce completions.
+func FuzzParse(app *Application, data []byte) {
+	var args []string
+	for _, arg := range bytes.Split(data, []byte{0}) {
+		args = append(args, string(arg))
+	}
+
+	// errors are expected, only panics are of interest
+	app.Resolve(args)
+
+	// completion runs on partial command lines that fail to parse
+	context := tokenize(args, false)
+	context.app = app
+	context.readOnly = true
+	parse(context, app)
+
+	app.completionOptions(context)
+	app.completionOptions(context.withoutDefaults(app))
+}
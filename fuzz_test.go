@@ -0,0 +1,46 @@
+package fisk
+
+import (
+	"testing"
+)
+
+func FuzzApplicationParse(f *testing.F) {
+	app := newTestApp()
+	app.Flag("debug", "").Short('d').Bool()
+	app.Flag("server", "").Short('s').Default("localhost").String()
+	app.Flag("token", "").Secret().String()
+	stream := app.Command("stream", "").Alias("str")
+	add := stream.Command("add", "").Default()
+	add.Flag("replicas", "").Short('r').Int()
+	add.Flag("subject", "").Strings()
+	add.Flag("storage", "").HintOptions("file", "memory").Enum("file", "memory")
+	add.Arg("name", "").Required().String()
+	stream.Command("ls", "").Arg("filter", "").Strings()
+	app.Command("duration", "").Arg("d", "").Duration()
+
+	for _, seed := range []string{
+		"",
+		"-",
+		"--",
+		"---",
+		"-ds",
+		"-dsx",
+		"--server=",
+		"=",
+		"stream\x00add\x00-r3\x00ORDERS",
+		"str\x00ls\x00a\x00b\x00--\x00-c",
+		"stream\x00add\x00--storage\x00",
+		"--no-debug\x00--token=s3cret\x00stream",
+		"--completion-bash\x00stream\x00--",
+		"duration\x00\"1h",
+		"\xff\xfe--\xc3\x28",
+		"-\xff",
+		"--'\"\x00''",
+	} {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		FuzzParse(app, data)
+	})
+}
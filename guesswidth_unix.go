@@ -6,21 +6,11 @@ package fisk
 import (
 	"io"
 	"os"
-	"strconv"
 	"syscall"
 	"unsafe"
 )
 
 func guessWidth(w io.Writer) int {
-	// check if COLUMNS env is set to comply with
-	// http://pubs.opengroup.org/onlinepubs/009604499/basedefs/xbd_chap08.html
-	colsStr := os.Getenv("COLUMNS")
-	if colsStr != "" {
-		if cols, err := strconv.Atoi(colsStr); err == nil {
-			return cols
-		}
-	}
-
 	if t, ok := w.(*os.File); ok {
 		fd := t.Fd()
 		var dimensions [4]uint16
@@ -0,0 +1,58 @@
+package fisk
+
+import (
+	"runtime"
+)
+
+// conditionalHiddenMixin hides commands and flags when a condition is not met at runtime
+type conditionalHiddenMixin struct {
+	visibleWhen []func() bool
+}
+
+// hiddenByCondition determines if any condition requires hiding the clause
+func (h *conditionalHiddenMixin) hiddenByCondition() bool {
+	for _, visible := range h.visibleWhen {
+		if !visible() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// visibleOnPlatform is a condition that is false when running on any of platforms
+func visibleOnPlatform(platforms []string) func() bool {
+	return func() bool {
+		for _, p := range platforms {
+			if p == runtime.GOOS {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+// HiddenUnless hides the flag from help, completion and the model unless
+// visible returns true when the application is initialized
+func (f *FlagClause) HiddenUnless(visible func() bool) *FlagClause {
+	f.visibleWhen = append(f.visibleWhen, visible)
+	return f
+}
+
+// HiddenOnPlatforms hides the flag when running on any of platforms, like "windows"
+func (f *FlagClause) HiddenOnPlatforms(platforms ...string) *FlagClause {
+	return f.HiddenUnless(visibleOnPlatform(platforms))
+}
+
+// HiddenUnless hides the command from help, completion and the model unless
+// visible returns true when the application is initialized
+func (c *CmdClause) HiddenUnless(visible func() bool) *CmdClause {
+	c.visibleWhen = append(c.visibleWhen, visible)
+	return c
+}
+
+// HiddenOnPlatforms hides the command when running on any of platforms, like "windows"
+func (c *CmdClause) HiddenOnPlatforms(platforms ...string) *CmdClause {
+	return c.HiddenUnless(visibleOnPlatform(platforms))
+}
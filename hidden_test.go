@@ -0,0 +1,33 @@
+package fisk
+
+import (
+	"bytes"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHiddenUnless(t *testing.T) {
+	w := &bytes.Buffer{}
+	licensed := false
+
+	app := newTestApp().UsageWriter(w)
+	app.Flag("enterprise", "Enterprise feature").HiddenUnless(func() bool { return licensed }).Bool()
+	app.Flag("native", "Platform feature").HiddenOnPlatforms(runtime.GOOS).Bool()
+	app.Flag("portable", "Portable feature").HiddenOnPlatforms("plan10").Bool()
+	app.Command("audit", "Enterprise audit").HiddenUnless(func() bool { return licensed })
+	app.Command("run", "Runs")
+
+	app.Parse([]string{"--help"})
+	assert.NotContains(t, w.String(), "enterprise")
+	assert.NotContains(t, w.String(), "native")
+	assert.NotContains(t, w.String(), "audit")
+	assert.Contains(t, w.String(), "portable")
+	assert.True(t, app.Model().Commands[1].Hidden)
+
+	// hidden clauses remain usable
+	app.Reset()
+	_, err := app.Parse([]string{"audit", "--enterprise"})
+	assert.NoError(t, err)
+}
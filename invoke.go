@@ -0,0 +1,92 @@
+package fisk
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Invoke runs the command at cmdPath, like "stream add", as if it was given
+// on the command line without building and tokenizing arguments.
+//
+// Keys of values are the names of flags and arguments in scope for the
+// command, flags are matched before arguments of the same name. Defaults,
+// environment variables, validation and all actions apply as with Parse().
+func (a *Application) Invoke(cmdPath string, values map[string]string) error {
+	err := a.lockedInit()
+	if err != nil {
+		return err
+	}
+
+	context := tokenize(nil, false)
+	context.app = a
+	context.flags = a.flagGroup
+	context.mergeArgs(a.argGroup)
+
+	group := a.cmdGroup
+	for _, name := range strings.Fields(cmdPath) {
+		cmd := group.commands[name]
+		if cmd == nil {
+			return fmt.Errorf("%w %q", ErrExpectedKnownCommand, cmdPath)
+		}
+		context.matchedCmd(cmd)
+		group = cmd.cmdGroup
+	}
+	for cmd := group.defaultSubcommand(); cmd != nil; cmd = cmd.defaultSubcommand() {
+		context.matchedDefaultCmd(cmd)
+	}
+
+	used := map[string]bool{}
+	for _, flag := range context.flags.flagOrder {
+		if v, ok := values[flag.name]; ok && !used[flag.name] {
+			context.matchedFlag(flag, v)
+			used[flag.name] = true
+		}
+	}
+	for _, arg := range context.arguments.args {
+		if v, ok := values[arg.name]; ok && !used[arg.name] {
+			context.matchedArg(arg, v)
+			used[arg.name] = true
+		}
+	}
+
+	var unknown []string
+	for k := range values {
+		if !used[k] {
+			unknown = append(unknown, k)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unknown flags or arguments for %q: %s", cmdPath, strings.Join(unknown, ", "))
+	}
+
+	a.lastContext = context
+
+	if err = a.setDefaults(context); err != nil {
+		return err
+	}
+
+	if err = a.validateFlagsAndArgs(context); err != nil {
+		return err
+	}
+
+	selected, err := a.setValues(context)
+	if err != nil {
+		return err
+	}
+
+	done := a.prepareContext(context)
+	defer done()
+
+	if err = a.applyPreActions(context, true); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	_, err = a.execute(context, selected)
+	a.callRunHooks(context, selected, err, time.Since(start))
+
+	return err
+}
@@ -0,0 +1,40 @@
+package fisk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvoke(t *testing.T) {
+	app := newTestApp()
+	server := app.Flag("server", "").Default("localhost").String()
+	stream := app.Command("stream", "")
+	add := stream.Command("add", "")
+	replicas := add.Flag("replicas", "").Int()
+	name := add.Arg("name", "").Required().String()
+	ran := ""
+	add.Action(func(*ParseContext) error {
+		ran = "add"
+		return nil
+	})
+	stream.Command("ls", "").Default().Action(func(*ParseContext) error {
+		ran = "ls"
+		return nil
+	})
+
+	assert.NoError(t, app.Invoke("stream add", map[string]string{"name": "ORDERS", "replicas": "3"}))
+	assert.Equal(t, "add", ran)
+	assert.Equal(t, "ORDERS", *name)
+	assert.Equal(t, 3, *replicas)
+	assert.Equal(t, "localhost", *server)
+	assert.True(t, app.LastParseContext().SelectedCommand == add)
+
+	assert.NoError(t, app.Invoke("stream", nil))
+	assert.Equal(t, "ls", ran)
+
+	assert.ErrorIs(t, app.Invoke("stream add", map[string]string{"replicas": "3"}), ErrRequiredArgument)
+	assert.ErrorIs(t, app.Invoke("stream rm", nil), ErrExpectedKnownCommand)
+	assert.EqualError(t, app.Invoke("stream add", map[string]string{"name": "x", "size": "1", "other": "1"}), `unknown flags or arguments for "stream add": other, size`)
+	assert.Error(t, app.Invoke("stream add", map[string]string{"name": "x", "replicas": "x"}))
+}
@@ -0,0 +1,125 @@
+package fisk
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// BindLogger sets the logger used by the application and by fisk for its own
+// diagnostics, records are filtered using the level set by the flags added by
+// WithLogLevelFlag() so the handler of logger should accept all levels
+func (a *Application) BindLogger(logger *slog.Logger) *Application {
+	a.logger = slog.New(&levelHandler{level: a.logLevel, handler: logger.Handler()})
+	return a
+}
+
+// WithLogLevelFlag adds global --log-level and --debug flags that set the level
+// of the logger bound using BindLogger(), when no logger is bound one logging
+// to stderr is used
+func (a *Application) WithLogLevelFlag() *Application {
+	a.Flag("log-level", "Sets the logging level").Default("info").HintOptions("debug", "info", "warn", "error").SetValue(&logLevelValue{a.logLevel})
+	a.Flag("debug", "Enables debug logging").SetValue(&debugLevelValue{level: a.logLevel})
+
+	if a.logger == nil {
+		a.logger = slog.New(slog.NewTextHandler(a.errorWriter, &slog.HandlerOptions{Level: a.logLevel}))
+	}
+
+	return a
+}
+
+// LogLevel is the level set by the flags added by WithLogLevelFlag(), it can
+// be used as the level of a slog.Handler
+func (a *Application) LogLevel() *slog.LevelVar {
+	return a.logLevel
+}
+
+// Logger is the logger bound using BindLogger() or WithLogLevelFlag(), when
+// neither were used and the FISK_DEBUG environment variable is set a debug
+// logger writing to stderr is returned otherwise a logger that discards all
+// records
+func (a *Application) Logger() *slog.Logger {
+	if a.logger != nil {
+		return a.logger
+	}
+
+	if os.Getenv("FISK_DEBUG") != "" {
+		return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	}
+
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// Logger is the logger of the application being parsed, see Application.Logger()
+func (p *ParseContext) Logger() *slog.Logger {
+	if p.app == nil {
+		return slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+
+	return p.app.Logger()
+}
+
+type levelHandler struct {
+	level   slog.Leveler
+	handler slog.Handler
+}
+
+func (h *levelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level.Level() && h.handler.Enabled(ctx, level)
+}
+
+func (h *levelHandler) Handle(ctx context.Context, r slog.Record) error {
+	return h.handler.Handle(ctx, r)
+}
+
+func (h *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &levelHandler{level: h.level, handler: h.handler.WithAttrs(attrs)}
+}
+
+func (h *levelHandler) WithGroup(name string) slog.Handler {
+	return &levelHandler{level: h.level, handler: h.handler.WithGroup(name)}
+}
+
+// -- slog.Level Value
+type logLevelValue struct {
+	level *slog.LevelVar
+}
+
+func (l *logLevelValue) Set(s string) error {
+	return l.level.UnmarshalText([]byte(strings.ToUpper(s)))
+}
+
+func (l *logLevelValue) Get() interface{} { return l.level.Level() }
+
+func (l *logLevelValue) String() string { return strings.ToLower(l.level.Level().String()) }
+
+func (l *logLevelValue) Reset() { l.level.Set(slog.LevelInfo) }
+
+// -- debug Value
+type debugLevelValue struct {
+	level *slog.LevelVar
+	set   bool
+}
+
+func (d *debugLevelValue) Set(s string) error {
+	if s == "true" {
+		d.level.Set(slog.LevelDebug)
+		d.set = true
+	}
+	return nil
+}
+
+func (d *debugLevelValue) Get() interface{} { return d.set }
+
+func (d *debugLevelValue) String() string {
+	if d.set {
+		return "true"
+	}
+	return "false"
+}
+
+func (d *debugLevelValue) BoolFlagIsNegatable() bool { return false }
+
+func (d *debugLevelValue) Reset() { d.set = false }
@@ -0,0 +1,42 @@
+package fisk
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBindLogger(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+
+	app := newTestApp().BindLogger(slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))).WithLogLevelFlag()
+	app.Command("run", "").Action(func(pc *ParseContext) error {
+		pc.Logger().Debug("debug message")
+		pc.Logger().Info("info message")
+		return nil
+	})
+
+	_, err := app.Parse([]string{"run"})
+	assert.NoError(t, err)
+	assert.Equal(t, slog.LevelInfo, app.LogLevel().Level())
+	assert.NotContains(t, buf.String(), "debug message")
+	assert.Contains(t, buf.String(), "info message")
+
+	buf.Reset()
+	_, err = app.Parse([]string{"run", "--debug"})
+	assert.NoError(t, err)
+	assert.Equal(t, slog.LevelDebug, app.LogLevel().Level())
+	assert.Contains(t, buf.String(), "debug message")
+	assert.Contains(t, buf.String(), `msg="Parsed command line" command=run`)
+
+	buf.Reset()
+	_, err = app.Parse([]string{"run", "--log-level", "error"})
+	assert.NoError(t, err)
+	assert.Equal(t, slog.LevelError, app.LogLevel().Level())
+	assert.Empty(t, buf.String())
+
+	_, err = app.Parse([]string{"run", "--log-level", "nope"})
+	assert.Error(t, err)
+}
@@ -10,13 +10,19 @@ import (
 
 var (
 	ignoreInCount = map[string]bool{
-		"help":                   true,
-		"help-long":              true,
-		"help-man":               true,
-		"completion-bash":        true,
-		"completion-script-bash": true,
-		"completion-script-zsh":  true,
-		"fisk-introspect":        true,
+		"help":                              true,
+		"help-long":                         true,
+		"help-man":                          true,
+		"completion-bash":                   true,
+		"completion-script-bash":            true,
+		"completion-script-zsh":             true,
+		"completion-script-fish":            true,
+		"completion-command-not-found-bash": true,
+		"completion-command-not-found-zsh":  true,
+		"fisk-introspect":                   true,
+		"fisk-debug-parse":                  true,
+		"fisk-export-env":                   true,
+		"fisk-export-secrets":               true,
 	}
 )
 
@@ -52,14 +58,22 @@ func (f *FlagGroupModel) FlagSummary() string {
 }
 
 type FlagModel struct {
-	Name        string   `json:"name"`
-	Help        string   `json:"help"`
-	Short       rune     `json:"short,omitempty"`
-	Default     []string `json:"default,omitempty"`
-	Envar       string   `json:"envar,omitempty"`
-	PlaceHolder string   `json:"place_holder,omitempty"`
-	Required    bool     `json:"required,omitempty"`
-	Hidden      bool     `json:"hidden,omitempty"`
+	Name         string    `json:"name"`
+	Help         string    `json:"help"`
+	HelpLong     string    `json:"help_long,omitempty"`
+	Short        rune      `json:"short,omitempty"`
+	ShortAliases []rune    `json:"short_aliases,omitempty"`
+	Default      []string  `json:"default,omitempty"`
+	Envar        string    `json:"envar,omitempty"`
+	PlaceHolder  string    `json:"place_holder,omitempty"`
+	Required     bool      `json:"required,omitempty"`
+	Hidden       bool      `json:"hidden,omitempty"`
+	Secret       bool      `json:"secret,omitempty"`
+	Category     string    `json:"category,omitempty"`
+	Group        string    `json:"group,omitempty"`
+	Stability    Stability `json:"stability,omitempty"`
+	Deprecated   string    `json:"deprecated,omitempty"`
+	RemovedIn    string    `json:"removed_in,omitempty"`
 
 	// used by plugin model
 	Boolean    bool `json:"boolean"`
@@ -102,7 +116,7 @@ func (f *FlagModel) FormatPlaceHolder() string {
 	if f.PlaceHolder != "" {
 		return f.PlaceHolder
 	}
-	if len(f.Default) > 0 {
+	if len(f.Default) > 0 && !f.Secret {
 		ellipsis := ""
 		if len(f.Default) > 1 {
 			ellipsis = "..."
@@ -112,14 +126,34 @@ func (f *FlagModel) FormatPlaceHolder() string {
 		}
 		return f.Default[0] + ellipsis
 	}
+	if p := typePlaceHolder(f.Value); p != "" {
+		return p
+	}
 	return strings.ToUpper(f.Name)
 }
 
+// Annotations are the stability and deprecation notes shown after the help
+func (f *FlagModel) Annotations() string {
+	return f.Stability.Suffix() + deprecationSuffix(f.Deprecated, f.RemovedIn)
+}
+
 func (f *FlagModel) HelpWithEnvar() string {
 	if f.Envar == "" {
-		return f.Help
+		return f.Help + f.Annotations()
 	}
-	return fmt.Sprintf("%s ($%s)", f.Help, f.Envar)
+	return fmt.Sprintf("%s%s ($%s)", f.Help, f.Annotations(), f.Envar)
+}
+
+// LongHelpWithEnvar is like HelpWithEnvar but prefers HelpLong when set
+func (f *FlagModel) LongHelpWithEnvar() string {
+	if f.HelpLong == "" {
+		return f.HelpWithEnvar()
+	}
+
+	long := *f
+	long.Help = f.HelpLong
+
+	return long.HelpWithEnvar()
 }
 
 type ArgGroupModel struct {
@@ -161,6 +195,8 @@ type ArgModel struct {
 	PlaceHolder string   `json:"place_holder,omitempty"`
 	Required    bool     `json:"required,omitempty"`
 	Hidden      bool     `json:"hidden,omitempty"`
+	Secret      bool     `json:"secret,omitempty"`
+	Group       string   `json:"group,omitempty"`
 	Value       Value    `json:"-"`
 
 	// used by plugin model
@@ -203,39 +239,306 @@ func (c *CmdGroupModel) FlattenedCommands() (out []*CmdModel) {
 }
 
 type CmdModel struct {
-	Name        string   `json:"name"`
-	Aliases     []string `json:"aliases,omitempty"`
-	Help        string   `json:"help"`
-	HelpLong    string   `json:"help_long,omitempty"`
-	FullCommand string   `json:"-"`
-	Depth       int      `json:"-"`
-	Hidden      bool     `json:"hidden,omitempty"`
-	Default     bool     `json:"default,omitempty"`
+	Name        string    `json:"name"`
+	Aliases     []string  `json:"aliases,omitempty"`
+	Help        string    `json:"help"`
+	HelpLong    string    `json:"help_long,omitempty"`
+	FullCommand string    `json:"-"`
+	Depth       int       `json:"-"`
+	Hidden      bool      `json:"hidden,omitempty"`
+	Default     bool      `json:"default,omitempty"`
+	Category    string    `json:"category,omitempty"`
+	Stability   Stability `json:"stability,omitempty"`
+	Deprecated  string    `json:"deprecated,omitempty"`
+	RemovedIn   string    `json:"removed_in,omitempty"`
+	HelpFooter  string    `json:"help_footer,omitempty"`
+	Cheat       string    `json:"cheat,omitempty"` // the name of the cheat in ApplicationModel.Cheats
+	Plugin      bool      `json:"-"`               // provided by a plugin
+
+	Examples []*CmdExample `json:"examples,omitempty"`
 
 	*FlagGroupModel
 	*ArgGroupModel
 	*CmdGroupModel
 }
 
+// CmdExample is an example invocation of a command
+type CmdExample struct {
+	Command string `json:"command"`
+	Help    string `json:"help,omitempty"`
+}
+
+// Args splits the example into arguments, the leading application name is removed
+func (e *CmdExample) Args(app string) ([]string, error) {
+	args, err := splitCommandLine(e.Command)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(args) > 0 && args[0] == app {
+		args = args[1:]
+	}
+
+	return args, nil
+}
+
+// Annotations are the stability and deprecation notes shown after the command
+func (c *CmdModel) Annotations() string {
+	return c.Stability.Suffix() + deprecationSuffix(c.Deprecated, c.RemovedIn)
+}
+
 func (c *CmdModel) String() string {
 	return c.FullCommand
 }
 
 type ApplicationModel struct {
-	Name      string            `json:"name"`
-	Help      string            `json:"help"`
-	Cheat     string            `json:"cheat,omitempty"`
-	Version   string            `json:"version,omitempty"`
-	Author    string            `json:"author,omitempty"`
-	Cheats    map[string]string `json:"cheats,omitempty"`
-	CheatTags []string          `json:"cheat_tags,omitempty"`
+	Name       string            `json:"name"`
+	Help       string            `json:"help"`
+	Cheat      string            `json:"cheat,omitempty"`
+	Version    string            `json:"version,omitempty"`
+	Author     string            `json:"author,omitempty"`
+	Cheats     map[string]string `json:"cheats,omitempty"`
+	CheatTags  []string          `json:"cheat_tags,omitempty"`
+	Shortcuts  map[string]string `json:"shortcuts,omitempty"`
+	HelpHeader string            `json:"help_header,omitempty"`
+	HelpFooter string            `json:"help_footer,omitempty"`
 
 	*ArgGroupModel
 	*CmdGroupModel
 	*FlagGroupModel
+
+	index *modelIndex
 }
 
+// modelIndex finds the models of clauses in a cached ApplicationModel
+type modelIndex struct {
+	cmds  map[*CmdClause]*CmdModel
+	flags map[*FlagClause]*FlagModel
+	args  map[*ArgClause]*ArgModel
+}
+
+// cachedModelIndex indexes the cached model, nil when the model is not cached
+func (a *Application) cachedModelIndex() *modelIndex {
+	if !a.initialized {
+		return nil
+	}
+
+	a.modelMu.Lock()
+	defer a.modelMu.Unlock()
+
+	model := a.lockedModel()
+	if model.index == nil {
+		idx := &modelIndex{
+			cmds:  map[*CmdClause]*CmdModel{},
+			flags: map[*FlagClause]*FlagModel{},
+			args:  map[*ArgClause]*ArgModel{},
+		}
+		idx.add(a.flagGroup, model.FlagGroupModel, a.argGroup, model.ArgGroupModel, a.cmdGroup, model.CmdGroupModel)
+		model.index = idx
+	}
+
+	return model.index
+}
+
+func (idx *modelIndex) add(flags *flagGroup, flagsModel *FlagGroupModel, args *argGroup, argsModel *ArgGroupModel, cmds *cmdGroup, cmdsModel *CmdGroupModel) {
+	// models built before a clause was added do not line up, those are built on demand instead
+	if len(flags.flagOrder) != len(flagsModel.Flags) || len(args.args) != len(argsModel.Args) || len(cmds.commandOrder) != len(cmdsModel.Commands) {
+		return
+	}
+
+	for i, flag := range flags.flagOrder {
+		idx.flags[flag] = flagsModel.Flags[i]
+	}
+	for i, arg := range args.args {
+		idx.args[arg] = argsModel.Args[i]
+	}
+	for i, cmd := range cmds.commandOrder {
+		cm := cmdsModel.Commands[i]
+		idx.cmds[cmd] = cm
+		idx.add(cmd.flagGroup, cm.FlagGroupModel, cmd.argGroup, cm.ArgGroupModel, cmd.cmdGroup, cm.CmdGroupModel)
+	}
+}
+
+// flagGroupModel is the model for flags reusing cached models where possible
+func (idx *modelIndex) flagGroupModel(flags *flagGroup) *FlagGroupModel {
+	if idx == nil {
+		return flags.Model()
+	}
+
+	m := &FlagGroupModel{Flags: make([]*FlagModel, 0, len(flags.flagOrder))}
+	for _, flag := range flags.flagOrder {
+		fm, ok := idx.flags[flag]
+		if !ok {
+			fm = flag.Model()
+		}
+		m.Flags = append(m.Flags, fm)
+	}
+
+	return m
+}
+
+// argGroupModel is the model for arguments reusing cached models where possible
+func (idx *modelIndex) argGroupModel(args *argGroup) *ArgGroupModel {
+	if idx == nil {
+		return args.Model()
+	}
+
+	m := &ArgGroupModel{}
+	for _, arg := range args.args {
+		am, ok := idx.args[arg]
+		if !ok {
+			am = arg.Model()
+		}
+		m.Args = append(m.Args, am)
+	}
+
+	return m
+}
+
+// cmdModel is the model for a command reusing the cached model where possible
+func (idx *modelIndex) cmdModel(cmd *CmdClause) *CmdModel {
+	if idx != nil {
+		if cm, ok := idx.cmds[cmd]; ok {
+			return cm
+		}
+	}
+
+	return cmd.Model()
+}
+
+// Model describes the application. Once the application is initialized the
+// model is built once and reused until flags, arguments or commands are added
+// or retrieved for changes using GetFlag(), GetArg() or GetCommand(), the
+// result is a copy that callers can change freely.
 func (a *Application) Model() *ApplicationModel {
+	a.modelMu.Lock()
+	defer a.modelMu.Unlock()
+
+	return a.lockedModel().copy()
+}
+
+// sharedModel is the cached model when the application is initialized, it must not be changed
+func (a *Application) sharedModel() *ApplicationModel {
+	a.modelMu.Lock()
+	defer a.modelMu.Unlock()
+
+	return a.lockedModel()
+}
+
+func (a *Application) lockedModel() *ApplicationModel {
+	if !a.initialized {
+		return a.buildModel()
+	}
+
+	// Name and Help are fields that can be changed without a builder
+	if a.model == nil || a.model.Name != a.Name || a.model.Help != a.Help {
+		a.model = a.buildModel()
+	}
+
+	return a.model
+}
+
+// modelChanged discards the cached model after the application was changed
+func (a *Application) modelChanged() {
+	if a == nil {
+		return
+	}
+
+	a.modelMu.Lock()
+	a.model = nil
+	a.modelMu.Unlock()
+}
+
+// copy is a deep copy of the model without the index of the cached model
+func (m *ApplicationModel) copy() *ApplicationModel {
+	c := *m
+	c.index = nil
+	c.Cheats = copyStringMap(m.Cheats)
+	c.CheatTags = copyStrings(m.CheatTags)
+	c.Shortcuts = copyStringMap(m.Shortcuts)
+	c.FlagGroupModel = m.FlagGroupModel.copy()
+	c.ArgGroupModel = m.ArgGroupModel.copy()
+	c.CmdGroupModel = m.CmdGroupModel.copy()
+
+	return &c
+}
+
+func (f *FlagGroupModel) copy() *FlagGroupModel {
+	if f == nil {
+		return nil
+	}
+
+	c := &FlagGroupModel{}
+	for _, flag := range f.Flags {
+		fm := *flag
+		fm.ShortAliases = append([]rune(nil), flag.ShortAliases...)
+		fm.Default = copyStrings(flag.Default)
+		c.Flags = append(c.Flags, &fm)
+	}
+
+	return c
+}
+
+func (a *ArgGroupModel) copy() *ArgGroupModel {
+	if a == nil {
+		return nil
+	}
+
+	c := &ArgGroupModel{}
+	for _, arg := range a.Args {
+		am := *arg
+		am.Default = copyStrings(arg.Default)
+		c.Args = append(c.Args, &am)
+	}
+
+	return c
+}
+
+func (g *CmdGroupModel) copy() *CmdGroupModel {
+	if g == nil {
+		return nil
+	}
+
+	c := &CmdGroupModel{}
+	for _, cmd := range g.Commands {
+		cm := *cmd
+		cm.Aliases = copyStrings(cmd.Aliases)
+		cm.Examples = nil
+		for _, example := range cmd.Examples {
+			e := *example
+			cm.Examples = append(cm.Examples, &e)
+		}
+		cm.FlagGroupModel = cmd.FlagGroupModel.copy()
+		cm.ArgGroupModel = cmd.ArgGroupModel.copy()
+		cm.CmdGroupModel = cmd.CmdGroupModel.copy()
+		c.Commands = append(c.Commands, &cm)
+	}
+
+	return c
+}
+
+func copyStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+
+	return append([]string{}, s...)
+}
+
+func copyStringMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+
+	c := make(map[string]string, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+
+	return c
+}
+
+func (a *Application) buildModel() *ApplicationModel {
 	return &ApplicationModel{
 		Name:           a.Name,
 		Help:           a.Help,
@@ -243,6 +546,9 @@ func (a *Application) Model() *ApplicationModel {
 		Author:         a.author,
 		Cheats:         a.cheats,
 		CheatTags:      a.cheatTags,
+		Shortcuts:      a.shortcuts,
+		HelpHeader:     a.helpHeader,
+		HelpFooter:     a.helpFooter,
 		FlagGroupModel: a.flagGroup.Model(),
 		ArgGroupModel:  a.argGroup.Model(),
 		CmdGroupModel:  a.cmdGroup.Model(),
@@ -266,6 +572,8 @@ func (a *ArgClause) Model() *ArgModel {
 		PlaceHolder: a.placeholder,
 		Required:    a.required,
 		Hidden:      a.hidden,
+		Secret:      a.secret,
+		Group:       a.group,
 		Value:       a.value,
 	}
 
@@ -284,15 +592,23 @@ func (f *flagGroup) Model() *FlagGroupModel {
 
 func (f *FlagClause) Model() *FlagModel {
 	m := &FlagModel{
-		Name:        f.name,
-		Help:        f.help,
-		Short:       f.shorthand,
-		Default:     f.defaultValues,
-		Envar:       f.envar,
-		PlaceHolder: f.placeholder,
-		Required:    f.required,
-		Hidden:      f.hidden,
-		Value:       f.value,
+		Name:         f.name,
+		Help:         f.help,
+		HelpLong:     f.helpLong,
+		Short:        f.shorthand,
+		ShortAliases: f.shortAliases,
+		Default:      f.defaultValues,
+		Envar:        f.envar,
+		PlaceHolder:  f.placeholder,
+		Required:     f.required,
+		Hidden:       f.hidden,
+		Category:     f.category,
+		Group:        f.group,
+		Stability:    f.stability,
+		Deprecated:   f.deprecated,
+		RemovedIn:    f.removedIn,
+		Secret:       f.secret,
+		Value:        f.value,
 	}
 
 	m.Boolean = m.IsBoolFlag()
@@ -322,7 +638,15 @@ func (c *CmdClause) Model() *CmdModel {
 		HelpLong:       c.helpLong,
 		Depth:          depth,
 		Hidden:         c.hidden,
+		Category:       c.category,
+		Stability:      c.stability,
+		Deprecated:     c.deprecated,
+		RemovedIn:      c.removedIn,
+		HelpFooter:     c.helpFooter,
 		Default:        c.isDefault,
+		Cheat:          c.cheat,
+		Plugin:         c.pluginDelegator != nil,
+		Examples:       c.examples,
 		FullCommand:    c.FullCommand(),
 		FlagGroupModel: c.flagGroup.Model(),
 		ArgGroupModel:  c.argGroup.Model(),
@@ -0,0 +1,112 @@
+package fisk
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModelIsCached(t *testing.T) {
+	var buf bytes.Buffer
+
+	app := newTestApp().UsageWriter(&buf)
+	app.Flag("server", "").Default("localhost").String()
+	app.Command("add", "")
+
+	assert.NotSame(t, app.sharedModel(), app.sharedModel())
+
+	assert.NoError(t, app.init())
+	model := app.sharedModel()
+	assert.Same(t, model, app.sharedModel())
+
+	// callers get copies they can change without affecting the application
+	changed := app.Model()
+	assert.NotSame(t, model, changed)
+	changed.Commands[1].Name = "changed"
+	server := len(changed.Flags) - 1
+	changed.Flags[server].Default[0] = "changed"
+	changed.Cheats["changed"] = "changed"
+	assert.Equal(t, "add", app.Model().Commands[1].Name)
+	assert.Equal(t, []string{"localhost"}, app.Model().Flags[server].Default)
+	assert.Empty(t, app.Model().Cheats)
+	pc, err := app.ParseContext([]string{})
+	assert.NoError(t, err)
+	assert.NoError(t, app.UsageForContextWithTemplate(pc, 2, CompactUsageTemplate))
+	assert.Contains(t, buf.String(), "localhost")
+	assert.NotContains(t, buf.String(), "changed")
+
+	app.Command("rm", "")
+	assert.NotSame(t, model, app.sharedModel())
+	assert.Len(t, app.Model().Commands, 3)
+
+	app.Name = "other"
+	app.HelpFooter("footer")
+	assert.Equal(t, "other", app.Model().Name)
+	assert.Equal(t, "footer", app.Model().HelpFooter)
+}
+
+func TestModelAfterChanges(t *testing.T) {
+	app := newTestApp()
+	app.Command("add", "")
+
+	_, err := app.Parse([]string{"add"})
+	assert.NoError(t, err)
+	assert.Len(t, app.Model().Commands[1].Flags, 0)
+
+	app.GetCommand("add").Flag("replicas", "").Int()
+	app.GetCommand("add").Help("Adds a stream")
+	app.GetArg("missing")
+	model := app.Model()
+	assert.Len(t, model.Commands[1].Flags, 1)
+	assert.Equal(t, "Adds a stream", model.Commands[1].Help)
+
+	app.Model()
+	app.Flag("server", "").String()
+	flags := app.cachedModelIndex().flagGroupModel(app.flagGroup).Flags
+	assert.Len(t, flags, len(app.flagGroup.flagOrder))
+	assert.Equal(t, "server", flags[len(flags)-1].Name)
+}
+
+func TestModelCategoriesSurvivePlugins(t *testing.T) {
+	plugin := newTestApp()
+	plugin.Flag("server", "").Category("Connection").String()
+	add := plugin.Command("add", "").Category("Management").Stability(Beta).Example("app add x", "Adds x")
+	add.Arg("name", "").Group("Identity").String()
+
+	j, err := json.Marshal(plugin.introspectModel())
+	assert.NoError(t, err)
+
+	host := newTestApp()
+	cmd, err := host.ExternalPluginCommand("/bin/true", j, "plugin", "A plugin")
+	assert.NoError(t, err)
+
+	model := cmd.Model()
+	assert.Equal(t, "Connection", model.Flags[0].Category)
+	sub := model.Commands[0]
+	assert.Equal(t, "Management", sub.Category)
+	assert.Equal(t, Beta, sub.Stability)
+	assert.Equal(t, "Identity", sub.Args[0].Group)
+	assert.Equal(t, "app add x", sub.Examples[0].Command)
+}
+
+func TestModelCommandCheats(t *testing.T) {
+	plugin := newTestApp().WithCheats()
+	plugin.Command("add", "").Cheat("adding", "app add x").Example("app add x", "")
+	plugin.Command("rm", "").Cheat("", "app rm x")
+
+	model := plugin.introspectModel()
+	assert.Equal(t, "adding", model.Commands[0].Cheat)
+	assert.Equal(t, "rm", model.Commands[1].Cheat)
+	assert.Equal(t, "app rm x", model.Cheats[model.Commands[1].Cheat])
+
+	j, err := json.Marshal(model)
+	assert.NoError(t, err)
+
+	host := newTestApp()
+	cmd, err := host.ExternalPluginCommand("/bin/true", j, "plugin", "A plugin")
+	assert.NoError(t, err)
+	assert.Equal(t, "adding", cmd.GetCommand("add").Model().Cheat)
+	assert.Len(t, cmd.GetCommand("add").Model().Examples, 1)
+}
@@ -0,0 +1,287 @@
+package fisk
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ModelChangeKind is the kind of difference between two models
+type ModelChangeKind string
+
+const (
+	ModelAdded   ModelChangeKind = "added"
+	ModelRemoved ModelChangeKind = "removed"
+	ModelChanged ModelChangeKind = "changed"
+)
+
+// ModelChange is a single difference found by DiffModelJSON()
+type ModelChange struct {
+	Kind ModelChangeKind `json:"kind"`
+	// Element is one of application, command, flag or argument
+	Element string `json:"element"`
+	// Path identifies the element like the keys used by Thaw(), for example
+	// "stream add", "stream add --replicas" or "stream add <name>"
+	Path string `json:"path,omitempty"`
+	// Field is the changed property of changed elements
+	Field string `json:"field,omitempty"`
+	Old   string `json:"old,omitempty"`
+	New   string `json:"new,omitempty"`
+	// Breaking is set when existing invocations might fail after the change
+	Breaking bool `json:"breaking,omitempty"`
+}
+
+func (c *ModelChange) String() string {
+	path := c.Path
+	if path == "" {
+		path = c.Element
+	} else {
+		path = c.Element + " " + path
+	}
+
+	var s string
+	switch c.Kind {
+	case ModelAdded:
+		s = "+ " + path
+	case ModelRemoved:
+		s = "- " + path
+	default:
+		s = fmt.Sprintf("~ %s: %s %q -> %q", path, c.Field, c.Old, c.New)
+	}
+
+	if c.Breaking {
+		s += " (breaking)"
+	}
+
+	return s
+}
+
+// Report lists the differences between two models
+type Report struct {
+	Changes []*ModelChange `json:"changes"`
+}
+
+// HasChanges determines if any differences were found
+func (r *Report) HasChanges() bool {
+	return len(r.Changes) > 0
+}
+
+// Breaking determines if any change might break existing invocations
+func (r *Report) Breaking() bool {
+	for _, c := range r.Changes {
+		if c.Breaking {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String renders the report for humans, one change per line
+func (r *Report) String() string {
+	if !r.HasChanges() {
+		return "No changes\n"
+	}
+
+	var b strings.Builder
+	for _, c := range r.Changes {
+		b.WriteString(c.String())
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// DiffModelJSON compares two models as produced by --fisk-introspect or
+// Freeze(), a is the old model and b the new one
+func DiffModelJSON(a, b []byte) (Report, error) {
+	var old, cur ApplicationModel
+
+	err := json.Unmarshal(a, &old)
+	if err != nil {
+		return Report{}, fmt.Errorf("invalid old model: %w", err)
+	}
+	err = json.Unmarshal(b, &cur)
+	if err != nil {
+		return Report{}, fmt.Errorf("invalid new model: %w", err)
+	}
+
+	d := &modelDiffer{}
+	d.field("application", "", "name", old.Name, cur.Name, true)
+	d.field("application", "", "help", old.Help, cur.Help, false)
+	d.field("application", "", "version", old.Version, cur.Version, false)
+	d.flags(nil, old.FlagGroupModel, cur.FlagGroupModel)
+	d.args(nil, old.ArgGroupModel, cur.ArgGroupModel)
+	d.commands(nil, old.CmdGroupModel, cur.CmdGroupModel)
+
+	return Report{Changes: d.changes}, nil
+}
+
+type modelDiffer struct {
+	changes []*ModelChange
+}
+
+func (d *modelDiffer) add(change *ModelChange) {
+	d.changes = append(d.changes, change)
+}
+
+func (d *modelDiffer) field(element string, path string, field string, old string, cur string, breaking bool) {
+	if old == cur {
+		return
+	}
+
+	d.add(&ModelChange{Kind: ModelChanged, Element: element, Path: path, Field: field, Old: old, New: cur, Breaking: breaking})
+}
+
+func diffShort(r rune) string {
+	if r == 0 {
+		return ""
+	}
+
+	return string(r)
+}
+
+func diffPath(path []string, name string) string {
+	return strings.Join(append(append([]string{}, path...), name), " ")
+}
+
+func (d *modelDiffer) flags(path []string, old *FlagGroupModel, cur *FlagGroupModel) {
+	var oldFlags, curFlags []*FlagModel
+	if old != nil {
+		oldFlags = old.Flags
+	}
+	if cur != nil {
+		curFlags = cur.Flags
+	}
+
+	known := map[string]*FlagModel{}
+	for _, f := range oldFlags {
+		known[f.Name] = f
+	}
+
+	for _, f := range curFlags {
+		p := diffPath(path, "--"+f.Name)
+		o, ok := known[f.Name]
+		if !ok {
+			d.add(&ModelChange{Kind: ModelAdded, Element: "flag", Path: p, Breaking: f.Required && len(f.Default) == 0})
+			continue
+		}
+		delete(known, f.Name)
+
+		d.field("flag", p, "short", diffShort(o.Short), diffShort(f.Short), o.Short != 0)
+		d.field("flag", p, "short aliases", string(o.ShortAliases), string(f.ShortAliases), len(o.ShortAliases) > 0)
+		d.field("flag", p, "help", o.Help, f.Help, false)
+		d.field("flag", p, "default", strings.Join(o.Default, ","), strings.Join(f.Default, ","), false)
+		d.field("flag", p, "envar", o.Envar, f.Envar, o.Envar != "")
+		d.field("flag", p, "required", strconv.FormatBool(o.Required), strconv.FormatBool(f.Required), f.Required)
+		d.field("flag", p, "boolean", strconv.FormatBool(o.Boolean), strconv.FormatBool(f.Boolean), true)
+		d.field("flag", p, "negatable", strconv.FormatBool(o.Negatable), strconv.FormatBool(f.Negatable), o.Negatable)
+		d.field("flag", p, "cumulative", strconv.FormatBool(o.Cumulative), strconv.FormatBool(f.Cumulative), o.Cumulative)
+		d.field("flag", p, "hidden", strconv.FormatBool(o.Hidden), strconv.FormatBool(f.Hidden), false)
+		d.field("flag", p, "secret", strconv.FormatBool(o.Secret), strconv.FormatBool(f.Secret), false)
+		d.field("flag", p, "stability", string(o.Stability), string(f.Stability), f.Stability == Alpha)
+		d.field("flag", p, "deprecated", o.Deprecated, f.Deprecated, false)
+	}
+
+	for _, f := range oldFlags {
+		if known[f.Name] != nil {
+			d.add(&ModelChange{Kind: ModelRemoved, Element: "flag", Path: diffPath(path, "--"+f.Name), Breaking: true})
+		}
+	}
+}
+
+func (d *modelDiffer) args(path []string, old *ArgGroupModel, cur *ArgGroupModel) {
+	var oldArgs, curArgs []*ArgModel
+	if old != nil {
+		oldArgs = old.Args
+	}
+	if cur != nil {
+		curArgs = cur.Args
+	}
+
+	known := map[string]int{}
+	for i, a := range oldArgs {
+		known[a.Name] = i
+	}
+
+	for i, a := range curArgs {
+		p := diffPath(path, "<"+a.Name+">")
+		oi, ok := known[a.Name]
+		if !ok {
+			d.add(&ModelChange{Kind: ModelAdded, Element: "argument", Path: p, Breaking: a.Required})
+			continue
+		}
+		delete(known, a.Name)
+
+		o := oldArgs[oi]
+		d.field("argument", p, "position", strconv.Itoa(oi+1), strconv.Itoa(i+1), true)
+		d.field("argument", p, "help", o.Help, a.Help, false)
+		d.field("argument", p, "default", strings.Join(o.Default, ","), strings.Join(a.Default, ","), false)
+		d.field("argument", p, "envar", o.Envar, a.Envar, o.Envar != "")
+		d.field("argument", p, "required", strconv.FormatBool(o.Required), strconv.FormatBool(a.Required), a.Required)
+		d.field("argument", p, "cumulative", strconv.FormatBool(o.Cumulative), strconv.FormatBool(a.Cumulative), o.Cumulative)
+		d.field("argument", p, "hidden", strconv.FormatBool(o.Hidden), strconv.FormatBool(a.Hidden), false)
+		d.field("argument", p, "secret", strconv.FormatBool(o.Secret), strconv.FormatBool(a.Secret), false)
+	}
+
+	for _, a := range oldArgs {
+		if _, ok := known[a.Name]; ok {
+			d.add(&ModelChange{Kind: ModelRemoved, Element: "argument", Path: diffPath(path, "<"+a.Name+">"), Breaking: true})
+		}
+	}
+}
+
+func (d *modelDiffer) commands(path []string, old *CmdGroupModel, cur *CmdGroupModel) {
+	var oldCmds, curCmds []*CmdModel
+	if old != nil {
+		oldCmds = old.Commands
+	}
+	if cur != nil {
+		curCmds = cur.Commands
+	}
+
+	known := map[string]*CmdModel{}
+	for _, c := range oldCmds {
+		known[c.Name] = c
+	}
+
+	for _, c := range curCmds {
+		cmdPath := append(append([]string{}, path...), c.Name)
+		p := strings.Join(cmdPath, " ")
+		o, ok := known[c.Name]
+		if !ok {
+			d.add(&ModelChange{Kind: ModelAdded, Element: "command", Path: p})
+			continue
+		}
+		delete(known, c.Name)
+
+		aliases := map[string]bool{}
+		for _, alias := range c.Aliases {
+			aliases[alias] = true
+		}
+		var droppedAlias bool
+		for _, alias := range o.Aliases {
+			if !aliases[alias] {
+				droppedAlias = true
+			}
+		}
+
+		d.field("command", p, "aliases", strings.Join(o.Aliases, ","), strings.Join(c.Aliases, ","), droppedAlias)
+		d.field("command", p, "help", o.Help, c.Help, false)
+		d.field("command", p, "default", strconv.FormatBool(o.Default), strconv.FormatBool(c.Default), o.Default)
+		d.field("command", p, "hidden", strconv.FormatBool(o.Hidden), strconv.FormatBool(c.Hidden), false)
+		d.field("command", p, "stability", string(o.Stability), string(c.Stability), c.Stability == Alpha)
+		d.field("command", p, "deprecated", o.Deprecated, c.Deprecated, false)
+
+		d.flags(cmdPath, o.FlagGroupModel, c.FlagGroupModel)
+		d.args(cmdPath, o.ArgGroupModel, c.ArgGroupModel)
+		d.commands(cmdPath, o.CmdGroupModel, c.CmdGroupModel)
+	}
+
+	for _, c := range oldCmds {
+		if known[c.Name] != nil {
+			d.add(&ModelChange{Kind: ModelRemoved, Element: "command", Path: diffPath(path, c.Name), Breaking: true})
+		}
+	}
+}
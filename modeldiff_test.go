@@ -0,0 +1,45 @@
+package fisk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffModelJSON(t *testing.T) {
+	build := func(v2 bool) []byte {
+		app := newTestApp()
+		app.Flag("server", "The server").Short('s').String()
+		stream := app.Command("stream", "Streams")
+		add := stream.Command("add", "Adds")
+		add.Arg("name", "The name").Required().String()
+		if v2 {
+			add.Flag("replicas", "Replicas").Default("3").Int()
+			stream.Command("ls", "Lists").Alias("list")
+		} else {
+			add.Flag("replicas", "Replicas").Default("1").Int()
+			stream.Command("ls", "Lists").Alias("list").Alias("l")
+			stream.Command("rm", "Removes")
+		}
+
+		j, err := app.Freeze()
+		assert.NoError(t, err)
+		return j
+	}
+
+	report, err := DiffModelJSON(build(false), build(false))
+	assert.NoError(t, err)
+	assert.False(t, report.HasChanges())
+	assert.Equal(t, "No changes\n", report.String())
+
+	report, err = DiffModelJSON(build(false), build(true))
+	assert.NoError(t, err)
+	assert.True(t, report.Breaking())
+	assert.Equal(t, `~ flag stream add --replicas: default "1" -> "3"
+~ command stream ls: aliases "list,l" -> "list" (breaking)
+- command stream rm (breaking)
+`, report.String())
+
+	_, err = DiffModelJSON([]byte("x"), build(true))
+	assert.ErrorContains(t, err, "invalid old model")
+}
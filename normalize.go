@@ -0,0 +1,89 @@
+package fisk
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// normalizeMixin holds the normalizations applied to values before they are validated and set
+type normalizeMixin struct {
+	normalizers []func(string) string
+}
+
+func (n *normalizeMixin) normalize(value string) string {
+	for _, normalizer := range n.normalizers {
+		value = normalizer(value)
+	}
+
+	return value
+}
+
+// TrimSpace removes leading and trailing white space from values before they are validated and set
+func (f *FlagClause) TrimSpace() *FlagClause {
+	f.normalizers = append(f.normalizers, strings.TrimSpace)
+	return f
+}
+
+// ToLower converts values to lower case before they are validated and set
+func (f *FlagClause) ToLower() *FlagClause {
+	f.normalizers = append(f.normalizers, strings.ToLower)
+	return f
+}
+
+// NFC converts values to Unicode Normalization Form C before they are validated and set
+func (f *FlagClause) NFC() *FlagClause {
+	f.normalizers = append(f.normalizers, norm.NFC.String)
+	return f
+}
+
+// LenientNumbers accepts numbers formatted like 1_000_000, 1,000,000 or 1e6
+// for integer and float values, commas are always thousands separators
+func (f *FlagClause) LenientNumbers() *FlagClause {
+	f.normalizers = append(f.normalizers, normalizeNumber)
+	return f
+}
+
+// TrimSpace removes leading and trailing white space from values before they are validated and set
+func (a *ArgClause) TrimSpace() *ArgClause {
+	a.normalizers = append(a.normalizers, strings.TrimSpace)
+	return a
+}
+
+// ToLower converts values to lower case before they are validated and set
+func (a *ArgClause) ToLower() *ArgClause {
+	a.normalizers = append(a.normalizers, strings.ToLower)
+	return a
+}
+
+// NFC converts values to Unicode Normalization Form C before they are validated and set
+func (a *ArgClause) NFC() *ArgClause {
+	a.normalizers = append(a.normalizers, norm.NFC.String)
+	return a
+}
+
+// LenientNumbers accepts numbers formatted like 1_000_000, 1,000,000 or 1e6
+// for integer and float values, commas are always thousands separators
+func (a *ArgClause) LenientNumbers() *ArgClause {
+	a.normalizers = append(a.normalizers, normalizeNumber)
+	return a
+}
+
+// normalizeNumber removes digit separators and expands integral exponents so 1e6 parses as an integer
+func normalizeNumber(value string) string {
+	v := strings.NewReplacer("_", "", ",", "").Replace(strings.TrimSpace(value))
+
+	unsigned := strings.ToLower(strings.TrimLeft(v, "+-"))
+	if strings.HasPrefix(unsigned, "0x") || !strings.Contains(unsigned, "e") {
+		return v
+	}
+
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || f != math.Trunc(f) || math.Abs(f) >= 1<<63 {
+		return v
+	}
+
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
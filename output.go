@@ -0,0 +1,85 @@
+package fisk
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TableRenderer can be implemented by values passed to RenderOutput() to control how they render as a table
+type TableRenderer interface {
+	RenderTable(w io.Writer) error
+}
+
+// OutputFormatFlag adds a global --output (-o) flag accepting one of formats,
+// the first format is the default. The selected format is available using
+// ParseContext.OutputFormat() and can be passed to RenderOutput()
+func (a *Application) OutputFormatFlag(formats ...string) *Application {
+	if len(formats) == 0 {
+		formats = []string{"table", "json", "yaml"}
+	}
+
+	a.Flag("output", "Output format").Short('o').Default(formats[0]).EnumVar(&a.outputFormat, formats...)
+	return a
+}
+
+// OutputFormat is the format selected using the flag added by OutputFormatFlag()
+func (p *ParseContext) OutputFormat() string {
+	if p.app == nil {
+		return ""
+	}
+
+	return p.app.outputFormat
+}
+
+// RenderOutput writes v to w in the given format, supported formats are json,
+// yaml, table and text. Tables are rendered using TableRenderer when implemented,
+// [][]string values are rendered as aligned columns and other values using fmt
+func RenderOutput(w io.Writer, format string, v interface{}) error {
+	switch format {
+	case "json":
+		j, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(j))
+		return err
+
+	case "yaml":
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+		return enc.Close()
+
+	case "table", "text":
+		switch t := v.(type) {
+		case TableRenderer:
+			return t.RenderTable(w)
+
+		case [][]string:
+			tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+			for _, row := range t {
+				for i, col := range row {
+					if i > 0 {
+						fmt.Fprint(tw, "\t")
+					}
+					fmt.Fprint(tw, col)
+				}
+				fmt.Fprintln(tw)
+			}
+			return tw.Flush()
+
+		default:
+			_, err := fmt.Fprintln(w, v)
+			return err
+		}
+
+	default:
+		return fmt.Errorf("unsupported output format %q", format)
+	}
+}
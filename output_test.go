@@ -0,0 +1,47 @@
+package fisk
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputFormatFlag(t *testing.T) {
+	var format string
+
+	app := newTestApp().OutputFormatFlag("table", "json")
+	app.Action(func(pc *ParseContext) error {
+		format = pc.OutputFormat()
+		return nil
+	})
+
+	_, err := app.Parse([]string{})
+	assert.NoError(t, err)
+	assert.Equal(t, "table", format)
+
+	_, err = app.Parse([]string{"-o", "json"})
+	assert.NoError(t, err)
+	assert.Equal(t, "json", format)
+
+	_, err = app.Parse([]string{"-o", "yaml"})
+	assert.Error(t, err)
+}
+
+func TestRenderOutput(t *testing.T) {
+	v := map[string]interface{}{"name": "ORDERS", "replicas": 3}
+	buf := bytes.NewBuffer(nil)
+
+	assert.NoError(t, RenderOutput(buf, "json", v))
+	assert.Equal(t, "{\n  \"name\": \"ORDERS\",\n  \"replicas\": 3\n}\n", buf.String())
+
+	buf.Reset()
+	assert.NoError(t, RenderOutput(buf, "yaml", v))
+	assert.Equal(t, "name: ORDERS\nreplicas: 3\n", buf.String())
+
+	buf.Reset()
+	assert.NoError(t, RenderOutput(buf, "table", [][]string{{"Name", "Replicas"}, {"ORDERS", "3"}}))
+	assert.Equal(t, "Name    Replicas\nORDERS  3\n", buf.String())
+
+	assert.Error(t, RenderOutput(buf, "xml", v))
+}
@@ -0,0 +1,88 @@
+package fisk
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ParseOptions configures ParseOrExit()
+type ParseOptions struct {
+	// UsageOnError shows the help of the selected command after usage errors,
+	// or the list of subcommands when one is required, like MustParseWithUsage()
+	UsageOnError bool
+	// ExitCodes sets the exit code for errors matching the keys using errors.Is(),
+	// other errors exit using ExitCodeFor()
+	ExitCodes map[error]int
+	// Writer receives errors and usage shown after errors, defaults to the
+	// writers of the application or the selected command
+	Writer io.Writer
+	// PromptMissing prompts for missing required flags and arguments, see Application.PromptMissing()
+	PromptMissing bool
+}
+
+func (o *ParseOptions) exitCode(err error) int {
+	for target, code := range o.ExitCodes {
+		if errors.Is(err, target) {
+			return code
+		}
+	}
+
+	return ExitCodeFor(err)
+}
+
+// ParseOrExit parses args using Parse() and returns the selected command, on
+// error it is shown as configured by opts and the application terminates
+func (a *Application) ParseOrExit(args []string, opts ParseOptions) string {
+	if opts.PromptMissing {
+		a.PromptMissing()
+	}
+
+	cmd, err := a.Parse(args)
+	if err == nil {
+		return cmd
+	}
+
+	w := opts.Writer
+	if w == nil {
+		w = a.errorWriterFor(a.lastContext)
+	}
+
+	if !opts.UsageOnError {
+		fmt.Fprintf(w, "%s: error: %v, try --help\n", a.Name, err)
+		a.terminate(opts.exitCode(err))
+		return ""
+	}
+
+	ut := styledTemplate(a.usageTemplate)
+
+	switch {
+	case errorIs(err, ErrSubCommandRequired):
+		fmt.Fprintf(w, "error: a subcommand from the list below is required, use --help for full help including flags and arguments\n\n")
+		ut = styledTemplate(a.errorUsageTemplate)
+
+	case errorIs(err, ErrExpectedKnownCommand):
+		fmt.Fprintf(w, "error: %v, use --help for full help including flags and arguments\n\n", err)
+		ut = styledTemplate(a.errorUsageTemplate)
+
+	case errorIs(err, ErrRequiredArgument, ErrRequiredFlag, ErrUnknownLongFlag, ErrUnknownShortFlag, ErrExpectedFlagArgument, ErrFlagCannotRepeat, ErrUnexpectedArgument, ErrDuplicateCommand):
+		fmt.Fprintf(w, "error: %v\n\n", err)
+
+	default:
+		fmt.Fprintf(w, "%s: error: %v\n", a.Name, err)
+		a.terminate(opts.exitCode(err))
+		return ""
+	}
+
+	if opts.Writer != nil {
+		uw := a.usageWriter
+		a.usageWriter = opts.Writer
+		defer func() { a.usageWriter = uw }()
+	}
+
+	pc, _ := a.parseContext(true, args)
+	a.UsageForContextWithTemplate(pc, 2, ut)
+	a.terminate(opts.exitCode(err))
+
+	return ""
+}
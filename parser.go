@@ -2,6 +2,7 @@ package fisk
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -80,6 +81,8 @@ type ParseElement struct {
 	Clause interface{}
 	// Value is corresponding value for an ArgClause or FlagClause (if any).
 	Value *string
+
+	defaultCommand bool // The CmdClause was selected as a default command rather than from the command line
 }
 
 // ParseContext holds the current context of the parser. When passed to
@@ -88,7 +91,10 @@ type ParseElement struct {
 // any).
 type ParseContext struct {
 	SelectedCommand *CmdClause
+	app             *Application
+	ctx             context.Context
 	ignoreDefault   bool
+	readOnly        bool // The application and its values are not modified while parsing
 	argsOnly        bool
 	peek            []*Token
 	argi            int // Index of current command-line arg we're processing.
@@ -97,6 +103,11 @@ type ParseContext struct {
 	flags           *flagGroup
 	arguments       *argGroup
 	argumenti       int // Cursor into arguments
+	recordTokens    bool
+	tokens          []*Token // Tokens produced by the tokenizer when recordTokens is set
+	lastToken       *Token
+	tokenBlock      []Token
+	elementBlock    []elementSlot
 	// Flags, arguments and commands encountered and collected during parse.
 	Elements []*ParseElement
 }
@@ -133,16 +144,6 @@ func tokenize(args []string, ignoreDefault bool) *ParseContext {
 	}
 }
 
-func (p *ParseContext) mergeFlags(flags *flagGroup) {
-	for _, flag := range flags.flagOrder {
-		if flag.shorthand != 0 {
-			p.flags.short[string(flag.shorthand)] = flag
-		}
-		p.flags.long[flag.name] = flag
-		p.flags.flagOrder = append(p.flags.flagOrder, flag)
-	}
-}
-
 func (p *ParseContext) mergeArgs(args *argGroup) {
 	p.arguments.args = append(p.arguments.args, args.args...)
 }
@@ -161,9 +162,22 @@ func (p *ParseContext) Next() *Token {
 		return p.pop()
 	}
 
+	token := p.nextToken()
+	if token.Type != TokenEOL && (p.recordTokens || p.tracing()) {
+		p.tokenProduced(token)
+		// values split from --flag=value or -fvalue are pushed onto the peek stack
+		for _, t := range p.peek {
+			p.tokenProduced(t)
+		}
+	}
+
+	return token
+}
+
+func (p *ParseContext) nextToken() *Token {
 	// End of tokens.
 	if len(p.args) == 0 {
-		return &Token{Index: p.argi, Type: TokenEOL}
+		return p.newToken(p.argi, TokenEOL, "")
 	}
 
 	if p.argi > 0 && p.argi <= len(p.rawArgs) && p.rawArgs[p.argi-1] == "--" {
@@ -174,25 +188,25 @@ func (p *ParseContext) Next() *Token {
 	p.next()
 
 	if p.argsOnly {
-		return &Token{p.argi, TokenArg, arg}
+		return p.newToken(p.argi, TokenArg, arg)
 	}
 
 	if arg == "--" {
-		return p.Next()
+		return p.nextToken()
 	}
 
 	if strings.HasPrefix(arg, "--") {
-		parts := strings.SplitN(arg[2:], "=", 2)
-		token := &Token{p.argi, TokenLong, parts[0]}
-		if len(parts) == 2 {
-			p.Push(&Token{p.argi, TokenArg, parts[1]})
+		name, value, hasValue := strings.Cut(arg[2:], "=")
+		token := p.newToken(p.argi, TokenLong, name)
+		if hasValue {
+			p.Push(p.newToken(p.argi, TokenArg, value))
 		}
 		return token
 	}
 
 	if strings.HasPrefix(arg, "-") {
 		if len(arg) == 1 {
-			return &Token{Index: p.argi, Type: TokenArg}
+			return p.newToken(p.argi, TokenArg, "")
 		}
 		shortRune, size := utf8.DecodeRuneInString(arg[1:])
 		short := string(shortRune)
@@ -203,9 +217,9 @@ func (p *ParseContext) Next() *Token {
 			// Bool short flag.
 		} else {
 			// Short flag with combined argument: -fARG
-			token := &Token{p.argi, TokenShort, short}
+			token := p.newToken(p.argi, TokenShort, short)
 			if len(arg) > size+1 {
-				p.Push(&Token{p.argi, TokenArg, arg[size+1:]})
+				p.Push(p.newToken(p.argi, TokenArg, arg[size+1:]))
 			}
 			return token
 		}
@@ -213,21 +227,21 @@ func (p *ParseContext) Next() *Token {
 		if len(arg) > size+1 {
 			p.args = append([]string{"-" + arg[size+1:]}, p.args...)
 		}
-		return &Token{p.argi, TokenShort, short}
-	} else if EnableFileExpansion && strings.HasPrefix(arg, "@") {
+		return p.newToken(p.argi, TokenShort, short)
+	} else if EnableFileExpansion && strings.HasPrefix(arg, "@") && arg != EditorMarker {
 		expanded, err := ExpandArgsFromFile(arg[1:])
 		if err != nil {
-			return &Token{p.argi, TokenError, err.Error()}
+			return p.newToken(p.argi, TokenError, err.Error())
 		}
 		if len(p.args) == 0 {
 			p.args = expanded
 		} else {
 			p.args = append(expanded, p.args...)
 		}
-		return p.Next()
+		return p.nextToken()
 	}
 
-	return &Token{p.argi, TokenArg, arg}
+	return p.newToken(p.argi, TokenArg, arg)
 }
 
 func (p *ParseContext) Peek() *Token {
@@ -253,21 +267,94 @@ func (p *ParseContext) String() string {
 	return p.SelectedCommand.FullCommand()
 }
 
+// parseBlockSize is how many tokens and elements are allocated at a time, this
+// avoids an allocation per argument when parsing very long argument lists
+const parseBlockSize = 64
+
+type elementSlot struct {
+	element ParseElement
+	value   string
+}
+
+func (p *ParseContext) newToken(index int, typ TokenType, value string) *Token {
+	if len(p.tokenBlock) == cap(p.tokenBlock) {
+		p.tokenBlock = make([]Token, 0, parseBlockSize)
+	}
+	p.tokenBlock = append(p.tokenBlock, Token{index, typ, value})
+
+	return &p.tokenBlock[len(p.tokenBlock)-1]
+}
+
+func (p *ParseContext) newElement(clause interface{}, value string) *ParseElement {
+	if len(p.elementBlock) == cap(p.elementBlock) {
+		p.elementBlock = make([]elementSlot, 0, parseBlockSize)
+	}
+	p.elementBlock = append(p.elementBlock, elementSlot{value: value})
+
+	slot := &p.elementBlock[len(p.elementBlock)-1]
+	slot.element = ParseElement{Clause: clause, Value: &slot.value}
+
+	return &slot.element
+}
+
 func (p *ParseContext) matchedFlag(flag *FlagClause, value string) {
-	p.Elements = append(p.Elements, &ParseElement{Clause: flag, Value: &value})
+	p.Elements = append(p.Elements, p.newElement(flag, flag.normalize(value)))
 }
 
 func (p *ParseContext) matchedArg(arg *ArgClause, value string) {
-	p.Elements = append(p.Elements, &ParseElement{Clause: arg, Value: &value})
+	p.Elements = append(p.Elements, p.newElement(arg, arg.normalize(value)))
 }
 
 func (p *ParseContext) matchedCmd(cmd *CmdClause) {
 	p.Elements = append(p.Elements, &ParseElement{Clause: cmd})
-	p.mergeFlags(cmd.flagGroup)
+	p.flags = cmd.flagsInScope()
 	p.mergeArgs(cmd.argGroup)
 	p.SelectedCommand = cmd
 }
 
+func (p *ParseContext) setCompletionAlts(cmd *CmdClause, alts []string) {
+	if !p.readOnly {
+		cmd.completionAlts = alts
+	}
+}
+
+func (p *ParseContext) matchedDefaultCmd(cmd *CmdClause) {
+	p.matchedCmd(cmd)
+	p.Elements[len(p.Elements)-1].defaultCommand = true
+}
+
+// withoutDefaults is a view of the context as it would be when parsed without
+// selecting default commands, stopping where the first default command was selected
+func (p *ParseContext) withoutDefaults(app *Application) *ParseContext {
+	view := &ParseContext{
+		app:           p.app,
+		ctx:           p.ctx,
+		ignoreDefault: true,
+		argsOnly:      p.argsOnly,
+		peek:          p.peek,
+		argi:          p.argi,
+		args:          p.args,
+		rawArgs:       p.rawArgs,
+		flags:         app.flagGroup,
+		arguments:     newArgGroup(),
+	}
+	view.mergeArgs(app.argGroup)
+
+	for _, element := range p.Elements {
+		if element.defaultCommand {
+			break
+		}
+
+		if cmd, ok := element.Clause.(*CmdClause); ok {
+			view.matchedCmd(cmd)
+		} else {
+			view.Elements = append(view.Elements, element)
+		}
+	}
+
+	return view
+}
+
 // ExpandArgsFromFile expand arguments from a file. Lines starting with # will be treated as comments.
 func ExpandArgsFromFile(filename string) (out []string, err error) {
 	if filename == "" {
@@ -294,7 +381,7 @@ func ExpandArgsFromFile(filename string) (out []string, err error) {
 }
 
 func parse(context *ParseContext, app *Application) (err error) {
-	context.mergeFlags(app.flagGroup)
+	context.flags = app.flagGroup
 	context.mergeArgs(app.argGroup)
 
 	cmds := app.cmdGroup
@@ -309,8 +396,8 @@ loop:
 			if flag, err := context.flags.parse(context); err != nil {
 				if !ignoreDefault {
 					if cmd := cmds.defaultSubcommand(); cmd != nil {
-						cmd.completionAlts = cmds.cmdNames()
-						context.matchedCmd(cmd)
+						context.setCompletionAlts(cmd, cmds.cmdNames())
+						context.matchedDefaultCmd(cmd)
 						cmds = cmd.cmdGroup
 						break
 					}
@@ -321,13 +408,24 @@ loop:
 			}
 
 		case TokenArg:
+			if app.argsAndCommands && cmds == app.cmdGroup {
+				if _, ok := cmds.commands[token.String()]; ok {
+					// a command was selected so top-level arguments do not apply
+					context.arguments = newArgGroup()
+					context.argumenti = 0
+				} else if context.arguments.have() {
+					// not a command, this and the following tokens are top-level arguments
+					cmds = newCmdGroup(app)
+				}
+			}
+
 			if cmds.have() {
 				selectedDefault := false
 				cmd, ok := cmds.commands[token.String()]
 				if !ok {
 					if !ignoreDefault {
 						if cmd = cmds.defaultSubcommand(); cmd != nil {
-							cmd.completionAlts = cmds.cmdNames()
+							context.setCompletionAlts(cmd, cmds.cmdNames())
 							selectedDefault = true
 						}
 					}
@@ -338,12 +436,14 @@ loop:
 				if cmd == HelpCommand {
 					ignoreDefault = true
 				}
-				cmd.completionAlts = nil
-				context.matchedCmd(cmd)
-				cmds = cmd.cmdGroup
-				if !selectedDefault {
+				context.setCompletionAlts(cmd, nil)
+				if selectedDefault {
+					context.matchedDefaultCmd(cmd)
+				} else {
+					context.matchedCmd(cmd)
 					context.Next()
 				}
+				cmds = cmd.cmdGroup
 			} else if context.arguments.have() {
 				if app.noInterspersed {
 					// no more flags
@@ -354,6 +454,10 @@ loop:
 					break loop
 				}
 				context.matchedArg(arg, token.String())
+				if arg.passThrough {
+					// everything that follows is captured unparsed
+					context.argsOnly = true
+				}
 				context.Next()
 			} else {
 				break loop
@@ -364,11 +468,16 @@ loop:
 		}
 	}
 
+	// Without arguments or a command the application needs a command rather than its arguments
+	if app.argsAndCommands && cmds == app.cmdGroup && context.argumenti == 0 && cmds.defaultSubcommand() == nil {
+		context.arguments = newArgGroup()
+	}
+
 	// Move to innermost default command.
 	for !ignoreDefault {
 		if cmd := cmds.defaultSubcommand(); cmd != nil {
-			cmd.completionAlts = cmds.cmdNames()
-			context.matchedCmd(cmd)
+			context.setCompletionAlts(cmd, cmds.cmdNames())
+			context.matchedDefaultCmd(cmd)
 			cmds = cmd.cmdGroup
 		} else {
 			break
@@ -383,10 +492,14 @@ loop:
 		return fmt.Errorf("%w %s", ErrUnexpectedArgument, context.Peek())
 	}
 
+	if context.readOnly {
+		return
+	}
+
 	// Set defaults for all remaining args.
 	for arg := context.nextArg(); arg != nil && !arg.consumesRemainder(); arg = context.nextArg() {
 		for _, defaultValue := range arg.defaultValues {
-			if err := arg.value.Set(defaultValue); err != nil {
+			if err := arg.set(defaultValue); err != nil {
 				return fmt.Errorf("invalid default value '%s' for argument '%s'", defaultValue, arg.name)
 			}
 		}
@@ -1,6 +1,7 @@
 package fisk
 
 import (
+	"fmt"
 	"os"
 	"testing"
 
@@ -119,3 +120,45 @@ func TestParseContextPush(t *testing.T) {
 	b = c.Next()
 	assert.Equal(t, "bar", b.Value)
 }
+
+func TestParserLongArgumentLists(t *testing.T) {
+	app := newTestApp()
+	flags := app.Flag("flag", "").Short('f').Strings()
+	args := app.Arg("args", "").Strings()
+
+	var cli []string
+	for i := 0; i < 1000; i++ {
+		cli = append(cli, fmt.Sprintf("--flag=f%d", i), fmt.Sprintf("-fs%d", i), fmt.Sprintf("a%d", i))
+	}
+
+	pc, err := app.ParseContext(cli)
+	assert.NoError(t, err)
+	assert.Len(t, pc.Elements, 3000)
+	assert.Equal(t, "f999", *pc.Elements[2997].Value)
+	assert.Equal(t, "s999", *pc.Elements[2998].Value)
+	assert.Equal(t, "a999", *pc.Elements[2999].Value)
+
+	_, err = app.Parse(cli)
+	assert.NoError(t, err)
+	assert.Len(t, *flags, 2000)
+	assert.Len(t, *args, 1000)
+	assert.Equal(t, "a0", (*args)[0])
+}
+
+func BenchmarkParseLongArgumentLists(b *testing.B) {
+	var cli []string
+	for i := 0; i < 10000; i++ {
+		cli = append(cli, fmt.Sprintf("--flag=f%d", i), fmt.Sprintf("a%d", i))
+	}
+
+	app := newTestApp()
+	app.Flag("flag", "").Strings()
+	app.Arg("args", "").Strings()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := app.ParseContext(cli); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
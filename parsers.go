@@ -33,7 +33,8 @@ func (p *parserMixin) StringMap() (target *map[string]string) {
 	return
 }
 
-// Duration sets the parser to a time.Duration parser.
+// Duration sets the parser to a time.Duration parser, values are parsed
+// using ParseDuration() so units like 2d and 1w and ISO-8601 durations work.
 func (p *parserMixin) Duration() (target *time.Duration) {
 	target = new(time.Duration)
 	p.DurationVar(target)
@@ -123,7 +124,7 @@ func (p *parserMixin) FloatVar(target *float64) {
 	p.Float64Var(target)
 }
 
-// Duration sets the parser to a time.Duration parser.
+// DurationVar sets the parser to a time.Duration parser using ParseDuration().
 func (p *parserMixin) DurationVar(target *time.Duration) {
 	p.SetValue(newDurationValue(target))
 }
@@ -214,3 +215,89 @@ func (p *parserMixin) Counter() (target *int) {
 func (p *parserMixin) CounterVar(target *int) {
 	p.SetValue(newCounterValue(target))
 }
+
+// Durations accumulates time.Duration values into a slice.
+func (p *parserMixin) Durations() (target *[]time.Duration) {
+	target = new([]time.Duration)
+	p.DurationsVar(target)
+	return
+}
+
+// DurationsVar accumulates time.Duration values into target.
+func (p *parserMixin) DurationsVar(target *[]time.Duration) {
+	p.DurationListVar(target)
+}
+
+// IPs accumulates net.IP values into a slice.
+func (p *parserMixin) IPs() (target *[]net.IP) {
+	target = new([]net.IP)
+	p.IPsVar(target)
+	return
+}
+
+// IPsVar accumulates net.IP values into target.
+func (p *parserMixin) IPsVar(target *[]net.IP) {
+	p.IPListVar(target)
+}
+
+// URLs accumulates url.URL values into a slice.
+func (p *parserMixin) URLs() (target *[]*url.URL) {
+	target = new([]*url.URL)
+	p.URLsVar(target)
+	return
+}
+
+// URLsVar accumulates url.URL values into target.
+func (p *parserMixin) URLsVar(target *[]*url.URL) {
+	p.URLListVar(target)
+}
+
+// Int64s accumulates int64 values into a slice.
+func (p *parserMixin) Int64s() (target *[]int64) {
+	target = new([]int64)
+	p.Int64sVar(target)
+	return
+}
+
+// Int64sVar accumulates int64 values into target.
+func (p *parserMixin) Int64sVar(target *[]int64) {
+	p.Int64ListVar(target)
+}
+
+// Uint64s accumulates uint64 values into a slice.
+func (p *parserMixin) Uint64s() (target *[]uint64) {
+	target = new([]uint64)
+	p.Uint64sVar(target)
+	return
+}
+
+// Uint64sVar accumulates uint64 values into target.
+func (p *parserMixin) Uint64sVar(target *[]uint64) {
+	p.Uint64ListVar(target)
+}
+
+// Floats accumulates float64 values into a slice.
+func (p *parserMixin) Floats() (target *[]float64) {
+	target = new([]float64)
+	p.FloatsVar(target)
+	return
+}
+
+// FloatsVar accumulates float64 values into target.
+func (p *parserMixin) FloatsVar(target *[]float64) {
+	p.Float64ListVar(target)
+}
+
+// BytesList accumulates numeric byte units into a slice. eg. 1.5KB
+func (p *parserMixin) BytesList() (target *[]units.Base2Bytes) {
+	target = new([]units.Base2Bytes)
+	p.BytesListVar(target)
+	return
+}
+
+// BytesListVar accumulates numeric byte units into target. eg. 1.5KB
+func (p *parserMixin) BytesListVar(target *[]units.Base2Bytes) {
+	p.SetValue(newAccumulator(target, func(v interface{}) Value {
+		return newBytesValue(v.(*units.Base2Bytes))
+	}))
+}
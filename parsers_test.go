@@ -5,7 +5,9 @@ import (
 	"net/url"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/choria-io/fisk/units"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -94,3 +96,33 @@ func TestFloat32(t *testing.T) {
 	assert.NoError(t, err)
 	assert.InEpsilon(t, 123.45, *v, 0.001)
 }
+
+func TestParseTypedCumulative(t *testing.T) {
+	app := newTestApp()
+	timeouts := app.Flag("timeout", "").Durations()
+	sizes := app.Flag("size", "").BytesList()
+	ips := app.Flag("ip", "").IPs()
+	urls := app.Arg("url", "").URLs()
+
+	_, err := app.Parse([]string{"--timeout=1s", "--timeout=1m", "--size=1KB", "--size=2MB", "--ip=10.0.0.1", "--ip=::1", "http://a", "http://b"})
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Duration{time.Second, time.Minute}, *timeouts)
+	assert.Equal(t, []units.Base2Bytes{units.KiB, 2 * units.MiB}, *sizes)
+	assert.Equal(t, []net.IP{net.ParseIP("10.0.0.1"), net.ParseIP("::1")}, *ips)
+	assert.Len(t, *urls, 2)
+	assert.Equal(t, "b", (*urls)[1].Host)
+
+	_, err = app.Parse([]string{"--timeout=x"})
+	assert.Error(t, err)
+}
+
+func TestParseExtendedDuration(t *testing.T) {
+	app := newTestApp()
+	maxAge := app.Flag("max-age", "").Duration()
+	interval := app.Arg("interval", "").Duration()
+
+	_, err := app.Parse([]string{"--max-age", "2d", "PT1H30M"})
+	assert.NoError(t, err)
+	assert.Equal(t, 48*time.Hour, *maxAge)
+	assert.Equal(t, 90*time.Minute, *interval)
+}
@@ -0,0 +1,89 @@
+package fisk
+
+import (
+	"fmt"
+	"io"
+)
+
+// -- password Value
+type passwordValue struct {
+	v *string
+}
+
+func newPasswordValue(p *string) *passwordValue {
+	return &passwordValue{p}
+}
+
+func (p *passwordValue) Set(s string) error {
+	*p.v = s
+	return nil
+}
+
+func (p *passwordValue) Get() interface{} { return *p.v }
+
+func (p *passwordValue) String() string { return *p.v }
+
+func (p *passwordValue) Reset() { *p.v = "" }
+
+// PasswordPrompt is a string value that, when not supplied on the command line
+// or environment, is read interactively from the terminal without echo.
+func (p *parserMixin) PasswordPrompt() (target *string) {
+	target = new(string)
+	p.PasswordPromptVar(target)
+	return
+}
+
+// PasswordPromptVar is a string value that, when not supplied on the command line
+// or environment, is read interactively from the terminal without echo.
+func (p *parserMixin) PasswordPromptVar(target *string) {
+	p.SetValue(newPasswordValue(target))
+}
+
+// readPassword reads a line from the terminal with echo disabled
+func readPassword(term Terminal, prompt string, w io.Writer) (string, error) {
+	fmt.Fprint(w, prompt)
+	defer fmt.Fprintln(w)
+
+	return term.ReadPassword()
+}
+
+// promptPasswords interactively reads all unset PasswordPrompt() flags when stdin is a terminal
+func (a *Application) promptPasswords(context *ParseContext) error {
+	term := a.term()
+	if !term.IsTTY() {
+		return nil
+	}
+
+	set := map[*FlagClause]bool{}
+	for _, element := range context.Elements {
+		if flag, ok := element.Clause.(*FlagClause); ok {
+			set[flag] = true
+		}
+	}
+
+	for _, flag := range context.flags.flagOrder {
+		if _, ok := flag.value.(*passwordValue); !ok {
+			continue
+		}
+		if set[flag] || flag.HasEnvarValue() || flag.hasDefault() {
+			continue
+		}
+
+		label := flag.help
+		if label == "" {
+			label = flag.name
+		}
+
+		pass, err := readPassword(term, label+": ", a.errorWriter)
+		if err != nil {
+			return fmt.Errorf("could not read --%s: %w", flag.name, err)
+		}
+
+		context.matchedFlag(flag, pass)
+		if err := flag.set(context, pass); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,11 @@
+//go:build linux && !appengine
+// +build linux,!appengine
+
+package fisk
+
+import "syscall"
+
+const (
+	ioctlReadTermios  = syscall.TCGETS
+	ioctlWriteTermios = syscall.TCSETS
+)
@@ -0,0 +1,18 @@
+//go:build appengine || (!linux && !freebsd && !darwin && !dragonfly && !netbsd && !openbsd && !windows)
+// +build appengine !linux,!freebsd,!darwin,!dragonfly,!netbsd,!openbsd,!windows
+
+package fisk
+
+import "fmt"
+
+func isTerminal(fd int) bool {
+	return false
+}
+
+func disableEcho(fd int) (func(), error) {
+	return nil, fmt.Errorf("reading passwords is not supported on this platform")
+}
+
+func enableRawInput(fd int) (func(), error) {
+	return nil, fmt.Errorf("raw input is not supported on this platform")
+}
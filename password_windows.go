@@ -0,0 +1,57 @@
+//go:build windows
+// +build windows
+
+package fisk
+
+import (
+	"syscall"
+)
+
+const (
+	enableLineInput            = 0x0002
+	enableEchoInput            = 0x0004
+	enableVirtualTerminalInput = 0x0200
+)
+
+var procSetConsoleMode = syscall.NewLazyDLL("kernel32.dll").NewProc("SetConsoleMode")
+
+func setConsoleMode(fd int, mode uint32) error {
+	r, _, err := procSetConsoleMode.Call(uintptr(fd), uintptr(mode))
+	if r == 0 {
+		return err
+	}
+	return nil
+}
+
+func isTerminal(fd int) bool {
+	var mode uint32
+	return syscall.GetConsoleMode(syscall.Handle(fd), &mode) == nil
+}
+
+func disableEcho(fd int) (func(), error) {
+	var mode uint32
+	if err := syscall.GetConsoleMode(syscall.Handle(fd), &mode); err != nil {
+		return nil, err
+	}
+
+	if err := setConsoleMode(fd, mode&^enableEchoInput); err != nil {
+		return nil, err
+	}
+
+	return func() { setConsoleMode(fd, mode) }, nil
+}
+
+// enableRawInput delivers key presses without waiting for a new line, arrow
+// keys are delivered as escape sequences
+func enableRawInput(fd int) (func(), error) {
+	var mode uint32
+	if err := syscall.GetConsoleMode(syscall.Handle(fd), &mode); err != nil {
+		return nil, err
+	}
+
+	if err := setConsoleMode(fd, mode&^(enableLineInput|enableEchoInput)|enableVirtualTerminalInput); err != nil {
+		return nil, err
+	}
+
+	return func() { setConsoleMode(fd, mode) }, nil
+}
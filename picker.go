@@ -0,0 +1,160 @@
+package fisk
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CommandPicker enables an interactive command picker that is shown instead of
+// usage when no command is given and stdin is a terminal
+func (a *Application) CommandPicker() *Application {
+	a.commandPicker = true
+	return a
+}
+
+type pickerEntry struct {
+	command string
+	help    string
+	score   int
+}
+
+func (a *Application) pickerEntries() []pickerEntry {
+	var entries []pickerEntry
+	var walk func(cmds []*CmdClause)
+	walk = func(cmds []*CmdClause) {
+		for _, cmd := range cmds {
+			if cmd.hidden || cmd == a.HelpCommand || cmd == a.ShellCommand {
+				continue
+			}
+			if len(cmd.commandOrder) == 0 {
+				entries = append(entries, pickerEntry{command: cmd.FullCommand(), help: strings.Split(cmd.help, "\n")[0]})
+			}
+			walk(cmd.commandOrder)
+		}
+	}
+	walk(a.commandOrder)
+
+	return entries
+}
+
+// pickCommand interactively selects a command, returns an empty string when nothing was picked
+func (a *Application) pickCommand(p *prompter) (string, error) {
+	entries := a.pickerEntries()
+	filter := ""
+
+	for {
+		matches := fuzzyFilter(entries, filter)
+		if len(matches) > 20 {
+			matches = matches[:20]
+		}
+
+		for i, m := range matches {
+			fmt.Fprintf(p.out, "%3d) %-30s %s\n", i+1, m.command, m.help)
+		}
+
+		answer, err := p.ask("Enter a number to run, text to filter or blank to exit", "")
+		if err != nil || answer == "" {
+			return "", err
+		}
+
+		if n, err := strconv.Atoi(answer); err == nil && n > 0 && n <= len(matches) {
+			return matches[n-1].command, nil
+		}
+
+		filter = answer
+	}
+}
+
+func fuzzyFilter(entries []pickerEntry, filter string) []pickerEntry {
+	if filter == "" {
+		return entries
+	}
+
+	var matched []pickerEntry
+	for _, e := range entries {
+		score, ok := fuzzyMatch(filter, e.command)
+		if !ok {
+			score, ok = fuzzyMatch(filter, e.help)
+			score -= 100
+		}
+		if ok {
+			e.score = score
+			matched = append(matched, e)
+		}
+	}
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		return matched[i].score > matched[j].score
+	})
+
+	return matched
+}
+
+// fuzzyMatch checks that all characters in pattern appear in order in s,
+// scoring consecutive and word start matches higher
+func fuzzyMatch(pattern string, s string) (int, bool) {
+	pattern = strings.ToLower(pattern)
+	s = strings.ToLower(s)
+
+	if pattern == "" {
+		return 0, true
+	}
+
+	best := 0
+	found := false
+	for start := 0; start < len(s); start++ {
+		if !strings.HasPrefix(s[start:], pattern[:1]) {
+			continue
+		}
+
+		score, ok := fuzzyMatchFrom(pattern, s, start)
+		if ok && (!found || score > best) {
+			best = score
+			found = true
+		}
+	}
+
+	return best, found
+}
+
+func fuzzyMatchFrom(pattern string, s string, pos int) (int, bool) {
+	score := 0
+	last := pos - 1
+	for _, pr := range pattern {
+		idx := strings.IndexRune(s[pos:], pr)
+		if idx == -1 {
+			return 0, false
+		}
+		idx += pos
+
+		switch {
+		case idx == last+1:
+			score += 5
+		case idx == 0 || s[idx-1] == ' ':
+			score += 3
+		default:
+			score -= idx - last
+		}
+
+		last = idx
+		pos = idx + len(string(pr))
+	}
+
+	return score, true
+}
+
+func (a *Application) maybePickCommand(context *ParseContext) (string, bool, error) {
+	if !a.commandPicker || !a.term().IsTTY() {
+		return "", false, nil
+	}
+
+	picked, err := a.pickCommand(a.newPrompter())
+	if err != nil || picked == "" {
+		return "", false, err
+	}
+
+	command, err := a.Parse(append(append([]string{}, context.rawArgs...), strings.Split(picked, " ")...))
+	return command, true, err
+}
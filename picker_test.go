@@ -0,0 +1,45 @@
+package fisk
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	_, ok := fuzzyMatch("sa", "stream add")
+	assert.True(t, ok)
+	_, ok = fuzzyMatch("smd", "stream add")
+	assert.True(t, ok)
+	_, ok = fuzzyMatch("xyz", "stream add")
+	assert.False(t, ok)
+
+	exact, _ := fuzzyMatch("add", "stream add")
+	loose, _ := fuzzyMatch("add", "a consumer dd")
+	assert.Greater(t, exact, loose)
+}
+
+func TestPickCommand(t *testing.T) {
+	app := newTestApp().CommandPicker()
+	stream := app.Command("stream", "Manage streams")
+	stream.Command("add", "Adds a stream")
+	stream.Command("rm", "Removes a stream")
+	app.Command("pub", "Publish a message")
+	app.Command("secret", "").Hidden()
+	assert.NoError(t, app.init())
+
+	out := bytes.NewBuffer(nil)
+	picked, err := app.pickCommand(newPrompter(strings.NewReader("rm\n1\n"), out))
+	assert.NoError(t, err)
+	assert.Equal(t, "stream rm", picked)
+	assert.Contains(t, out.String(), "stream add")
+	assert.Contains(t, out.String(), "Publish a message")
+	assert.NotContains(t, out.String(), "secret")
+	assert.NotContains(t, out.String(), "help")
+
+	picked, err = app.pickCommand(newPrompter(strings.NewReader("\n"), out))
+	assert.NoError(t, err)
+	assert.Equal(t, "", picked)
+}
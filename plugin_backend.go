@@ -0,0 +1,29 @@
+package fisk
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// PluginBackend loads and runs plugins that are not executables, for example
+// WebAssembly modules run in a sandbox by the github.com/choria-io/fisk/wasm
+// module, see PluginCommand()
+type PluginBackend interface {
+	PluginExecutor
+
+	// PluginModel is the model of the plugin in the format shown by --fisk-introspect
+	PluginModel() (json.RawMessage, error)
+}
+
+// PluginCommand extends the application using a plugin loaded and run by
+// backend, source identifies the plugin to the backend and is passed to it as
+// PluginExecution.Command. When name or help is not an empty string it will
+// override that from the plugin
+func (a *Application) PluginCommand(source string, backend PluginBackend, name string, help string) (*CmdClause, error) {
+	model, err := backend.PluginModel()
+	if err != nil {
+		return nil, fmt.Errorf("could not load plugin model from %s: %w", source, err)
+	}
+
+	return a.externalPluginCommand(source, model, name, help, backend)
+}
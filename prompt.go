@@ -0,0 +1,261 @@
+package fisk
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// prompter asks questions on behalf of interactive features like Wizard()
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+
+	// password reads a line without echo, lines are read from in when nil
+	password func() (string, error)
+	// raw delivers key presses from in without echo until restored, input is line buffered when nil
+	raw func() (restore func(), err error)
+}
+
+func newPrompter(r io.Reader, w io.Writer) *prompter {
+	return &prompter{in: bufio.NewReader(r), out: w}
+}
+
+func (a *Application) newPrompter() *prompter {
+	term := a.term()
+	p := newPrompter(term.Input(), a.errorWriter)
+	p.password = term.ReadPassword
+
+	// a custom terminal decides how keys are delivered
+	if a.terminal == nil && term.IsTTY() {
+		p.raw = func() (func(), error) { return enableRawInput(int(os.Stdin.Fd())) }
+	}
+
+	return p
+}
+
+func (p *prompter) readLine() (string, error) {
+	line, err := p.in.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// ask prompts for a single value, returning def when nothing was entered
+func (p *prompter) ask(label string, def string) (string, error) {
+	if def != "" {
+		fmt.Fprintf(p.out, "%s [%s]: ", label, def)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", label)
+	}
+
+	line, err := p.readLine()
+	if err != nil {
+		return "", err
+	}
+
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return def, nil
+	}
+
+	return line, nil
+}
+
+// askSecret prompts for a single value without echo, no default is shown
+func (p *prompter) askSecret(label string) (string, error) {
+	fmt.Fprintf(p.out, "%s: ", label)
+
+	if p.password == nil {
+		line, err := p.readLine()
+		return strings.TrimSpace(line), err
+	}
+
+	defer fmt.Fprintln(p.out)
+	line, err := p.password()
+
+	return strings.TrimSpace(line), err
+}
+
+// choose presents a numbered list of options, accepting either the number or the option itself
+func (p *prompter) choose(label string, options []string, def string) (string, error) {
+	for {
+		fmt.Fprintf(p.out, "%s:\n", label)
+		for i, opt := range options {
+			fmt.Fprintf(p.out, "  %d) %s\n", i+1, opt)
+		}
+
+		answer, err := p.ask("Choice", def)
+		if err != nil || answer == "" {
+			return answer, err
+		}
+
+		if n, err := strconv.Atoi(answer); err == nil && n > 0 && n <= len(options) {
+			return options[n-1], nil
+		}
+
+		for _, opt := range options {
+			if opt == answer {
+				return opt, nil
+			}
+		}
+
+		fmt.Fprintf(p.out, "Invalid choice %q\n", answer)
+	}
+}
+
+// enumOptions returns the valid options for Enum() and Enums() values
+func enumOptions(v Value) []string {
+	switch e := v.(type) {
+	case *enumValue:
+		return e.options
+	case *enumsValue:
+		return e.options
+	}
+
+	return nil
+}
+
+// PromptMissing enables interactively asking for required flags and arguments
+// that were not supplied when stdin is a terminal, Enum() values are selected
+// from a list of valid options
+func (a *Application) PromptMissing() *Application {
+	a.promptMissing = true
+	return a
+}
+
+func (a *Application) maybePromptMissing(context *ParseContext) error {
+	if !a.promptMissing || !a.term().IsTTY() {
+		return nil
+	}
+
+	return a.promptMissingRequired(context, a.newPrompter())
+}
+
+func (a *Application) promptMissingRequired(context *ParseContext, p *prompter) error {
+	given := map[interface{}]bool{}
+	for _, element := range context.Elements {
+		given[element.Clause] = true
+	}
+
+	ask := func(label string, help string, value Value, secret bool) (string, error) {
+		if help != "" {
+			label = fmt.Sprintf("%s (%s)", label, help)
+		}
+
+		if secret {
+			return p.askSecret(label)
+		}
+
+		if options := enumOptions(value); len(options) > 0 {
+			return p.choose(label, options, "")
+		}
+
+		return p.ask(label, "")
+	}
+
+	for _, flag := range context.flags.flagOrder {
+		if given[flag] || !flag.needsValue() {
+			continue
+		}
+
+		answer, err := ask("--"+flag.name, flag.help, flag.value, flag.secret)
+		if err != nil {
+			return err
+		}
+		if answer == "" {
+			continue
+		}
+
+		if err := flag.set(context, answer); err != nil {
+			return fmt.Errorf("%s: %w", flag.name, err)
+		}
+		flag.isSetByUser()
+		context.matchedFlag(flag, answer)
+	}
+
+	for _, arg := range context.arguments.args {
+		if given[arg] || !arg.needsValue() {
+			continue
+		}
+
+		answer, err := ask("<"+arg.name+">", arg.help, arg.value, arg.secret)
+		if err != nil {
+			return err
+		}
+		if answer == "" {
+			continue
+		}
+
+		if err := arg.set(answer); err != nil {
+			return fmt.Errorf("%s: %w", arg.name, err)
+		}
+		context.matchedArg(arg, answer)
+	}
+
+	return nil
+}
+
+func (a *Application) maybePromptArgs(context *ParseContext) error {
+	if !a.term().IsTTY() {
+		return nil
+	}
+
+	for _, arg := range context.arguments.args {
+		if arg.promptLabel != "" {
+			return a.promptArgs(context, a.newPrompter())
+		}
+	}
+
+	return nil
+}
+
+// promptArgs asks for PromptIfMissing() arguments that were not given, the
+// default is offered and kept when nothing is entered, Secret() arguments are
+// read without echo and their defaults are not shown
+func (a *Application) promptArgs(context *ParseContext, p *prompter) error {
+	given := map[interface{}]bool{}
+	for _, element := range context.Elements {
+		given[element.Clause] = true
+	}
+
+	for _, arg := range context.arguments.args {
+		if arg.promptLabel == "" || given[arg] || arg.HasEnvarValue() {
+			continue
+		}
+
+		var def string
+		if len(arg.defaultValues) > 0 && !arg.secret {
+			def = arg.defaultValues[len(arg.defaultValues)-1]
+		}
+
+		var answer string
+		var err error
+		switch options := enumOptions(arg.value); {
+		case arg.secret:
+			answer, err = p.askSecret(arg.promptLabel)
+		case len(options) > 0:
+			answer, err = p.choose(arg.promptLabel, options, def)
+		default:
+			answer, err = p.ask(arg.promptLabel, def)
+		}
+		if err != nil {
+			return err
+		}
+		if answer == "" || answer == def {
+			continue
+		}
+
+		if err := arg.set(answer); err != nil {
+			return fmt.Errorf("%s: %w", arg.name, redactError(err, arg.secret, answer))
+		}
+		context.matchedArg(arg, answer)
+	}
+
+	return nil
+}
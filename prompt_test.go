@@ -0,0 +1,114 @@
+package fisk
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrompterChoose(t *testing.T) {
+	out := bytes.NewBuffer(nil)
+	p := newPrompter(strings.NewReader("yaml\n5\n3\n"), out)
+
+	choice, err := p.choose("Format", []string{"json", "yaml", "table"}, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "yaml", choice)
+
+	choice, err = p.choose("Format", []string{"json", "yaml", "table"}, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "table", choice)
+	assert.Contains(t, out.String(), `Invalid choice "5"`)
+	assert.Contains(t, out.String(), "  3) table\n")
+}
+
+func TestPromptMissingRequired(t *testing.T) {
+	app := newTestApp().PromptMissing()
+	format := app.Flag("format", "Output format").Required().Enum("json", "yaml")
+	name := app.Arg("name", "").Required().String()
+	other := app.Flag("other", "").String()
+
+	pc, err := app.ParseContext([]string{})
+	assert.NoError(t, err)
+
+	out := bytes.NewBuffer(nil)
+	assert.NoError(t, app.promptMissingRequired(pc, newPrompter(strings.NewReader("2\nORDERS\n"), out)))
+	assert.Equal(t, "yaml", *format)
+	assert.Equal(t, "ORDERS", *name)
+	assert.Equal(t, "", *other)
+	assert.Contains(t, out.String(), "--format (Output format):\n  1) json\n  2) yaml\n")
+	assert.NoError(t, app.validateRequired(pc))
+}
+
+func TestPromptMissingRequiredSecret(t *testing.T) {
+	app := newTestApp().PromptMissing()
+	token := app.Flag("token", "").Required().Secret().String()
+	password := app.Arg("password", "").Required().Secret().String()
+
+	pc, err := app.ParseContext([]string{})
+	assert.NoError(t, err)
+
+	var prompted []string
+	out := bytes.NewBuffer(nil)
+	p := newPrompter(strings.NewReader("echoed\n"), out)
+	p.password = func() (string, error) {
+		prompted = append(prompted, "secret")
+		return fmt.Sprintf("s3cret%d", len(prompted)), nil
+	}
+
+	assert.NoError(t, app.promptMissingRequired(pc, p))
+	assert.Len(t, prompted, 2)
+	assert.Equal(t, "s3cret1", *token)
+	assert.Equal(t, "s3cret2", *password)
+	assert.Equal(t, "--token: \n<password>: \n", out.String())
+}
+
+func TestPromptArgs(t *testing.T) {
+	app := newTestApp()
+	stream := app.Arg("stream", "").PromptIfMissing("Stream name").String()
+	storage := app.Arg("storage", "").PromptIfMissing("Storage").Default("file").Enum("file", "memory")
+	replicas := app.Arg("replicas", "").Default("1").PromptIfMissing("Replicas").Int()
+
+	pc, err := app.ParseContext([]string{})
+	assert.NoError(t, err)
+	assert.NoError(t, app.setDefaults(pc))
+
+	out := bytes.NewBuffer(nil)
+	assert.NoError(t, app.promptArgs(pc, newPrompter(strings.NewReader("ORDERS\n\n3\n"), out)))
+	assert.Equal(t, "ORDERS", *stream)
+	assert.Equal(t, "file", *storage)
+	assert.Equal(t, 3, *replicas)
+	assert.Contains(t, out.String(), "Stream name: Storage:\n  1) file\n  2) memory\nChoice [file]: Replicas [1]: ")
+
+	pc, err = app.ParseContext([]string{"X"})
+	assert.NoError(t, err)
+	out.Reset()
+	assert.NoError(t, app.promptArgs(pc, newPrompter(strings.NewReader("\n\n"), out)))
+	assert.NotContains(t, out.String(), "Stream name")
+
+	assert.Error(t, app.promptArgs(pc, newPrompter(strings.NewReader("\nx\n"), out)))
+}
+
+func TestPromptArgsSecret(t *testing.T) {
+	app := newTestApp()
+	password := app.Arg("password", "").Default("s3cret").Secret().PromptIfMissing("Password").String()
+
+	pc, err := app.ParseContext([]string{})
+	assert.NoError(t, err)
+	assert.NoError(t, app.setDefaults(pc))
+
+	var prompted int
+	out := bytes.NewBuffer(nil)
+	p := newPrompter(strings.NewReader(""), out)
+	p.password = func() (string, error) {
+		prompted++
+		return "other", nil
+	}
+
+	assert.NoError(t, app.promptArgs(pc, p))
+	assert.Equal(t, 1, prompted)
+	assert.Equal(t, "other", *password)
+	assert.Equal(t, "Password: \n", out.String())
+}
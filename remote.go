@@ -0,0 +1,296 @@
+package fisk
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// RemoteManifest is the document fetched by RemoteCommands(), Payload holds
+// a RemotePayload and Signature is the base64 encoded ed25519 signature of
+// the exact bytes of Payload
+type RemoteManifest struct {
+	Payload   json.RawMessage `json:"payload"`
+	Signature string          `json:"signature"`
+}
+
+// RemotePayload describes the commands of a RemoteManifest and how they are
+// run, exactly one of Command and URL should be set
+type RemotePayload struct {
+	// Model describes the commands in the format shown by --fisk-introspect
+	Model json.RawMessage `json:"model"`
+	// Command is a plugin executable that runs the commands
+	Command string `json:"command,omitempty"`
+	// URL is a https endpoint that receives a POST of RemoteInvocation to run the commands
+	URL string `json:"url,omitempty"`
+	// Issued is when the manifest was signed, it may not be in the future
+	Issued time.Time `json:"issued"`
+	// Expires is when the manifest stops being valid, manifests without it are rejected
+	Expires time.Time `json:"expires"`
+}
+
+// RemoteInvocation is posted to RemotePayload.URL to run a remote command, the
+// response body is written to stdout and a status other than 2xx fails the command
+type RemoteInvocation struct {
+	Args []string `json:"args"`
+}
+
+// DefaultRemoteCacheTTL is how long a cached manifest is used before RemoteCommands() fetches it again
+const DefaultRemoteCacheTTL = time.Hour
+
+// maxRemoteManifestSize limits how much of a manifest response is read
+const maxRemoteManifestSize = 1024 * 1024
+
+// RemoteCacheTTL sets how long manifests cached by RemoteCommands() are used without fetching them again
+func (a *Application) RemoteCacheTTL(ttl time.Duration) *Application {
+	a.remoteTTL = ttl
+	return a
+}
+
+// RemoteCommands fetches a signed RemoteManifest from the https url and adds its
+// commands to the application, the signature must verify using key and the
+// manifest must not have expired.
+//
+// The manifest is saved to the file cache, when not empty, and a cached copy
+// younger than the RemoteCacheTTL() is used without fetching the url. Older
+// copies are used when the url can not be fetched.
+func (a *Application) RemoteCommands(url string, cache string, key ed25519.PublicKey) (*CmdClause, error) {
+	if err := requireHTTPS(url); err != nil {
+		return nil, err
+	}
+
+	client := a.remoteClient
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	payload, err := a.loadRemoteManifest(client, url, cache, key)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case payload.Command != "" && payload.URL != "":
+		return nil, fmt.Errorf("invalid manifest from %s: only one of command and url can be set", url)
+
+	case payload.Command != "":
+		return a.ExternalPluginCommand(payload.Command, payload.Model, "", "")
+
+	case payload.URL != "":
+		if err := requireHTTPS(payload.URL); err != nil {
+			return nil, fmt.Errorf("invalid manifest from %s: %w", url, err)
+		}
+
+		return a.externalPluginCommand(payload.URL, payload.Model, "", "", &httpPluginExecutor{client: withoutRedirects(client), w: os.Stdout})
+
+	default:
+		return nil, fmt.Errorf("invalid manifest from %s: no command or url set", url)
+	}
+}
+
+// loadRemoteManifest returns the verified payload from a fresh cache, the url or a stale cache in that order
+func (a *Application) loadRemoteManifest(client *http.Client, url string, cache string, key ed25519.PublicKey) (*RemotePayload, error) {
+	ttl := a.remoteTTL
+	if ttl == 0 {
+		ttl = DefaultRemoteCacheTTL
+	}
+
+	var (
+		cached *RemotePayload
+		issued time.Time // of the cached manifest, fetched manifests may not be older even when it expired
+	)
+	if cache != "" {
+		stat, err := os.Stat(cache)
+		if err == nil {
+			var data []byte
+			data, err = os.ReadFile(cache)
+			if err == nil {
+				var payload *RemotePayload
+				payload, err = verifyRemoteManifest(data, key)
+				if payload != nil {
+					issued = payload.Issued
+				}
+				if err == nil {
+					cached = payload
+				}
+			}
+			if err != nil {
+				a.Logger().Warn("Ignoring invalid cached remote manifest", "cache", cache, "error", err)
+			} else if time.Since(stat.ModTime()) < ttl {
+				return cached, nil
+			}
+		}
+	}
+
+	manifest, fetchErr := fetchRemoteManifest(client, url)
+	if fetchErr != nil {
+		if cached == nil {
+			if cache == "" {
+				return nil, fetchErr
+			}
+			return nil, fmt.Errorf("%w and no valid cached manifest", fetchErr)
+		}
+
+		a.Logger().Warn("Using cached remote manifest", "url", url, "error", fetchErr)
+		return cached, nil
+	}
+
+	payload, err := verifyRemoteManifest(manifest, key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid manifest from %s: %w", url, err)
+	}
+
+	// an older manifest could be a replay rolling back the commands
+	if payload.Issued.Before(issued) {
+		return nil, fmt.Errorf("invalid manifest from %s: issued at %s before the cached manifest issued at %s", url, payload.Issued.Format(time.RFC3339), issued.Format(time.RFC3339))
+	}
+
+	if cache != "" {
+		err = writeRemoteCache(cache, manifest)
+		if err != nil {
+			a.Logger().Warn("Could not cache remote manifest", "cache", cache, "error", err)
+		}
+	}
+
+	return payload, nil
+}
+
+func requireHTTPS(u string) error {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return err
+	}
+	if parsed.Scheme != "https" {
+		return fmt.Errorf("%s is not a https url", u)
+	}
+
+	return nil
+}
+
+func fetchRemoteManifest(client *http.Client, url string) ([]byte, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch manifest: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not fetch manifest from %s: %s", url, resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxRemoteManifestSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch manifest: %w", err)
+	}
+	if len(body) > maxRemoteManifestSize {
+		return nil, fmt.Errorf("manifest from %s exceeds %d bytes", url, maxRemoteManifestSize)
+	}
+
+	return body, nil
+}
+
+// verifyRemoteManifest verifies the signature and validity period of a manifest, when
+// only the validity period is wrong the payload is returned along with the error
+func verifyRemoteManifest(data []byte, key ed25519.PublicKey) (*RemotePayload, error) {
+	if len(key) != ed25519.PublicKeySize {
+		return nil, errors.New("invalid public key")
+	}
+
+	var manifest RemoteManifest
+	err := json.Unmarshal(data, &manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(manifest.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature: %w", err)
+	}
+	if !ed25519.Verify(key, manifest.Payload, sig) {
+		return nil, errors.New("signature verification failed")
+	}
+
+	var payload RemotePayload
+	err = json.Unmarshal(manifest.Payload, &payload)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	switch {
+	case payload.Expires.IsZero():
+		return &payload, errors.New("manifest has no expiry time")
+	case now.After(payload.Expires):
+		return &payload, fmt.Errorf("manifest expired at %s", payload.Expires.Format(time.RFC3339))
+	case payload.Issued.After(now):
+		return &payload, fmt.Errorf("manifest issued in the future at %s", payload.Issued.Format(time.RFC3339))
+	}
+
+	return &payload, nil
+}
+
+func writeRemoteCache(cache string, manifest []byte) error {
+	err := os.MkdirAll(filepath.Dir(cache), 0700)
+	if err != nil {
+		return err
+	}
+
+	tmp := cache + ".tmp"
+	err = os.WriteFile(tmp, manifest, 0600)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, cache)
+}
+
+// withoutRedirects is a copy of client that does not follow redirects, invocations
+// can hold secrets that should only be sent to the url in the manifest
+func withoutRedirects(client *http.Client) *http.Client {
+	c := *client
+	c.CheckRedirect = func(req *http.Request, _ []*http.Request) error {
+		return fmt.Errorf("refusing to follow redirect to %s", req.URL.Redacted())
+	}
+
+	return &c
+}
+
+// httpPluginExecutor runs remote commands by posting their arguments to an url
+type httpPluginExecutor struct {
+	client *http.Client
+	w      io.Writer
+}
+
+func (e *httpPluginExecutor) ExecutePlugin(execution *PluginExecution) error {
+	body, err := json.Marshal(&RemoteInvocation{Args: execution.Args})
+	if err != nil {
+		return err
+	}
+
+	resp, err := e.client.Post(execution.Command, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		msg = bytes.TrimSpace(msg)
+		if len(msg) > 0 {
+			return fmt.Errorf("%s: %s", resp.Status, msg)
+		}
+		return errors.New(resp.Status)
+	}
+
+	_, err = io.Copy(e.w, resp.Body)
+	return err
+}
@@ -0,0 +1,195 @@
+package fisk
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoteCommands(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+
+	plugin := New("ops", "Operations commands")
+	plugin.Command("deploy", "Deploys").Arg("service", "").Required().String()
+	model, err := json.Marshal(plugin.introspectModel())
+	assert.NoError(t, err)
+
+	var (
+		manifest []byte
+		fetches  int
+		posted   RemoteInvocation
+	)
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/manifest":
+			fetches++
+			w.Write(manifest)
+		case "/run":
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&posted))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	sign := func(payload RemotePayload, key ed25519.PrivateKey) []byte {
+		if payload.Expires.IsZero() {
+			payload.Issued = time.Now().Add(-time.Minute)
+			payload.Expires = time.Now().Add(time.Hour)
+		}
+		p, err := json.Marshal(payload)
+		assert.NoError(t, err)
+		m, err := json.Marshal(RemoteManifest{Payload: p, Signature: base64.StdEncoding.EncodeToString(ed25519.Sign(key, p))})
+		assert.NoError(t, err)
+		return m
+	}
+
+	cache := filepath.Join(t.TempDir(), "remote", "manifest.json")
+	newApp := func() *Application {
+		app := newTestApp()
+		app.remoteClient = srv.Client()
+		return app
+	}
+
+	_, err = newApp().RemoteCommands("http://example.net/manifest", cache, pub)
+	assert.EqualError(t, err, "http://example.net/manifest is not a https url")
+
+	_, otherKey, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+	manifest = sign(RemotePayload{Model: model, URL: srv.URL + "/run"}, otherKey)
+	_, err = newApp().RemoteCommands(srv.URL+"/manifest", cache, pub)
+	assert.ErrorContains(t, err, "signature verification failed")
+
+	manifest = sign(RemotePayload{Model: model, URL: srv.URL + "/run"}, priv)
+	app := newApp()
+	cmd, err := app.RemoteCommands(srv.URL+"/manifest", cache, pub)
+	assert.NoError(t, err)
+	assert.NotNil(t, cmd.GetCommand("deploy"))
+	assert.FileExists(t, cache)
+
+	_, err = app.Parse([]string{"ops", "deploy", "web"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"deploy", "web"}, posted.Args)
+
+	// a fresh cache is used without fetching the manifest
+	fetches = 0
+	cmd, err = newApp().RemoteCommands(srv.URL+"/manifest", cache, pub)
+	assert.NoError(t, err)
+	assert.NotNil(t, cmd.GetCommand("deploy"))
+	assert.Equal(t, 0, fetches)
+
+	// a stale cache is refreshed
+	old := time.Now().Add(-2 * time.Hour)
+	assert.NoError(t, os.Chtimes(cache, old, old))
+	_, err = newApp().RemoteCommands(srv.URL+"/manifest", cache, pub)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, fetches)
+
+	_, err = newApp().RemoteCacheTTL(time.Nanosecond).RemoteCommands(srv.URL+"/manifest", cache, pub)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, fetches)
+
+	// a stale cache is used when the manifest can not be fetched
+	srv.Close()
+	assert.NoError(t, os.Chtimes(cache, old, old))
+	cmd, err = newApp().RemoteCommands(srv.URL+"/manifest", cache, pub)
+	assert.NoError(t, err)
+	assert.NotNil(t, cmd.GetCommand("deploy"))
+
+	_, err = newApp().RemoteCommands(srv.URL+"/manifest", "", pub)
+	assert.ErrorContains(t, err, "could not fetch manifest")
+}
+
+func TestRemoteManifestValidity(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+
+	var manifest []byte
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(manifest)
+	}))
+	defer srv.Close()
+
+	sign := func(payload RemotePayload) []byte {
+		p, err := json.Marshal(payload)
+		assert.NoError(t, err)
+		m, err := json.Marshal(RemoteManifest{Payload: p, Signature: base64.StdEncoding.EncodeToString(ed25519.Sign(priv, p))})
+		assert.NoError(t, err)
+		return m
+	}
+
+	load := func(cache string) error {
+		app := newTestApp()
+		app.remoteClient = srv.Client()
+		_, err := app.RemoteCommands(srv.URL, cache, pub)
+		return err
+	}
+
+	model := json.RawMessage(`{"name":"ops","help":"Operations"}`)
+	now := time.Now()
+
+	manifest = sign(RemotePayload{Model: model, URL: srv.URL})
+	assert.ErrorContains(t, load(""), "manifest has no expiry time")
+
+	manifest = sign(RemotePayload{Model: model, URL: srv.URL, Issued: now.Add(-2 * time.Hour), Expires: now.Add(-time.Hour)})
+	assert.ErrorContains(t, load(""), "manifest expired at")
+
+	manifest = sign(RemotePayload{Model: model, URL: srv.URL, Issued: now.Add(time.Hour), Expires: now.Add(2 * time.Hour)})
+	assert.ErrorContains(t, load(""), "manifest issued in the future")
+
+	manifest = []byte(`{"payload":"` + strings.Repeat("x", maxRemoteManifestSize) + `"}`)
+	assert.ErrorContains(t, load(""), "exceeds 1048576 bytes")
+
+	// manifests issued before the cached one are rejected, even when the cached one expired
+	replay := filepath.Join(t.TempDir(), "manifest.json")
+	assert.NoError(t, os.WriteFile(replay, sign(RemotePayload{Model: model, URL: srv.URL, Issued: now.Add(-time.Minute), Expires: now.Add(time.Hour)}), 0600))
+	old := now.Add(-2 * time.Hour)
+	assert.NoError(t, os.Chtimes(replay, old, old))
+	manifest = sign(RemotePayload{Model: model, URL: srv.URL, Issued: now.Add(-time.Hour), Expires: now.Add(time.Hour)})
+	assert.ErrorContains(t, load(replay), "before the cached manifest")
+
+	assert.NoError(t, os.WriteFile(replay, sign(RemotePayload{Model: model, URL: srv.URL, Issued: now.Add(-2 * time.Hour), Expires: now.Add(-time.Minute)}), 0600))
+	manifest = sign(RemotePayload{Model: model, URL: srv.URL, Issued: now.Add(-3 * time.Hour), Expires: now.Add(time.Hour)})
+	assert.ErrorContains(t, load(replay), "before the cached manifest")
+
+	manifest = sign(RemotePayload{Model: model, URL: srv.URL, Issued: now.Add(-time.Minute), Expires: now.Add(time.Hour)})
+	assert.NoError(t, load(replay))
+
+	// an expired cached manifest is not used when the url fails
+	cache := filepath.Join(t.TempDir(), "manifest.json")
+	assert.NoError(t, os.WriteFile(cache, sign(RemotePayload{Model: model, URL: srv.URL, Issued: now.Add(-2 * time.Hour), Expires: now.Add(-time.Hour)}), 0600))
+	srv.Config.Handler = http.NotFoundHandler()
+	assert.ErrorContains(t, load(cache), "no valid cached manifest")
+}
+
+func TestRemoteInvocationRedirects(t *testing.T) {
+	posted := false
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/moved":
+			http.Redirect(w, r, "/run", http.StatusTemporaryRedirect)
+		case "/run":
+			posted = true
+		}
+	}))
+	defer srv.Close()
+
+	executor := &httpPluginExecutor{client: withoutRedirects(srv.Client()), w: io.Discard}
+	err := executor.ExecutePlugin(&PluginExecution{Command: srv.URL + "/moved", Args: []string{"--token", "s3cret"}})
+	assert.ErrorContains(t, err, "refusing to follow redirect to "+srv.URL+"/run")
+	assert.False(t, posted)
+
+	assert.NoError(t, executor.ExecutePlugin(&PluginExecution{Command: srv.URL + "/run"}))
+	assert.True(t, posted)
+}
@@ -0,0 +1,49 @@
+package fisk
+
+// Reset clears the state left behind by Parse() so the application can be
+// parsed again, for example by a REPL or in tests.
+//
+// Values of flags and arguments implementing Resetter, which includes all
+// values provided by fisk, are set to their zero value and IsSetByUser()
+// markers are cleared. Defaults and environment variables are applied again
+// by the next Parse(). Custom values not implementing Resetter keep their
+// current value, cumulative ones would thus keep accumulating.
+func (a *Application) Reset() *Application {
+	resetFlags(a.flagGroup)
+	resetArgs(a.argGroup)
+	resetCommands(a.cmdGroup)
+	a.lastContext = nil
+
+	return a
+}
+
+func resetCommands(group *cmdGroup) {
+	for _, cmd := range group.commandOrder {
+		cmd.completionAlts = nil
+		cmd.wizardRequested = false
+		resetFlags(cmd.flagGroup)
+		resetArgs(cmd.argGroup)
+		resetCommands(cmd.cmdGroup)
+	}
+}
+
+func resetFlags(group *flagGroup) {
+	for _, flag := range group.flagOrder {
+		if flag.setByUser != nil {
+			*flag.setByUser = false
+		}
+		resetValue(flag.value)
+	}
+}
+
+func resetArgs(group *argGroup) {
+	for _, arg := range group.args {
+		resetValue(arg.value)
+	}
+}
+
+func resetValue(value Value) {
+	if r, ok := value.(Resetter); ok {
+		r.Reset()
+	}
+}
@@ -0,0 +1,39 @@
+package fisk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReset(t *testing.T) {
+	var serverSet bool
+
+	app := newTestApp().WithVerbosityFlags()
+	server := app.Flag("server", "").IsSetByUser(&serverSet).String()
+	add := app.Command("add", "")
+	subjects := add.Flag("subject", "").Strings()
+	replicas := add.Flag("replicas", "").Default("1").Int()
+	name := add.Arg("name", "").String()
+	app.Command("rm", "")
+
+	_, err := app.Parse([]string{"add", "ORDERS", "--server", "example.net", "--subject", "a", "--replicas", "3", "-vv"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a"}, *subjects)
+	assert.True(t, serverSet)
+
+	app.Reset()
+	assert.Equal(t, "", *server)
+	assert.False(t, serverSet)
+	assert.Empty(t, *subjects)
+	assert.Equal(t, 0, *replicas)
+	assert.Equal(t, "", *name)
+	assert.Equal(t, 0, app.verbose)
+
+	_, err = app.Parse([]string{"add", "STREAM", "--subject", "b"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b"}, *subjects)
+	assert.Equal(t, 1, *replicas)
+	assert.Equal(t, "STREAM", *name)
+	assert.False(t, serverSet)
+}
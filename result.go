@@ -0,0 +1,186 @@
+package fisk
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseResult holds the values resolved by Resolve(), keyed by clause.
+//
+// Unlike Parse() the values are not written to the flags and arguments and
+// no actions are run, so a single initialized Application can be resolved
+// from multiple goroutines at the same time.
+type ParseResult struct {
+	// Command is the selected command, space separated
+	Command string
+	// SelectedCommand is the selected command, nil when none were selected
+	SelectedCommand *CmdClause
+	// Context is the read only parse context the values were resolved from
+	Context *ParseContext
+
+	flags map[*FlagClause][]string
+	args  map[*ArgClause][]string
+	set   map[interface{}]bool
+}
+
+// FlagValues are all values for a flag, more than one for cumulative flags
+func (r *ParseResult) FlagValues(flag *FlagClause) []string {
+	return r.flags[flag]
+}
+
+// FlagValue is the last value for a flag
+func (r *ParseResult) FlagValue(flag *FlagClause) string {
+	return last(r.flags[flag])
+}
+
+// ArgValues are all values for an argument, more than one for cumulative arguments
+func (r *ParseResult) ArgValues(arg *ArgClause) []string {
+	return r.args[arg]
+}
+
+// ArgValue is the last value for an argument
+func (r *ParseResult) ArgValue(arg *ArgClause) string {
+	return last(r.args[arg])
+}
+
+// IsSetByUser determines if a *FlagClause or *ArgClause was given on the command line
+func (r *ParseResult) IsSetByUser(clause interface{}) bool {
+	return r.set[clause]
+}
+
+func last(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[len(values)-1]
+}
+
+// Resolve parses args, resolves defaults and environment variables and
+// validates the result into a ParseResult without modifying the application,
+// the values bound to flags and arguments or running any actions.
+func (a *Application) Resolve(args []string) (*ParseResult, error) {
+	err := a.lockedInit()
+	if err != nil {
+		return nil, err
+	}
+
+	context := tokenize(args, false)
+	context.app = a
+	context.readOnly = true
+
+	err = parse(context, a)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ParseResult{
+		Context:         context,
+		SelectedCommand: context.SelectedCommand,
+		flags:           map[*FlagClause][]string{},
+		args:            map[*ArgClause][]string{},
+		set:             map[interface{}]bool{},
+	}
+
+	var selected []string
+	for _, element := range context.Elements {
+		switch clause := element.Clause.(type) {
+		case *CmdClause:
+			selected = append(selected, clause.name)
+		case *FlagClause:
+			v, err := clause.hooked(*element.Value)
+			if err != nil {
+				return nil, redactError(err, clause.secret, *element.Value)
+			}
+			result.flags[clause] = append(result.flags[clause], v)
+			result.set[clause] = true
+		case *ArgClause:
+			result.args[clause] = append(result.args[clause], *element.Value)
+			result.set[clause] = true
+		}
+	}
+	result.Command = strings.Join(selected, " ")
+
+	for _, flag := range context.flags.long {
+		if !result.set[flag] {
+			result.flags[flag], err = resolvedDefaults(&flag.envarMixin, isCumulative(flag.value), flag.defaults)
+			if err != nil {
+				return nil, fmt.Errorf("default for --%s: %w", flag.name, err)
+			}
+			for i, v := range result.flags[flag] {
+				result.flags[flag][i], err = flag.hooked(flag.normalize(v))
+				if err != nil {
+					return nil, redactError(err, flag.secret, v)
+				}
+			}
+		}
+	}
+
+	for _, arg := range context.arguments.args {
+		if !result.set[arg] {
+			result.args[arg], err = resolvedDefaults(&arg.envarMixin, isCumulative(arg.value), arg.defaults)
+			if err != nil {
+				return nil, fmt.Errorf("default for '%s': %w", arg.name, err)
+			}
+			for i, v := range result.args[arg] {
+				result.args[arg][i] = arg.normalize(v)
+			}
+		}
+	}
+
+	err = a.validateRequired(context)
+	if err != nil {
+		return nil, err
+	}
+
+	for flag, values := range result.flags {
+		if flag.validator == nil {
+			continue
+		}
+		for _, v := range values {
+			if err := flag.validator(v); err != nil {
+				return nil, redactError(err, flag.secret, v)
+			}
+		}
+	}
+
+	for arg, values := range result.args {
+		if arg.validator == nil {
+			continue
+		}
+		for _, v := range values {
+			if err := arg.validator(v); err != nil {
+				return nil, redactError(err, arg.secret, v)
+			}
+		}
+	}
+
+	if result.Command == "" && a.commandRequired(context) {
+		return result, ErrCommandNotSpecified
+	}
+
+	return result, nil
+}
+
+// resolvedDefaults are the values from the environment or defaults, the result
+// is a copy that callers can modify without changing the defaults
+func resolvedDefaults(envar *envarMixin, cumulative bool, defaults func() ([]string, error)) ([]string, error) {
+	if envar.HasEnvarValue() {
+		if cumulative {
+			return envar.GetSplitEnvarValue(), nil
+		}
+		return []string{envar.GetEnvarValue()}, nil
+	}
+
+	values, err := defaults()
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]string(nil), values...), nil
+}
+
+func isCumulative(value Value) bool {
+	v, ok := value.(repeatableFlag)
+	return ok && v.IsCumulative()
+}
@@ -0,0 +1,79 @@
+package fisk
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolve(t *testing.T) {
+	os.Setenv("TEST_RESOLVE_REPLICAS", "3")
+	defer os.Unsetenv("TEST_RESOLVE_REPLICAS")
+
+	app := newTestApp()
+	server := app.Flag("server", "").Default("localhost")
+	serverValue := server.String()
+	add := app.Command("add", "")
+	replicas := add.Flag("replicas", "").Envar("TEST_RESOLVE_REPLICAS")
+	replicas.Int()
+	subjects := add.Flag("subject", "")
+	subjects.Strings()
+	name := add.Arg("name", "").Required().Validator(func(v string) error {
+		if v == "invalid" {
+			return fmt.Errorf("invalid name")
+		}
+		return nil
+	})
+	name.String()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			res, err := app.Resolve([]string{"add", fmt.Sprintf("n%d", i), "--subject", "a", "--subject", "b"})
+			assert.NoError(t, err)
+			assert.Equal(t, "add", res.Command)
+			assert.Equal(t, fmt.Sprintf("n%d", i), res.ArgValue(name))
+			assert.Equal(t, []string{"a", "b"}, res.FlagValues(subjects))
+			assert.Equal(t, "3", res.FlagValue(replicas))
+			assert.Equal(t, "localhost", res.FlagValue(server))
+			assert.True(t, res.IsSetByUser(subjects))
+			assert.False(t, res.IsSetByUser(server))
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, "", *serverValue)
+
+	_, err := app.Resolve([]string{"add", "invalid"})
+	assert.EqualError(t, err, "invalid name")
+
+	_, err = app.Resolve([]string{"add"})
+	assert.ErrorIs(t, err, ErrRequiredArgument)
+
+	_, err = app.Resolve([]string{})
+	assert.ErrorIs(t, err, ErrCommandNotSpecified)
+}
+
+func TestResolveDoesNotChangeDefaults(t *testing.T) {
+	app := newTestApp()
+	subjects := app.Flag("subject", "").Default(" a ", " b ").TrimSpace().SetHook(func(raw string) (string, error) { return raw + ".x", nil })
+	subjects.Strings()
+	names := app.Arg("name", "").Default("One", "Two").ToLower()
+	names.Strings()
+
+	for i := 0; i < 2; i++ {
+		res, err := app.Resolve([]string{})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a.x", "b.x"}, res.FlagValues(subjects))
+		assert.Equal(t, []string{"one", "two"}, res.ArgValues(names))
+	}
+
+	assert.Equal(t, []string{" a ", " b "}, subjects.defaultValues)
+	assert.Equal(t, []string{"One", "Two"}, names.defaultValues)
+}
@@ -0,0 +1,133 @@
+package fisk
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// HelpMatch is a command found by SearchHelp()
+type HelpMatch struct {
+	// Command is the full command, like "stream add"
+	Command string
+	// Help is the short help of the command
+	Help string
+	// Context are the lines that matched prefixed by where they were found,
+	// like "flag --replicas: Number of replicas"
+	Context []string
+
+	nameMatch bool
+}
+
+// SearchHelp finds visible commands where term appears, ignoring case, in the
+// name, aliases, help, long help, flag help or examples, commands matching by
+// name are listed first
+func (a *Application) SearchHelp(term string) ([]*HelpMatch, error) {
+	if err := a.lockedInit(); err != nil {
+		return nil, err
+	}
+
+	term = strings.ToLower(strings.TrimSpace(term))
+	if term == "" {
+		return nil, fmt.Errorf("search term is required")
+	}
+
+	var matches []*HelpMatch
+	var walk func(cmds []*CmdModel)
+	walk = func(cmds []*CmdModel) {
+		for _, cmd := range cmds {
+			if cmd.Hidden || cmd.FullCommand == "help" {
+				continue
+			}
+
+			if match := searchCommand(cmd, term); match != nil {
+				matches = append(matches, match)
+			}
+
+			if cmd.CmdGroupModel != nil {
+				walk(cmd.Commands)
+			}
+		}
+	}
+	walk(a.sharedModel().Commands)
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].nameMatch && !matches[j].nameMatch
+	})
+
+	return matches, nil
+}
+
+func searchCommand(cmd *CmdModel, term string) *HelpMatch {
+	match := &HelpMatch{Command: cmd.FullCommand, Help: strings.SplitN(cmd.Help, "\n", 2)[0]}
+
+	contains := func(s string) bool { return strings.Contains(strings.ToLower(s), term) }
+	add := func(where string, text string) {
+		for _, line := range strings.Split(text, "\n") {
+			if contains(line) {
+				match.Context = append(match.Context, fmt.Sprintf("%s: %s", where, strings.TrimSpace(line)))
+			}
+		}
+	}
+
+	match.nameMatch = contains(cmd.Name)
+	for _, alias := range cmd.Aliases {
+		if contains(alias) {
+			match.nameMatch = true
+			match.Context = append(match.Context, "alias: "+alias)
+		}
+	}
+
+	add("help", cmd.Help)
+	add("long help", cmd.HelpLong)
+
+	if cmd.FlagGroupModel != nil {
+		for _, flag := range cmd.Flags {
+			if flag.Hidden {
+				continue
+			}
+			if contains(flag.Name) {
+				match.Context = append(match.Context, fmt.Sprintf("flag --%s: %s", flag.Name, flag.Help))
+				continue
+			}
+			add("flag --"+flag.Name, flag.Help)
+		}
+	}
+
+	for _, example := range cmd.Examples {
+		if contains(example.Command) || contains(example.Help) {
+			match.Context = append(match.Context, "example: "+example.Command)
+		}
+	}
+
+	if !match.nameMatch && len(match.Context) == 0 {
+		return nil
+	}
+
+	return match
+}
+
+func writeHelpSearch(w io.Writer, term string, matches []*HelpMatch) {
+	if len(matches) == 0 {
+		fmt.Fprintf(w, "No commands match %q\n", term)
+		return
+	}
+
+	for i, match := range matches {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+
+		fmt.Fprintln(w, match.Command)
+		if match.Help != "" {
+			fmt.Fprintf(w, "    %s\n", match.Help)
+		}
+		for _, line := range match.Context {
+			if strings.HasPrefix(line, "help: ") && strings.TrimPrefix(line, "help: ") == match.Help {
+				continue
+			}
+			fmt.Fprintf(w, "    %s\n", line)
+		}
+	}
+}
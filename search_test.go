@@ -0,0 +1,52 @@
+package fisk
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchHelp(t *testing.T) {
+	var buf bytes.Buffer
+
+	app := newTestApp().UsageWriter(&buf)
+	stream := app.Command("stream", "Manage streams")
+	add := stream.Command("add", "Adds a stream").HelpLong("Streams store messages\nReplicas improve availability")
+	add.Flag("replicas", "Number of copies to keep").Int()
+	add.Example("test stream add ORDERS --replicas 3", "Adds a replicated stream")
+	stream.Command("rm", "Removes a stream").Flag("force", "Do not prompt").Bool()
+	app.Command("replay", "Replays messages")
+	app.Command("secret", "Replicas secrets").Hidden()
+
+	matches, err := app.SearchHelp("REPLICA")
+	assert.NoError(t, err)
+	assert.Len(t, matches, 1)
+	assert.Equal(t, "stream add", matches[0].Command)
+	assert.Equal(t, []string{
+		"long help: Replicas improve availability",
+		"flag --replicas: Number of copies to keep",
+		"example: test stream add ORDERS --replicas 3",
+	}, matches[0].Context)
+
+	matches, err = app.SearchHelp("re")
+	assert.NoError(t, err)
+	var commands []string
+	for _, m := range matches {
+		commands = append(commands, m.Command)
+	}
+	assert.Equal(t, []string{"stream", "replay", "stream add", "stream rm"}, commands)
+
+	_, err = app.SearchHelp(" ")
+	assert.EqualError(t, err, "search term is required")
+
+	_, err = app.Parse([]string{"help", "--search", "prompt"})
+	assert.NoError(t, err)
+	assert.Equal(t, "stream rm\n    Removes a stream\n    flag --force: Do not prompt\n", buf.String())
+
+	buf.Reset()
+	app.Reset()
+	_, err = app.Parse([]string{"help", "--search", "nothing"})
+	assert.NoError(t, err)
+	assert.Equal(t, "No commands match \"nothing\"\n", buf.String())
+}
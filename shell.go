@@ -0,0 +1,367 @@
+package fisk
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// WithShell adds a shell command that reads commands from stdin and executes them
+// against the application, global flags given to the shell are kept for all commands
+func (a *Application) WithShell() *Application {
+	a.ShellCommand = a.Commandf("shell", "Interactive shell for %s", a.Name).Action(func(pc *ParseContext) error {
+		return a.runShell(pc, a.newPrompter())
+	})
+	a.ShellCommand.HelpLong(`Commands are entered without the application name, previous commands can be
+listed using 'history' and repeated using '!!' or '!<number>'.
+
+Press tab to complete the current word, the possible completions are listed
+when there is more than one.
+
+Use 'exit', 'quit' or end of input to leave the shell.`)
+
+	return a
+}
+
+// shellExit is raised by terminate while in the shell to stop handling the current line
+type shellExit struct {
+	code int
+}
+
+type shellState struct {
+	history []string
+	globals []string
+	values  map[string]string
+}
+
+func (a *Application) runShell(pc *ParseContext, p *prompter) error {
+	if a.inShell {
+		return fmt.Errorf("already in a shell")
+	}
+
+	terminate := a.terminate
+	a.inShell = true
+	a.terminate = func(code int) { panic(shellExit{code: code}) }
+	defer func() {
+		a.terminate = terminate
+		a.inShell = false
+	}()
+
+	state := &shellState{values: map[string]string{}}
+	state.rememberGlobals(a, pc)
+
+	for {
+		line, err := a.readShellLine(p, a.Name+"> ")
+		if err != nil && err != io.EOF {
+			return err
+		}
+		eof := err == io.EOF
+
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "" && eof, line == "exit", line == "quit":
+			if eof {
+				fmt.Fprintln(p.out)
+			}
+			return nil
+
+		case line == "":
+			continue
+
+		case line == "history":
+			for i, h := range state.history {
+				fmt.Fprintf(p.out, "%5d  %s\n", i+1, h)
+			}
+			continue
+
+		case strings.HasPrefix(line, "!"):
+			line, err = state.recall(line)
+			if err != nil {
+				fmt.Fprintln(p.out, err)
+				continue
+			}
+			fmt.Fprintln(p.out, line)
+		}
+
+		state.history = append(state.history, line)
+
+		words, err := splitCommandLine(line)
+		if err != nil {
+			a.Errorf("%s", err)
+			continue
+		}
+
+		args := append(append([]string{}, state.globals...), words...)
+		terminated, err := a.shellParse(args)
+		if err != nil {
+			a.Errorf("%s", err)
+			continue
+		}
+
+		// flags like --version that terminate are not kept for later lines
+		if lpc, err := a.parseContext(true, args); err == nil && !terminated {
+			state.rememberGlobals(a, lpc)
+		}
+
+		if eof {
+			return nil
+		}
+	}
+}
+
+// shellParse parses and runs args, a terminate call like the one after --help
+// ends handling of the line rather than the shell
+func (a *Application) shellParse(args []string) (terminated bool, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(shellExit); !ok {
+				panic(r)
+			}
+			terminated = true
+		}
+	}()
+
+	a.Reset()
+	_, err = a.Parse(args)
+
+	return false, err
+}
+
+// readShellLine reads a line after showing prompt, pressing tab completes the
+// current word, when the input is raw the line is echoed here
+func (a *Application) readShellLine(p *prompter, prompt string) (string, error) {
+	fmt.Fprint(p.out, prompt)
+
+	echo := false
+	if p.raw != nil {
+		if restore, err := p.raw(); err == nil {
+			defer restore()
+			echo = true
+		}
+	}
+
+	var line []rune
+	for {
+		r, _, err := p.in.ReadRune()
+		if err != nil {
+			return string(line), err
+		}
+
+		switch r {
+		case '\r', '\n':
+			if echo {
+				fmt.Fprintln(p.out)
+			}
+			return string(line), nil
+
+		case '\t':
+			line = a.completeShellLine(p, prompt, line, echo)
+
+		case '\b', 127:
+			if len(line) > 0 {
+				line = line[:len(line)-1]
+				if echo {
+					fmt.Fprint(p.out, "\b \b")
+				}
+			}
+
+		case 3: // ctrl-c discards the line
+			if echo {
+				fmt.Fprintln(p.out, "^C")
+			}
+			line = nil
+			fmt.Fprint(p.out, prompt)
+
+		case 4: // ctrl-d on an empty line ends the input
+			if len(line) == 0 {
+				return "", io.EOF
+			}
+
+		case 27: // escape sequences like the arrow keys are ignored
+			if next, _, err := p.in.ReadRune(); err == nil && next == '[' {
+				for {
+					c, _, err := p.in.ReadRune()
+					if err != nil || (c >= 0x40 && c <= 0x7e) {
+						break
+					}
+				}
+			}
+
+		default:
+			if unicode.IsPrint(r) {
+				line = append(line, r)
+				if echo {
+					fmt.Fprint(p.out, string(r))
+				}
+			}
+		}
+	}
+}
+
+// completeShellLine completes the last word of line, when the completions
+// share no longer prefix they are listed and the prompt is shown again
+func (a *Application) completeShellLine(p *prompter, prompt string, line []rune, echo bool) []rune {
+	text := string(line)
+	words, err := splitCommandLine(text)
+	if err != nil {
+		return line
+	}
+	if len(words) == 0 || strings.HasSuffix(text, " ") {
+		words = append(words, "")
+	}
+
+	current := words[len(words)-1]
+	matches := a.shellCompletions(words)
+	if len(matches) == 0 {
+		return line
+	}
+
+	prefix := matches[0]
+	for _, match := range matches[1:] {
+		for !strings.HasPrefix(match, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	if !strings.HasPrefix(prefix, current) {
+		return line
+	}
+
+	completion := prefix[len(current):]
+	if len(matches) == 1 && !strings.HasSuffix(prefix, "/") {
+		completion += " "
+	}
+
+	if completion == "" {
+		fmt.Fprintf(p.out, "\n%s\n%s%s", strings.Join(matches, "  "), prompt, text)
+		return line
+	}
+
+	if echo {
+		fmt.Fprint(p.out, completion)
+	}
+
+	return append(line, []rune(completion)...)
+}
+
+// shellCompletions completes the last word in args using the bash completion engine
+func (a *Application) shellCompletions(args []string) []string {
+	// completionOptions expects the first argument to be the --completion-bash flag
+	context, _ := a.parseContext(true, args)
+	context.rawArgs = append([]string{"--completion-bash"}, args...)
+
+	current := ""
+	if len(args) > 0 {
+		current = args[len(args)-1]
+	}
+
+	var matches []string
+	for _, opt := range a.completionOptions(context) {
+		if hint := parsePathDirective(opt); hint != nil {
+			matches = append(matches, hint.complete(current)...)
+			continue
+		}
+
+		if strings.HasPrefix(opt, current) {
+			matches = append(matches, opt)
+		}
+	}
+
+	return matches
+}
+
+func (s *shellState) rememberGlobals(a *Application, pc *ParseContext) {
+	for _, element := range pc.Elements {
+		flag, ok := element.Clause.(*FlagClause)
+		if !ok || element.Value == nil || a.flagGroup.long[flag.name] != flag {
+			continue
+		}
+		if flag.name == "help" || strings.HasPrefix(flag.name, "help-") || strings.HasPrefix(flag.name, "completion-") {
+			continue
+		}
+
+		s.values[flag.name] = *element.Value
+	}
+
+	s.globals = nil
+	for _, flag := range a.flagOrder {
+		if v, ok := s.values[flag.name]; ok {
+			s.globals = append(s.globals, fmt.Sprintf("--%s=%s", flag.name, v))
+		}
+	}
+}
+
+func (s *shellState) recall(line string) (string, error) {
+	if len(s.history) == 0 {
+		return "", fmt.Errorf("no history")
+	}
+
+	if line == "!!" {
+		return s.history[len(s.history)-1], nil
+	}
+
+	n, err := strconv.Atoi(line[1:])
+	if err != nil || n < 1 || n > len(s.history) {
+		return "", fmt.Errorf("%s: event not found", line)
+	}
+
+	return s.history[n-1], nil
+}
+
+// splitCommandLine splits a line into words honoring single and double quotes and backslash escapes
+func splitCommandLine(line string) ([]string, error) {
+	var (
+		words   []string
+		word    strings.Builder
+		inWord  bool
+		quote   rune
+		escaped bool
+	)
+
+	for _, r := range line {
+		switch {
+		case escaped:
+			word.WriteRune(r)
+			escaped = false
+
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inWord = true
+
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+
+		case r == ' ' || r == '\t':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if escaped {
+		return nil, fmt.Errorf("unterminated escape")
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+
+	return words, nil
+}
@@ -0,0 +1,86 @@
+package fisk
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitCommandLine(t *testing.T) {
+	cases := []struct {
+		line  string
+		words []string
+		err   bool
+	}{
+		{"stream add ORDERS", []string{"stream", "add", "ORDERS"}, false},
+		{`pub "hello world"  'it''s' a\ b`, []string{"pub", "hello world", "its", "a b"}, false},
+		{`pub ""`, []string{"pub", ""}, false},
+		{`pub "unterminated`, nil, true},
+	}
+
+	for _, c := range cases {
+		words, err := splitCommandLine(c.line)
+		if c.err {
+			assert.Error(t, err, c.line)
+			continue
+		}
+		assert.NoError(t, err, c.line)
+		assert.Equal(t, c.words, words, c.line)
+	}
+}
+
+func TestShell(t *testing.T) {
+	var names []string
+	var servers []string
+
+	app := newTestApp().WithShell()
+	server := app.Flag("server", "").Default("localhost").String()
+	add := app.Command("add", "")
+	name := add.Arg("name", "").String()
+	add.Action(func(*ParseContext) error {
+		names = append(names, *name)
+		servers = append(servers, *server)
+		return nil
+	})
+	app.Command("list", "")
+
+	pc, err := app.ParseContext([]string{"--server", "example.net", "shell"})
+	assert.NoError(t, err)
+
+	out := bytes.NewBuffer(nil)
+	in := strings.NewReader("add one\nadd 'two three'\n!1\nhistory\nad\tfour\n\t\nexit\n")
+	assert.NoError(t, app.runShell(pc, newPrompter(in, out)))
+
+	assert.Equal(t, []string{"one", "two three", "one", "four"}, names)
+	assert.Equal(t, []string{"example.net", "example.net", "example.net", "example.net"}, servers)
+	assert.Contains(t, out.String(), "    2  add 'two three'")
+	assert.Contains(t, out.String(), "\nhelp  shell  add  list\ntest> ")
+}
+
+func TestShellTerminate(t *testing.T) {
+	var ran []string
+
+	usage := bytes.NewBuffer(nil)
+	app := newTestApp().WithShell().Terminate(func(int) { t.Fatal("the shell was terminated") })
+	app.UsageWriter(usage)
+	app.Version("1.0.0")
+	app.Command("delete", "Deletes things").Action(func(*ParseContext) error {
+		ran = append(ran, "delete")
+		return nil
+	})
+
+	pc, err := app.ParseContext([]string{"shell"})
+	assert.NoError(t, err)
+
+	in := strings.NewReader("delete --help\nhelp delete\n--version\ndelete\nexit\n")
+	errs := bytes.NewBuffer(nil)
+	app.ErrorWriter(errs)
+	assert.NoError(t, app.runShell(pc, newPrompter(in, bytes.NewBuffer(nil))))
+
+	assert.Empty(t, errs.String())
+	assert.Equal(t, []string{"delete"}, ran)
+	assert.Contains(t, usage.String(), "Deletes things")
+	assert.Contains(t, usage.String(), "1.0.0")
+}
@@ -0,0 +1,68 @@
+package fisk
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Shortcut defines a shell alias name running the application with the
+// command line command, for example Shortcut("nsl", "stream ls"). Shortcuts are
+// written by WriteShellAliases() and the docs command.
+func (a *Application) Shortcut(name string, command string) *Application {
+	if a.shortcuts == nil {
+		a.shortcuts = map[string]string{}
+	}
+
+	a.shortcuts[name] = command
+	a.modelChanged()
+
+	return a
+}
+
+// Shortcut defines a shell alias name running this command, see Application.Shortcut()
+func (c *CmdClause) Shortcut(name string) *CmdClause {
+	c.app.Shortcut(name, c.FullCommand())
+	return c
+}
+
+// validateShortcuts ensures every shortcut starts with a known command
+func (a *Application) validateShortcuts() error {
+	for name, command := range a.shortcuts {
+		words := strings.Fields(command)
+		if len(words) == 0 || strings.HasPrefix(words[0], "-") {
+			continue
+		}
+
+		if a.cmdGroup.commands[words[0]] == nil {
+			return fmt.Errorf("shortcut %q refers to unknown command %q", name, words[0])
+		}
+	}
+
+	return nil
+}
+
+// WriteShellAliases writes alias definitions for all shortcuts to w, the
+// output can be sourced by bash, zsh and fish
+func (a *Application) WriteShellAliases(w io.Writer) error {
+	return writeShellAliases(w, a.sharedModel())
+}
+
+func writeShellAliases(w io.Writer, model *ApplicationModel) error {
+	var names []string
+	for name := range model.Shortcuts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		line := strings.TrimSpace(model.Name + " " + model.Shortcuts[name])
+		_, err := fmt.Fprintf(w, "alias %s=%s\n", name, shellQuote(line))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package fisk
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShortcuts(t *testing.T) {
+	app := newTestApp().WithDocsCommand().UsageWriter(&bytes.Buffer{})
+	stream := app.Command("stream", "")
+	stream.Command("ls", "").Shortcut("nsl")
+	app.Shortcut("nsi", "stream info --json 'ORDERS'")
+
+	dir := t.TempDir()
+	_, err := app.Parse([]string{"docs", "--dir", dir, "--formats", "aliases"})
+	assert.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	assert.NoError(t, app.WriteShellAliases(out))
+	assert.Equal(t, "alias nsi='test stream info --json '\\''ORDERS'\\'''\nalias nsl='test stream ls'\n", out.String())
+
+	saved, err := os.ReadFile(filepath.Join(dir, "test-aliases.sh"))
+	assert.NoError(t, err)
+	assert.Equal(t, out.String(), string(saved))
+
+	app = newTestApp().Shortcut("x", "missing ls")
+	app.Command("stream", "")
+	_, err = app.Parse([]string{"stream"})
+	assert.EqualError(t, err, `shortcut "x" refers to unknown command "missing"`)
+}
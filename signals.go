@@ -0,0 +1,86 @@
+package fisk
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"time"
+)
+
+// HandleSignals cancels the context available using ParseContext.Context() when
+// one of signals is received while pre actions and actions run, a second signal
+// terminates the application immediately
+func (a *Application) HandleSignals(signals ...os.Signal) *Application {
+	if len(signals) == 0 {
+		signals = []os.Signal{os.Interrupt}
+	}
+
+	a.signals = signals
+	return a
+}
+
+// WithTimeoutFlag adds a global --timeout flag that limits how long pre actions
+// and actions may run using the context available using ParseContext.Context(), a zero
+// timeout disables the deadline
+func (a *Application) WithTimeoutFlag(timeout time.Duration) *Application {
+	a.Flag("timeout", "How long to wait for operations to complete").Default(timeout.String()).DurationVar(&a.timeout)
+	return a
+}
+
+// Context is the context for pre actions and actions, it is canceled when signals registered using
+// Application.HandleSignals() are received or the --timeout added by
+// Application.WithTimeoutFlag() is reached
+func (p *ParseContext) Context() context.Context {
+	if p.ctx == nil {
+		return context.Background()
+	}
+
+	return p.ctx
+}
+
+// prepareContext creates the context passed to pre actions and actions, the returned function must be called after they complete
+func (a *Application) prepareContext(pc *ParseContext) func() {
+	var (
+		ctx    context.Context
+		cancel context.CancelFunc
+	)
+
+	if a.timeout > 0 {
+		ctx, cancel = context.WithTimeout(pc.Context(), a.timeout)
+	} else {
+		ctx, cancel = context.WithCancel(pc.Context())
+	}
+	pc.ctx = ctx
+
+	if len(a.signals) == 0 {
+		return cancel
+	}
+
+	sigs := make(chan os.Signal, 2)
+	done := make(chan struct{})
+	signal.Notify(sigs, a.signals...)
+
+	go func() {
+		received := 0
+		for {
+			select {
+			case <-sigs:
+				received++
+				if received == 1 {
+					cancel()
+				} else {
+					a.terminate(1)
+					return
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(sigs)
+		close(done)
+		cancel()
+	}
+}
@@ -0,0 +1,90 @@
+//go:build unix
+// +build unix
+
+package fisk
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleSignals(t *testing.T) {
+	var ctxErr error
+
+	app := newTestApp().HandleSignals(syscall.SIGUSR1)
+	app.Action(func(pc *ParseContext) error {
+		p, _ := os.FindProcess(os.Getpid())
+		p.Signal(syscall.SIGUSR1)
+
+		select {
+		case <-pc.Context().Done():
+			ctxErr = pc.Context().Err()
+		case <-time.After(5 * time.Second):
+		}
+		return nil
+	})
+
+	_, err := app.Parse([]string{})
+	assert.NoError(t, err)
+	assert.ErrorIs(t, ctxErr, context.Canceled)
+}
+
+func TestParseContextContext(t *testing.T) {
+	assert.NotNil(t, (&ParseContext{}).Context())
+}
+
+func TestWithTimeoutFlag(t *testing.T) {
+	var deadline time.Time
+	var hasDeadline bool
+
+	app := newTestApp().WithTimeoutFlag(time.Minute)
+	app.Action(func(pc *ParseContext) error {
+		deadline, hasDeadline = pc.Context().Deadline()
+		return nil
+	})
+
+	_, err := app.Parse([]string{})
+	assert.NoError(t, err)
+	assert.True(t, hasDeadline)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, 5*time.Second)
+
+	_, err = app.Parse([]string{"--timeout", "1d"})
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(24*time.Hour), deadline, 5*time.Second)
+
+	_, err = app.Parse([]string{"--timeout", "0"})
+	assert.NoError(t, err)
+	assert.False(t, hasDeadline)
+}
+
+func TestPreActionContext(t *testing.T) {
+	var (
+		ctxErr      error
+		hasDeadline bool
+	)
+
+	app := newTestApp().HandleSignals(syscall.SIGUSR1).WithTimeoutFlag(time.Minute)
+	app.PreAction(func(pc *ParseContext) error {
+		_, hasDeadline = pc.Context().Deadline()
+
+		p, _ := os.FindProcess(os.Getpid())
+		p.Signal(syscall.SIGUSR1)
+
+		select {
+		case <-pc.Context().Done():
+			ctxErr = pc.Context().Err()
+		case <-time.After(5 * time.Second):
+		}
+		return nil
+	})
+
+	_, err := app.Parse([]string{})
+	assert.NoError(t, err)
+	assert.True(t, hasDeadline)
+	assert.ErrorIs(t, ctxErr, context.Canceled)
+}
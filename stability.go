@@ -0,0 +1,68 @@
+package fisk
+
+import (
+	"fmt"
+)
+
+// Stability communicates the maturity of a command or flag
+type Stability string
+
+const (
+	// Stable features are the default and are not annotated in help
+	Stable Stability = ""
+	// Beta features are complete but may still change
+	Beta Stability = "beta"
+	// Alpha features are experimental, they can be gated using EnableAlphaFlag()
+	Alpha Stability = "alpha"
+)
+
+// Suffix is the annotation shown in help, like " (beta)", empty when stable
+func (s Stability) Suffix() string {
+	if s == Stable {
+		return ""
+	}
+
+	return fmt.Sprintf(" (%s)", s)
+}
+
+// Stability sets the maturity of the command, shown in help and docs
+func (c *CmdClause) Stability(stability Stability) *CmdClause {
+	c.stability = stability
+	return c
+}
+
+// Stability sets the maturity of the flag, shown in help and docs
+func (f *FlagClause) Stability(stability Stability) *FlagClause {
+	f.stability = stability
+	return f
+}
+
+// EnableAlphaFlag adds a global --enable-alpha flag, using Alpha commands
+// and flags fails unless it is given
+func (a *Application) EnableAlphaFlag() *Application {
+	a.gateAlpha = true
+	a.Flag("enable-alpha", "Enables experimental commands and flags").UnNegatableBoolVar(&a.alphaEnabled)
+	return a
+}
+
+// validateStability ensures Alpha commands and flags are only used when enabled
+func (a *Application) validateStability(context *ParseContext) error {
+	if !a.gateAlpha || a.alphaEnabled {
+		return nil
+	}
+
+	for _, element := range context.Elements {
+		switch clause := element.Clause.(type) {
+		case *CmdClause:
+			if clause.stability == Alpha {
+				return fmt.Errorf("command '%s' is an alpha feature, use --enable-alpha to enable it", clause.FullCommand())
+			}
+		case *FlagClause:
+			if clause.stability == Alpha {
+				return fmt.Errorf("flag '--%s' is an alpha feature, use --enable-alpha to enable it", clause.name)
+			}
+		}
+	}
+
+	return nil
+}
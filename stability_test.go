@@ -0,0 +1,46 @@
+package fisk
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStability(t *testing.T) {
+	w := &bytes.Buffer{}
+	app := newTestApp().EnableAlphaFlag()
+	app.UsageWriter(w)
+	app.Command("stable", "A stable command")
+	beta := app.Command("beta", "A beta command").Stability(Beta)
+	beta.Flag("turbo", "Go faster").Stability(Alpha).Bool()
+	app.Command("alpha", "An alpha command").Stability(Alpha)
+
+	app.Usage(nil)
+	assert.Contains(t, w.String(), "\nbeta [<flags>] (beta)\n")
+	assert.Contains(t, w.String(), "\nalpha (alpha)\n")
+	assert.Contains(t, w.String(), "\nstable\n")
+
+	w.Reset()
+	app.Usage([]string{"beta"})
+	assert.Contains(t, w.String(), "Go faster (alpha)")
+
+	for _, cmd := range app.Model().Commands {
+		if cmd.Name == "beta" {
+			assert.Equal(t, Beta, cmd.Stability)
+			assert.Equal(t, Alpha, cmd.Flags[0].Stability)
+		}
+	}
+
+	_, err := app.Parse([]string{"beta"})
+	assert.NoError(t, err)
+
+	_, err = app.Parse([]string{"alpha"})
+	assert.EqualError(t, err, "command 'alpha' is an alpha feature, use --enable-alpha to enable it")
+
+	_, err = app.Parse([]string{"beta", "--turbo"})
+	assert.EqualError(t, err, "flag '--turbo' is an alpha feature, use --enable-alpha to enable it")
+
+	_, err = app.Parse([]string{"beta", "--turbo", "--enable-alpha"})
+	assert.NoError(t, err)
+}
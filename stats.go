@@ -0,0 +1,122 @@
+package fisk
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// WithUsageStats records the path of every command that is run, without its
+// flags or arguments, to the local file path and adds a stats command listing
+// the most used commands. Nothing is sent over the network.
+func (a *Application) WithUsageStats(path string) *Application {
+	var limit int
+
+	a.StatsCommand = a.Command("stats", "Shows the commands you use most").Action(func(_ *ParseContext) error {
+		return writeUsageStats(a.usageWriter, path, limit)
+	})
+	a.StatsCommand.Flag("limit", "Number of commands to show").Default("10").IntVar(&limit)
+
+	a.OnCommandRun(func(cmdPath string, _ []string, _ error, _ time.Duration) {
+		if cmdPath == "" || cmdPath == a.StatsCommand.FullCommand() {
+			return
+		}
+
+		err := recordUsage(path, cmdPath)
+		if err != nil {
+			a.Logger().Debug("Could not record usage statistics", "file", path, "error", err)
+		}
+	})
+
+	return a
+}
+
+// recordUsage appends a line with the time and cmdPath to the stats file
+func recordUsage(path string, cmdPath string) error {
+	err := os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(f, "%d\t%s\n", time.Now().Unix(), cmdPath)
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+type usageStat struct {
+	command string
+	count   int
+}
+
+func readUsageStats(path string) ([]usageStat, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	counts := map[string]int{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		_, cmd, ok := strings.Cut(scanner.Text(), "\t")
+		if ok && cmd != "" {
+			counts[cmd]++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	var stats []usageStat
+	for cmd, count := range counts {
+		stats = append(stats, usageStat{command: cmd, count: count})
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].count == stats[j].count {
+			return stats[i].command < stats[j].command
+		}
+		return stats[i].count > stats[j].count
+	})
+
+	return stats, nil
+}
+
+func writeUsageStats(w io.Writer, path string, limit int) error {
+	stats, err := readUsageStats(path)
+	if err != nil {
+		return err
+	}
+
+	if len(stats) == 0 {
+		fmt.Fprintln(w, "No commands have been recorded")
+		return nil
+	}
+
+	if limit > 0 && len(stats) > limit {
+		stats = stats[:limit]
+	}
+
+	for _, stat := range stats {
+		fmt.Fprintf(w, "%6d  %s\n", stat.count, stat.command)
+	}
+
+	return nil
+}
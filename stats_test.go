@@ -0,0 +1,41 @@
+package fisk
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsageStats(t *testing.T) {
+	var out bytes.Buffer
+
+	path := filepath.Join(t.TempDir(), "stats", "usage")
+	app := newTestApp().UsageWriter(&out).WithUsageStats(path)
+	stream := app.Command("stream", "")
+	stream.Command("ls", "").Action(func(*ParseContext) error { return nil })
+	stream.Command("add", "").Arg("name", "").String()
+
+	_, err := app.Parse([]string{"stats"})
+	assert.NoError(t, err)
+	assert.Equal(t, "No commands have been recorded\n", out.String())
+
+	for _, args := range [][]string{{"stream", "ls"}, {"stream", "add", "secret"}, {"stream", "ls"}, {"stats"}} {
+		app.Reset()
+		_, err = app.Parse(args)
+		assert.NoError(t, err)
+	}
+
+	out.Reset()
+	app.Reset()
+	_, err = app.Parse([]string{"stats"})
+	assert.NoError(t, err)
+	assert.Equal(t, "     2  stream ls\n     1  stream add\n", out.String())
+
+	out.Reset()
+	app.Reset()
+	_, err = app.Parse([]string{"stats", "--limit", "1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "     2  stream ls\n", out.String())
+}
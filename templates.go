@@ -21,7 +21,7 @@ var ShorterMainUsageTemplate = `{{define "FormatCommand" -}}
 {{define "FormatCommands" -}}
 {{range .Commands -}}
 {{if not .Hidden -}}
-  {{.FullCommand}}{{if .Default}}*{{end}}{{template "FormatCommand" .}}
+  {{.FullCommand}}{{if .Default}}*{{end}}{{template "FormatCommand" .}}{{.Annotations}}
 {{.Help|Wrap 4}}
 {{end -}}
 {{end -}}
@@ -31,7 +31,7 @@ var ShorterMainUsageTemplate = `{{define "FormatCommand" -}}
 {{range .Commands -}}
 {{if not .Hidden -}}
 {{if not (eq .FullCommand "help") -}}
-  {{.FullCommand}}{{if .Default}}*{{end}}{{template "FormatCommand" .}}
+  {{.FullCommand}}{{if .Default}}*{{end}}{{template "FormatCommand" .}}{{.Annotations}}
 {{.Help|FirstLine|Wrap 4}}
 {{end -}}
 {{end -}}
@@ -45,6 +45,8 @@ var ShorterMainUsageTemplate = `{{define "FormatCommand" -}}
 {{end -}}
 {{end -}}
 
+{{with .HelpHeader}}{{.|Wrap 0}}
+{{end -}}
 {{if .Context.SelectedCommand -}}
 usage: {{.App.Name}} {{.Context.SelectedCommand}}{{template "FormatUsage" .Context.SelectedCommand}}
 {{if .Context.SelectedCommand.HelpLong}}{{.Context.SelectedCommand.HelpLong|Wrap 0}}
@@ -53,13 +55,13 @@ usage: {{.App.Name}} {{.Context.SelectedCommand}}{{template "FormatUsage" .Conte
 usage: {{.App.Name}}{{template "FormatUsage" .App}}
 {{end -}}
 {{if .Context.SelectedCommand -}}
-{{if .Context.Flags|VisibleFlags -}}
-Flags:
-{{.Context.Flags|FlagsToTwoColumns|FormatTwoColumns}}
+{{range .Context.Flags|FlagGroups -}}
+{{if .Name}}{{.Name}}{{else}}Flags{{end}}:
+{{.Flags|FlagsToTwoColumns|FormatTwoColumns}}
 {{end -}}
-{{if .Context.Args -}}
-Args:
-{{.Context.Args|ArgsToTwoColumns|FormatTwoColumns}}
+{{range .Context.Args|ArgGroups -}}
+{{if .Name}}{{.Name}}{{else}}Args{{end}}:
+{{.Args|ArgsToTwoColumns|FormatTwoColumns}}
 {{end -}}
 {{if len .Context.SelectedCommand.Commands -}}
 Subcommands:
@@ -69,6 +71,7 @@ Subcommands:
 Commands:
 {{template "FormatCommandsForTopLevel" .App}}
 {{end -}}
+{{with .HelpFooter}}{{.|Wrap 0}}{{end -}}
 `
 
 // CompactMainUsageTemplate formats commands and subcommands in a two column
@@ -102,6 +105,8 @@ var CompactMainUsageTemplate = `{{define "FormatCommand" -}}
 {{end -}}
 {{end -}}
 
+{{with .HelpHeader}}{{.|Wrap 0}}
+{{end -}}
 {{if .Context.SelectedCommand -}}
 usage: {{.App.Name}} {{.Context.SelectedCommand}}{{template "FormatUsage" .Context.SelectedCommand}}
 {{if .Context.SelectedCommand.HelpLong}}{{.Context.SelectedCommand.HelpLong|Wrap 0}}
@@ -110,9 +115,9 @@ usage: {{.App.Name}} {{.Context.SelectedCommand}}{{template "FormatUsage" .Conte
 usage: {{.App.Name}}{{template "FormatUsage" .App}}
 {{end -}}
 {{if .Context.SelectedCommand -}}
-{{if .Context.Args -}}
-Args:
-{{.Context.Args|ArgsToTwoColumns|FormatTwoColumns}}
+{{range .Context.Args|ArgGroups -}}
+{{if .Name}}{{.Name}}{{else}}Args{{end}}:
+{{.Args|ArgsToTwoColumns|FormatTwoColumns}}
 {{end -}}
 {{if len .Context.SelectedCommand.Commands -}}
 Subcommands:
@@ -123,9 +128,9 @@ Commands:
 {{.App.Commands|CommandsToTwoColumns|FormatTwoColumns}}
 {{end -}}
 {{if .Context.SelectedCommand -}}
-{{if .Context.SelectedCommand.Flags|VisibleFlags -}}
-Flags:
-{{.Context.SelectedCommand.Flags|FlagsToTwoColumns|FormatTwoColumns}}
+{{range .Context.SelectedCommand.Flags|FlagGroups -}}
+{{if .Name}}{{.Name}}{{else}}Flags{{end}}:
+{{.Flags|FlagsToTwoColumns|FormatTwoColumns}}
 {{end -}}
 {{end -}}
 {{if GlobalFlags .Context|VisibleFlags -}}
@@ -136,6 +141,7 @@ Global Flags:
 Pass --help to see global flags applicable to this command.
 {{end -}}
 {{end -}}
+{{with .HelpFooter}}{{.|Wrap 0}}{{end -}}
 `
 
 // KingpinDefaultUsageTemplate is the default usage template as used by kingpin
@@ -147,7 +153,7 @@ var KingpinDefaultUsageTemplate = `{{define "FormatCommand" -}}
 {{define "FormatCommands" -}}
 {{range .FlattenedCommands -}}
 {{if not .Hidden -}}
-  {{.FullCommand}}{{if .Default}}*{{end}}{{template "FormatCommand" .}}
+  {{.FullCommand}}{{if .Default}}*{{end}}{{template "FormatCommand" .}}{{.Annotations}}
 {{.Help|Wrap 4}}
 {{end -}}
 {{end -}}
@@ -161,18 +167,20 @@ var KingpinDefaultUsageTemplate = `{{define "FormatCommand" -}}
 
 {{end -}}
 
+{{with .HelpHeader}}{{.|Wrap 0}}
+{{end -}}
 {{if .Context.SelectedCommand -}}
 usage: {{.App.Name}} {{.Context.SelectedCommand}}{{template "FormatUsage" .Context.SelectedCommand}}
 {{else -}}
 usage: {{.App.Name}}{{template "FormatUsage" .App}}
 {{end -}}
-{{if .Context.Flags|VisibleFlags -}}
-Flags:
-{{.Context.Flags|FlagsToTwoColumns|FormatTwoColumns}}
+{{range .Context.Flags|FlagGroups -}}
+{{if .Name}}{{.Name}}{{else}}Flags{{end}}:
+{{.Flags|FlagsToTwoColumns|FormatTwoColumns}}
 {{end -}}
-{{if .Context.Args -}}
-Args:
-{{.Context.Args|ArgsToTwoColumns|FormatTwoColumns}}
+{{range .Context.Args|ArgGroups -}}
+{{if .Name}}{{.Name}}{{else}}Args{{end}}:
+{{.Args|ArgsToTwoColumns|FormatTwoColumns}}
 {{end -}}
 {{if .Context.SelectedCommand -}}
 {{if len .Context.SelectedCommand.Commands -}}
@@ -183,6 +191,7 @@ Subcommands:
 Commands:
 {{template "FormatCommands" .App}}
 {{end -}}
+{{with .HelpFooter}}{{.|Wrap 0}}{{end -}}
 `
 
 // SeparateOptionalFlagsUsageTemplate is a usage template where command's optional flags are listed separately
@@ -194,7 +203,7 @@ var SeparateOptionalFlagsUsageTemplate = `{{define "FormatCommand" -}}
 {{define "FormatCommands" -}}
 {{range .FlattenedCommands -}}
 {{if not .Hidden -}}
-  {{.FullCommand}}{{if .Default}}*{{end}}{{template "FormatCommand" .}}
+  {{.FullCommand}}{{if .Default}}*{{end}}{{template "FormatCommand" .}}{{.Annotations}}
 {{.Help|Wrap 4}}
 {{end -}}
 {{end -}}
@@ -206,6 +215,8 @@ var SeparateOptionalFlagsUsageTemplate = `{{define "FormatCommand" -}}
 {{.Help|Wrap 0 -}}
 {{end -}}
 
+{{end -}}
+{{with .HelpHeader}}{{.|Wrap 0}}
 {{end -}}
 {{if .Context.SelectedCommand -}}
 usage: {{.App.Name}} {{.Context.SelectedCommand}}{{template "FormatUsage" .Context.SelectedCommand}}
@@ -221,9 +232,9 @@ Required flags:
 Optional flags:
 {{.Context.Flags|OptionalFlags|FlagsToTwoColumns|FormatTwoColumns}}
 {{end -}}
-{{if .Context.Args -}}
-Args:
-{{.Context.Args|ArgsToTwoColumns|FormatTwoColumns}}
+{{range .Context.Args|ArgGroups -}}
+{{if .Name}}{{.Name}}{{else}}Args{{end}}:
+{{.Args|ArgsToTwoColumns|FormatTwoColumns}}
 {{end -}}
 {{if .Context.SelectedCommand -}}
 Subcommands:
@@ -234,6 +245,7 @@ Subcommands:
 Commands:
 {{template "FormatCommands" .App}}
 {{end -}}
+{{with .HelpFooter}}{{.|Wrap 0}}{{end -}}
 `
 
 // CompactUsageTemplate is a usage template with compactly formatted commands.
@@ -259,18 +271,20 @@ var CompactUsageTemplate = `{{define "FormatCommand" -}}
 
 {{end -}}
 
+{{with .HelpHeader}}{{.|Wrap 0}}
+{{end -}}
 {{if .Context.SelectedCommand -}}
 usage: {{.App.Name}} {{.Context.SelectedCommand}}{{template "FormatUsage" .Context.SelectedCommand}}
 {{else -}}
 usage: {{.App.Name}}{{template "FormatUsage" .App}}
 {{end -}}
-{{if .Context.Flags|VisibleFlags -}}
-Flags:
-{{.Context.Flags|FlagsToTwoColumns|FormatTwoColumns}}
+{{range .Context.Flags|FlagGroups -}}
+{{if .Name}}{{.Name}}{{else}}Flags{{end}}:
+{{.Flags|FlagsToTwoColumns|FormatTwoColumns}}
 {{end -}}
-{{if .Context.Args -}}
-Args:
-{{.Context.Args|ArgsToTwoColumns|FormatTwoColumns}}
+{{range .Context.Args|ArgGroups -}}
+{{if .Name}}{{.Name}}{{else}}Args{{end}}:
+{{.Args|ArgsToTwoColumns|FormatTwoColumns}}
 {{end -}}
 {{if .Context.SelectedCommand -}}
 {{if .Context.SelectedCommand.Commands -}}
@@ -282,15 +296,20 @@ Commands:
 Commands:
 {{template "FormatCommandList" .App.Commands}}
 {{end -}}
+{{with .HelpFooter}}{{.|Wrap 0}}{{end -}}
 `
 
 // ManPageTemplate renders usage in unix man format
 var ManPageTemplate = `{{define "FormatFlags" -}}
+{{range .Flags|FlagGroups -}}
+{{if .Name -}}
+.PP
+\fI{{.Name}}\fR
+{{end -}}
 {{range .Flags -}}
-{{if not .Hidden -}}
 .TP
-\fB{{if .Short}}-{{.Short|Char}}, {{end}}--{{.Name}}{{if not .IsBoolFlag}}={{.FormatPlaceHolder}}{{end -}}\fR
-{{.Help}}
+\fB{{if .Short}}-{{.Short|Char}}, {{end}}{{range .ShortAliases}}-{{.|Char}}, {{end}}--{{.Name}}{{if not .IsBoolFlag}}={{.FormatPlaceHolder}}{{end -}}\fR
+{{if .HelpLong}}{{.HelpLong}}{{else}}{{.Help}}{{end}}{{.Annotations}}
 {{end -}}
 {{end -}}
 {{end -}}
@@ -300,14 +319,35 @@ var ManPageTemplate = `{{define "FormatFlags" -}}
 {{range .Args}}{{if not .Hidden}} {{if not .Required}}[{{end}}{{if .PlaceHolder}}{{.PlaceHolder}}{{else}}<{{.Name}}>{{end}}{{if .Value|IsCumulative}}...{{end}}{{if not .Required}}]{{end}}{{end}}{{end -}}
 {{end -}}
 
+{{define "FormatArgs" -}}
+{{range .Args|ArgGroups -}}
+{{if .Name -}}
+.PP
+\fI{{.Name}}\fR
+{{end -}}
+{{range .Args -}}
+{{if not .Hidden -}}
+.TP
+\fB{{if .PlaceHolder}}{{.PlaceHolder}}{{else}}<{{.Name}}>{{end}}\fR
+{{.Help}}
+{{end -}}
+{{end -}}
+{{end -}}
+{{end -}}
+
 {{define "FormatCommands" -}}
 {{range .FlattenedCommands -}}
 {{if not .Hidden -}}
 .SS
-\fB{{.FullCommand}}{{template "FormatCommand" . -}}\fR
+\fB{{.FullCommand}}{{template "FormatCommand" . -}}\fR{{.Annotations}}
 .PP
 {{.Help}}
+{{with .HelpFooter -}}
+.PP
+{{.}}
+{{end -}}
 {{template "FormatFlags" . -}}
+{{template "FormatArgs" . -}}
 {{end -}}
 {{end -}}
 {{end -}}
@@ -330,6 +370,334 @@ var ManPageTemplate = `{{define "FormatFlags" -}}
 .SH "COMMANDS"
 {{template "FormatCommands" .App -}}
 {{end -}}
+{{with .App.HelpFooter -}}
+.SH "NOTES"
+{{.}}
+{{end -}}
+`
+
+// MarkdownTemplate renders a markdown reference of the application and all its commands
+var MarkdownTemplate = `{{define "FormatFlags" -}}
+{{range .Flags -}}
+{{if not .Hidden -}}
+* ` + "`" + `{{if .Short}}-{{.Short|Char}}, {{end}}{{range .ShortAliases}}-{{.|Char}}, {{end}}--{{.Name}}{{if not .IsBoolFlag}}={{.FormatPlaceHolder}}{{end}}` + "`" + `{{with .LongHelpWithEnvar}} {{.}}{{end}}
+{{end -}}
+{{end -}}
+{{end -}}
+
+{{define "FormatArgs" -}}
+{{range .Args -}}
+{{if not .Hidden -}}
+* ` + "`" + `{{if .PlaceHolder}}{{.PlaceHolder}}{{else}}<{{.Name}}>{{end}}` + "`" + `{{with .HelpWithEnvar}} {{.}}{{end}}
+{{end -}}
+{{end -}}
+{{end -}}
+
+{{define "FormatCommand" -}}
+{{if .FlagSummary}} {{.FlagSummary}}{{end -}}
+{{range .Args}}{{if not .Hidden}} {{if not .Required}}[{{end}}{{if .PlaceHolder}}{{.PlaceHolder}}{{else}}<{{.Name}}>{{end}}{{if .Value|IsCumulative}}...{{end}}{{if not .Required}}]{{end}}{{end}}{{end -}}
+{{end -}}
+
+# {{.App.Name}}
+{{with .App.Help}}
+{{.}}
+{{end}}
+` + "```" + `
+{{.App.Name}}{{template "FormatCommand" .App}}{{if .App.Commands}} <command> [<args> ...]{{end}}
+` + "```" + `
+{{if .App.Flags|VisibleFlags}}
+## Flags
+
+{{template "FormatFlags" .App -}}
+{{end -}}
+{{if .App.Args|VisibleArgs}}
+## Arguments
+
+{{template "FormatArgs" .App -}}
+{{end -}}
+{{if .App.Commands}}
+## Commands
+{{range .App.FlattenedCommands -}}
+{{if and (not .Hidden) (ne .FullCommand "help")}}
+### {{.FullCommand}}{{.Annotations}}
+
+{{if .HelpLong}}{{.HelpLong}}{{else}}{{.Help}}{{end}}
+
+` + "```" + `
+{{$.App.Name}} {{.FullCommand}}{{template "FormatCommand" .}}
+` + "```" + `
+{{if .Flags|VisibleFlags}}
+#### Flags
+
+{{template "FormatFlags" . -}}
+{{end -}}
+{{if .Args|VisibleArgs}}
+#### Arguments
+
+{{template "FormatArgs" . -}}
+{{end -}}
+{{if .Examples}}
+#### Examples
+{{range .Examples}}
+{{with .Help}}{{.}}
+
+{{end}}` + "```" + `
+{{.Command}}
+` + "```" + `
+{{end -}}
+{{end -}}
+{{with .HelpFooter}}
+{{.}}
+{{end -}}
+{{end -}}
+{{end -}}
+{{end -}}
+{{with .App.HelpFooter}}
+## Notes
+
+{{.}}
+{{end -}}
+`
+
+// HTMLTemplate renders the complete application as a single HTML page with an
+// anchor per command, used by the docs command
+var HTMLTemplate = `{{define "FormatFlags" -}}
+<ul>
+{{range .Flags -}}
+{{if not .Hidden -}}
+<li><code>{{if .Short}}-{{.Short|Char}}, {{end}}{{range .ShortAliases}}-{{.|Char}}, {{end}}--{{.Name}}{{if not .IsBoolFlag}}={{.FormatPlaceHolder|HTML}}{{end}}</code>{{with .LongHelpWithEnvar}} {{.|HTML}}{{end}}</li>
+{{end -}}
+{{end -}}
+</ul>
+{{end -}}
+
+{{define "FormatArgs" -}}
+<ul>
+{{range .Args -}}
+{{if not .Hidden -}}
+<li><code>{{if .PlaceHolder}}{{.PlaceHolder|HTML}}{{else}}&lt;{{.Name}}&gt;{{end}}</code>{{with .HelpWithEnvar}} {{.|HTML}}{{end}}</li>
+{{end -}}
+{{end -}}
+</ul>
+{{end -}}
+
+{{define "FormatCommand" -}}
+{{if .FlagSummary}} {{.FlagSummary|HTML}}{{end -}}
+{{range .Args}}{{if not .Hidden}} {{if not .Required}}[{{end}}{{if .PlaceHolder}}{{.PlaceHolder|HTML}}{{else}}&lt;{{.Name}}&gt;{{end}}{{if .Value|IsCumulative}}...{{end}}{{if not .Required}}]{{end}}{{end}}{{end -}}
+{{end -}}
+
+<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.App.Name|HTML}}</title>
+</head>
+<body>
+<h1>{{.App.Name|HTML}}</h1>
+{{with .App.Help}}
+<p>{{.|HTML}}</p>
+{{end}}
+<pre>{{.App.Name|HTML}}{{template "FormatCommand" .App}}{{if .App.Commands}} &lt;command&gt; [&lt;args&gt; ...]{{end}}</pre>
+{{if .App.Flags|VisibleFlags}}
+<h2>Flags</h2>
+{{template "FormatFlags" .App -}}
+{{end -}}
+{{if .App.Args|VisibleArgs}}
+<h2>Arguments</h2>
+{{template "FormatArgs" .App -}}
+{{end -}}
+{{if .App.Commands}}
+<h2>Commands</h2>
+<ul>
+{{range .App.FlattenedCommands -}}
+{{if and (not .Hidden) (ne .FullCommand "help") -}}
+<li><a href="#{{.FullCommand|Anchor}}">{{.FullCommand|HTML}}</a> {{.Help|HTML}}</li>
+{{end -}}
+{{end -}}
+</ul>
+{{range .App.FlattenedCommands -}}
+{{if and (not .Hidden) (ne .FullCommand "help")}}
+<h3 id="{{.FullCommand|Anchor}}">{{.FullCommand|HTML}}{{.Annotations|HTML}}</h3>
+<p>{{if .HelpLong}}{{.HelpLong|HTML}}{{else}}{{.Help|HTML}}{{end}}</p>
+<pre>{{$.App.Name|HTML}} {{.FullCommand|HTML}}{{template "FormatCommand" .}}</pre>
+{{if .Flags|VisibleFlags}}
+<h4>Flags</h4>
+{{template "FormatFlags" . -}}
+{{end -}}
+{{if .Args|VisibleArgs}}
+<h4>Arguments</h4>
+{{template "FormatArgs" . -}}
+{{end -}}
+{{if .Examples}}
+<h4>Examples</h4>
+{{range .Examples -}}
+{{with .Help}}<p>{{.|HTML}}</p>
+{{end -}}
+<pre>{{.Command|HTML}}</pre>
+{{end -}}
+{{end -}}
+{{with .HelpFooter}}
+<p>{{.|HTML}}</p>
+{{end -}}
+{{end -}}
+{{end -}}
+{{end -}}
+{{with .App.HelpFooter}}
+<h2>Notes</h2>
+<p>{{.|HTML}}</p>
+{{end -}}
+</body>
+</html>
+`
+
+// OrgTemplate renders the complete application as an org-mode document, used
+// by the docs command
+var OrgTemplate = `{{define "FormatFlags" -}}
+{{range .Flags -}}
+{{if not .Hidden -}}
+- ~{{if .Short}}-{{.Short|Char}}, {{end}}{{range .ShortAliases}}-{{.|Char}}, {{end}}--{{.Name}}{{if not .IsBoolFlag}}={{.FormatPlaceHolder}}{{end}}~{{with .LongHelpWithEnvar}} {{.}}{{end}}
+{{end -}}
+{{end -}}
+{{end -}}
+
+{{define "FormatArgs" -}}
+{{range .Args -}}
+{{if not .Hidden -}}
+- ~{{if .PlaceHolder}}{{.PlaceHolder}}{{else}}<{{.Name}}>{{end}}~{{with .HelpWithEnvar}} {{.}}{{end}}
+{{end -}}
+{{end -}}
+{{end -}}
+
+{{define "FormatCommand" -}}
+{{if .FlagSummary}} {{.FlagSummary}}{{end -}}
+{{range .Args}}{{if not .Hidden}} {{if not .Required}}[{{end}}{{if .PlaceHolder}}{{.PlaceHolder}}{{else}}<{{.Name}}>{{end}}{{if .Value|IsCumulative}}...{{end}}{{if not .Required}}]{{end}}{{end}}{{end -}}
+{{end -}}
+
+#+TITLE: {{.App.Name}}
+
+* {{.App.Name}}
+{{with .App.Help}}
+{{.}}
+{{end}}
+#+begin_src sh
+{{.App.Name}}{{template "FormatCommand" .App}}{{if .App.Commands}} <command> [<args> ...]{{end}}
+#+end_src
+{{if .App.Flags|VisibleFlags}}
+** Flags
+
+{{template "FormatFlags" .App -}}
+{{end -}}
+{{if .App.Args|VisibleArgs}}
+** Arguments
+
+{{template "FormatArgs" .App -}}
+{{end -}}
+{{if .App.Commands}}
+** Commands
+{{range .App.FlattenedCommands -}}
+{{if and (not .Hidden) (ne .FullCommand "help")}}
+*** {{.FullCommand}}{{.Annotations}}
+:PROPERTIES:
+:CUSTOM_ID: {{.FullCommand|Anchor}}
+:END:
+
+{{if .HelpLong}}{{.HelpLong}}{{else}}{{.Help}}{{end}}
+
+#+begin_src sh
+{{$.App.Name}} {{.FullCommand}}{{template "FormatCommand" .}}
+#+end_src
+{{if .Flags|VisibleFlags}}
+**** Flags
+
+{{template "FormatFlags" . -}}
+{{end -}}
+{{if .Args|VisibleArgs}}
+**** Arguments
+
+{{template "FormatArgs" . -}}
+{{end -}}
+{{if .Examples}}
+**** Examples
+{{range .Examples}}
+{{with .Help}}{{.}}
+
+{{end}}#+begin_src sh
+{{.Command}}
+#+end_src
+{{end -}}
+{{end -}}
+{{with .HelpFooter}}
+{{.}}
+{{end -}}
+{{end -}}
+{{end -}}
+{{end -}}
+{{with .App.HelpFooter}}
+** Notes
+
+{{.}}
+{{end -}}
+`
+
+// AccessibleUsageTemplate is a usage template for screen readers, it avoids
+// column alignment and symbols and describes each flag, argument and command
+// in a single labelled line
+var AccessibleUsageTemplate = `{{define "DescribeArgs" -}}
+{{if .|VisibleArgs}}
+Arguments:
+{{range .|VisibleArgs}}{{.|DescribeArg}}
+{{end -}}
+{{end -}}
+{{end -}}
+
+{{define "DescribeCommands" -}}
+{{if .}}
+Commands:
+{{range .}}{{if not .Hidden}}{{.|DescribeCommand}}
+{{end}}{{end -}}
+{{end -}}
+{{end -}}
+
+{{define "DescribeFlags" -}}
+{{range .|VisibleFlags}}{{.|DescribeFlag}}
+{{end -}}
+{{end -}}
+
+{{with .HelpHeader}}{{.}}
+
+{{end -}}
+{{with .Context.SelectedCommand -}}
+Usage: {{$.App.Name}} {{.FullCommand}}{{if .Commands}} followed by a command{{end}}
+{{if .HelpLong}}
+{{.HelpLong}}
+{{else if .Help}}
+{{.Help}}
+{{end -}}
+{{template "DescribeArgs" $.Context.Args -}}
+{{template "DescribeCommands" .Commands -}}
+{{if .Flags|VisibleFlags}}
+Flags:
+{{template "DescribeFlags" .Flags -}}
+{{end -}}
+{{if GlobalFlags $.Context|VisibleFlags}}
+Global flags:
+{{template "DescribeFlags" GlobalFlags $.Context -}}
+{{end -}}
+{{else -}}
+Usage: {{.App.Name}}{{if .App.Commands}} followed by a command{{end}}
+{{with .App.Help}}
+{{.}}
+{{end -}}
+{{template "DescribeArgs" .App.Args -}}
+{{template "DescribeCommands" .App.Commands -}}
+{{if GlobalFlags .Context|VisibleFlags}}
+Flags:
+{{template "DescribeFlags" GlobalFlags .Context -}}
+{{end -}}
+{{end -}}
+{{with .HelpFooter}}
+{{.}}
+{{end -}}
 `
 
 // LongHelpTemplate is a usage template for --help-long
@@ -341,9 +709,9 @@ var LongHelpTemplate = `{{define "FormatCommand" -}}
 {{define "FormatCommands" -}}
 {{range .FlattenedCommands -}}
 {{if not .Hidden -}}
-  {{.FullCommand}}{{template "FormatCommand" .}}
+  {{.FullCommand}}{{template "FormatCommand" .}}{{.Annotations}}
 {{.Help|Wrap 4}}
-{{with .Flags|FlagsToTwoColumns}}{{FormatTwoColumnsWithIndent . 4 2}}{{end}}
+{{with .Flags|LongFlagsToTwoColumns}}{{FormatTwoColumnsWithIndent . 4 2}}{{end}}
 {{end -}}
 {{end -}}
 {{end -}}
@@ -356,28 +724,52 @@ var LongHelpTemplate = `{{define "FormatCommand" -}}
 
 {{end -}}
 
+{{with .HelpHeader}}{{.|Wrap 0}}
+{{end -}}
 usage: {{.App.Name}}{{template "FormatUsage" .App}}
-{{if .Context.Flags|VisibleFlags -}}
-Flags:
-{{.Context.Flags|FlagsToTwoColumns|FormatTwoColumns}}
+{{range .Context.Flags|FlagGroups -}}
+{{if .Name}}{{.Name}}{{else}}Flags{{end}}:
+{{.Flags|LongFlagsToTwoColumns|FormatTwoColumns}}
 {{end -}}
-{{if .Context.Args -}}
-Args:
-{{.Context.Args|ArgsToTwoColumns|FormatTwoColumns}}
+{{range .Context.Args|ArgGroups -}}
+{{if .Name}}{{.Name}}{{else}}Args{{end}}:
+{{.Args|ArgsToTwoColumns|FormatTwoColumns}}
 {{end -}}
 {{if .App.Commands -}}
 Commands:
 {{template "FormatCommands" .App}}
 {{end -}}
+{{with .HelpFooter}}{{.|Wrap 0}}{{end -}}
 `
 
 var BashCompletionTemplate = `
 _{{.App.Name}}_bash_autocomplete() {
-    local cur prev opts base
+    local cur prev opts base exts ext
     COMPREPLY=()
     cur="${COMP_WORDS[COMP_CWORD]}"
     opts=$( ${COMP_WORDS[0]} --completion-bash "${COMP_WORDS[@]:1:$COMP_CWORD}" )
-    COMPREPLY=( $(compgen -W "${opts}" -- ${cur}) )
+    case "${opts}" in
+        __fisk_complete_dirs*)
+            compopt -o filenames 2>/dev/null
+            COMPREPLY=( $(compgen -d -- "${cur}") )
+            ;;
+        __fisk_complete_files:*)
+            compopt -o filenames 2>/dev/null
+            exts="${opts%%$'\n'*}"
+            COMPREPLY=( $(compgen -d -- "${cur}") )
+            exts="${exts#__fisk_complete_files:}"
+            for ext in ${exts//,/ }; do
+                COMPREPLY+=( $(compgen -f -X "!*.${ext}" -- "${cur}") )
+            done
+            ;;
+        __fisk_complete_files*)
+            compopt -o filenames 2>/dev/null
+            COMPREPLY=( $(compgen -f -- "${cur}") )
+            ;;
+        *)
+            COMPREPLY=( $(compgen -W "${opts}" -- ${cur}) )
+            ;;
+    esac
     return 0
 }
 complete -F _{{.App.Name}}_bash_autocomplete -o default {{.App.Name}}
@@ -385,17 +777,153 @@ complete -F _{{.App.Name}}_bash_autocomplete -o default {{.App.Name}}
 `
 
 var ZshCompletionTemplate = `#compdef {{.App.Name}}
+{{range ZshCompletions}}
+{{.Function}}() {
+    local curcontext="$curcontext" context state state_descr line
+    typeset -A opt_args
+{{- if .Root}}
+    local -a {{.Function}}_words=("${words[@]}")
+    local {{.Function}}_current=$CURRENT
+{{- end}}
+
+    _arguments -C -S{{range .Specs}} \
+        {{.}}{{end}}
+{{- if .Commands}}
+
+    case $state in
+        command)
+            local -a commands=(
+{{- range .Commands}}
+                {{.Describe}}
+{{- end}}
+            )
+            _describe -t commands command commands
+            ;;
+        args)
+            case $line[1] in
+{{- range .Commands}}
+                {{.Names}}) {{.Function}} ;;
+{{- end}}
+            esac
+            ;;
+    esac
+{{- end}}
+}
+{{end}}
+{{- with $root := (index ZshCompletions 0).Function}}
+{{$root}}_hints() {
+    local -a hints=(${(f)"$(${ {{- $root}}_words[1]} --completion-bash "${(@){{$root}}_words[2,${{$root}}_current]}" 2>/dev/null)"})
+    compadd -a hints
+}
+
+if [[ "$(basename -- ${(%):-%x})" != "_{{$.App.Name}}" ]]; then
+    compdef {{$root}} {{$.App.Name}}
+fi
+{{- end}}
+`
+
+// FishCompletionTemplate is a fish completion script, candidates come from
+// --completion-bash and commands are described using their help
+var FishCompletionTemplate = `function __fish_{{.App.Name}}_describe
+    switch "$argv"
+{{- range .App.Commands|AllCommands}}
+    case {{.FullCommand|FishQuote}}
+        echo {{.Help|FirstLine|FishQuote}}
+{{- end}}
+    case '*'
+        return 1
+    end
+end
+
+function __fish_{{.App.Name}}_complete
+    set -l words (commandline -opc)
+    set -l current (commandline -ct)
+
+    set -l path
+    for word in $words[2..-1]
+        if __fish_{{.App.Name}}_describe $path $word >/dev/null
+            set -a path $word
+        end
+    end
+
+    set -l found 0
+    for candidate in ($words[1] --completion-bash $words[2..-1] $current)
+        set found 1
+        switch $candidate
+            case __fisk_complete_dirs
+                __fish_complete_directories $current
+            case __fisk_complete_files
+                __fish_complete_path $current
+            case '__fisk_complete_files:*'
+                __fish_complete_directories $current
+                for ext in (string split , (string replace __fisk_complete_files: '' $candidate))
+                    __fish_complete_suffix .$ext
+                end
+            case '*'
+                if set -l help (__fish_{{.App.Name}}_describe $path $candidate)
+                    printf '%s\t%s\n' $candidate $help
+                else
+                    echo $candidate
+                end
+        end
+    end
+
+    if test $found -eq 0; and not string match -q -- '-*' $current
+        __fish_complete_path $current
+    end
+end
+
+complete -c {{.App.Name}} -f -a '(__fish_{{.App.Name}}_complete)'
+`
 
-_{{.App.Name}}() {
-    local matches=($(${words[1]} --completion-bash "${(@)words[2,$CURRENT]}"))
-    compadd -a matches
+// BashCommandNotFoundTemplate is a command_not_found_handle for bash that
+// suggests running the application when one of its commands is typed on its
+// own, an existing handler is called after the suggestion
+var BashCommandNotFoundTemplate = `{{- $words := CommandPattern .App.Commands -}}
+if ! declare -f _{{.App.Name}}_next_command_not_found_handle >/dev/null && declare -f command_not_found_handle >/dev/null; then
+    eval "_{{.App.Name}}_next_$(declare -f command_not_found_handle)"
+fi
 
-    if [[ $compstate[nmatches] -eq 0 && $words[$CURRENT] != -* ]]; then
-        _files
+command_not_found_handle() {
+{{- if $words}}
+    case "$1" in
+    {{$words}})
+        printf '%s: command not found, did you mean "{{.App.Name}} %s"?\n' "$1" "$*" >&2
+        ;;
+    esac
+{{- end}}
+
+    if declare -f _{{.App.Name}}_next_command_not_found_handle >/dev/null; then
+        _{{.App.Name}}_next_command_not_found_handle "$@"
+        return $?
     fi
+
+    printf 'bash: %s: command not found\n' "$1" >&2
+    return 127
 }
+`
 
-if [[ "$(basename -- ${(%):-%x})" != "_{{.App.Name}}" ]]; then
-    compdef _{{.App.Name}} {{.App.Name}}
+// ZshCommandNotFoundTemplate is the zsh version of BashCommandNotFoundTemplate
+var ZshCommandNotFoundTemplate = `{{- $words := CommandPattern .App.Commands -}}
+if (( ! $+functions[_{{.App.Name}}_next_command_not_found_handler] && $+functions[command_not_found_handler] )); then
+    functions[_{{.App.Name}}_next_command_not_found_handler]=$functions[command_not_found_handler]
 fi
+
+command_not_found_handler() {
+{{- if $words}}
+    case "$1" in
+    {{$words}})
+        printf '%s: command not found, did you mean "{{.App.Name}} %s"?\n' "$1" "$*" >&2
+        ;;
+    esac
+{{- end}}
+
+    if (( $+functions[_{{.App.Name}}_next_command_not_found_handler] )); then
+        _{{.App.Name}}_next_command_not_found_handler "$@"
+        return $?
+    fi
+
+    print -u2 "zsh: command not found: $1"
+    return 127
+}
 `
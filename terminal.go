@@ -0,0 +1,96 @@
+package fisk
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+)
+
+// Terminal is the terminal interactive features like prompts, the wizard and
+// password entry interact with and help is rendered for.
+//
+// The default uses stdin and the usage writer, tests can supply their own
+// using Application.Terminal(), see the fisktest package.
+type Terminal interface {
+	// IsTTY determines if the user can be prompted
+	IsTTY() bool
+	// Width is the width of the terminal in columns, 0 when unknown
+	Width() int
+	// ColorEnabled determines if output may be colored
+	ColorEnabled() bool
+	// Input is where answers to prompts are read from
+	Input() io.Reader
+	// ReadPassword reads a line of input without echoing it
+	ReadPassword() (string, error)
+}
+
+// Terminal sets the terminal used for prompts and to render help
+func (a *Application) Terminal(terminal Terminal) *Application {
+	a.terminal = terminal
+	return a
+}
+
+// term is the configured terminal or one using stdin and the usage writer,
+// the color decision is overridden when --color was given
+func (a *Application) term() Terminal {
+	var t Terminal = &stdTerminal{w: a.usageWriter}
+	if a.terminal != nil {
+		t = a.terminal
+	}
+
+	if a.colorSet {
+		return &colorTerminal{Terminal: t, enabled: a.color}
+	}
+
+	return t
+}
+
+// termFor is the terminal of the application that owns context
+func termFor(context *ParseContext) Terminal {
+	if context != nil && context.app != nil {
+		return context.app.term()
+	}
+
+	return &stdTerminal{w: os.Stderr}
+}
+
+type stdTerminal struct {
+	w io.Writer
+}
+
+func (t *stdTerminal) IsTTY() bool {
+	return isTerminal(int(os.Stdin.Fd()))
+}
+
+func (t *stdTerminal) Width() int {
+	return guessWidth(t.w)
+}
+
+func (t *stdTerminal) ColorEnabled() bool {
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+
+	f, ok := t.w.(*os.File)
+	return ok && isTerminal(int(f.Fd()))
+}
+
+func (t *stdTerminal) Input() io.Reader {
+	return os.Stdin
+}
+
+func (t *stdTerminal) ReadPassword() (string, error) {
+	restore, err := disableEcho(int(os.Stdin.Fd()))
+	if err != nil {
+		return "", err
+	}
+	defer restore()
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
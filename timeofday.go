@@ -0,0 +1,78 @@
+package fisk
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+var timeOfDayLayouts = []string{"15:04", "15:04:05", "3pm", "3:04pm", "3:04:05pm"}
+
+// TimeOfDay is a wall clock time without a date, see TimeOfDay()
+type TimeOfDay struct {
+	Hour   int
+	Minute int
+	Second int
+}
+
+// ParseTimeOfDay parses times like 14:30, 14:30:05, 2pm and 2:30pm
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	v := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(s), " ", ""))
+
+	for _, layout := range timeOfDayLayouts {
+		t, err := time.Parse(layout, v)
+		if err == nil {
+			return TimeOfDay{Hour: t.Hour(), Minute: t.Minute(), Second: t.Second()}, nil
+		}
+	}
+
+	return TimeOfDay{}, fmt.Errorf("invalid time of day %q, expected a time like 14:30, 14:30:05 or 2:30pm", s)
+}
+
+// On is the time of day on the date of t, in the location of t
+func (t TimeOfDay) On(date time.Time) time.Time {
+	y, m, d := date.Date()
+	return time.Date(y, m, d, t.Hour, t.Minute, t.Second, 0, date.Location())
+}
+
+// String formats the time as 14:30, or 14:30:05 when it has seconds
+func (t TimeOfDay) String() string {
+	if t.Second != 0 {
+		return fmt.Sprintf("%02d:%02d:%02d", t.Hour, t.Minute, t.Second)
+	}
+
+	return fmt.Sprintf("%02d:%02d", t.Hour, t.Minute)
+}
+
+// -- TimeOfDay Value
+type timeOfDayValue TimeOfDay
+
+func (t *timeOfDayValue) Set(s string) error {
+	v, err := ParseTimeOfDay(s)
+	if err != nil {
+		return err
+	}
+
+	*t = timeOfDayValue(v)
+	return nil
+}
+
+func (t *timeOfDayValue) Get() interface{} { return TimeOfDay(*t) }
+
+func (t *timeOfDayValue) String() string { return TimeOfDay(*t).String() }
+
+func (t *timeOfDayValue) Reset() { *t = timeOfDayValue{} }
+
+func (t *timeOfDayValue) PlaceHolder() string { return "HH:MM" }
+
+// TimeOfDay parses a wall clock time like 14:30, 14:30:05 or 2:30pm
+func (p *parserMixin) TimeOfDay() (target *TimeOfDay) {
+	target = new(TimeOfDay)
+	p.TimeOfDayVar(target)
+	return
+}
+
+// TimeOfDayVar parses a wall clock time like 14:30, 14:30:05 or 2:30pm
+func (p *parserMixin) TimeOfDayVar(target *TimeOfDay) {
+	p.SetValue((*timeOfDayValue)(target))
+}
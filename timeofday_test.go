@@ -0,0 +1,55 @@
+package fisk
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeOfDay(t *testing.T) {
+	cases := []struct {
+		s   string
+		tod TimeOfDay
+		err bool
+	}{
+		{"14:30", TimeOfDay{14, 30, 0}, false},
+		{"9:05", TimeOfDay{9, 5, 0}, false},
+		{"14:30:05", TimeOfDay{14, 30, 5}, false},
+		{"2:30pm", TimeOfDay{14, 30, 0}, false},
+		{"2:30 PM", TimeOfDay{14, 30, 0}, false},
+		{"12am", TimeOfDay{0, 0, 0}, false},
+		{"25:00", TimeOfDay{}, true},
+		{"14pm", TimeOfDay{}, true},
+		{"noon", TimeOfDay{}, true},
+	}
+
+	for _, c := range cases {
+		tod, err := ParseTimeOfDay(c.s)
+		if c.err {
+			assert.Error(t, err, c.s)
+		} else {
+			assert.NoError(t, err, c.s)
+		}
+		assert.Equal(t, c.tod, tod, c.s)
+	}
+
+	assert.Equal(t, "14:30", TimeOfDay{14, 30, 0}.String())
+	assert.Equal(t, "14:30:05", TimeOfDay{14, 30, 5}.String())
+	assert.Equal(t, time.Date(2024, 1, 2, 14, 30, 0, 0, time.UTC), TimeOfDay{14, 30, 0}.On(time.Date(2024, 1, 2, 8, 0, 0, 0, time.UTC)))
+
+	app := newTestApp()
+	at := app.Flag("at", "Time to run").TimeOfDay()
+	_, err := app.Parse([]string{"--at", "6:15pm"})
+	assert.NoError(t, err)
+	assert.Equal(t, TimeOfDay{18, 15, 0}, *at)
+
+	_, err = app.Parse([]string{"--at", "later"})
+	assert.EqualError(t, err, `invalid time of day "later", expected a time like 14:30, 14:30:05 or 2:30pm`)
+
+	w := &bytes.Buffer{}
+	app.UsageWriter(w)
+	app.Usage(nil)
+	assert.Contains(t, w.String(), "--at=HH:MM")
+}
@@ -0,0 +1,130 @@
+package fisk
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+var timeRangeLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"}
+
+// TimeRange is a window of time, see TimeRange()
+type TimeRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// ParseTimeRange parses ranges like 2024-01-01..2024-02-01 or -2h..now
+//
+// Each end is "now", a duration relative to now like -2h or -1w as understood
+// by ParseDuration(), a date like 2024-01-01, a date and time like
+// 2024-01-01T10:00 or a RFC3339 timestamp. Dates without a time zone are in
+// the local time zone.
+func ParseTimeRange(s string) (TimeRange, error) {
+	return parseTimeRange(s, time.Now())
+}
+
+func parseTimeRange(s string, now time.Time) (TimeRange, error) {
+	start, end, ok := strings.Cut(strings.TrimSpace(s), "..")
+	if !ok {
+		return TimeRange{}, fmt.Errorf("invalid time range %q, expected a range like 2024-01-01..2024-02-01 or -2h..now", s)
+	}
+
+	var (
+		r   TimeRange
+		err error
+	)
+
+	r.Start, err = parseTimeRangeEnd(start, now)
+	if err != nil {
+		return TimeRange{}, fmt.Errorf("invalid start of time range: %w", err)
+	}
+
+	r.End, err = parseTimeRangeEnd(end, now)
+	if err != nil {
+		return TimeRange{}, fmt.Errorf("invalid end of time range: %w", err)
+	}
+
+	if r.End.Before(r.Start) {
+		return TimeRange{}, fmt.Errorf("invalid time range %q, end is before start", s)
+	}
+
+	return r, nil
+}
+
+func parseTimeRangeEnd(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+
+	switch {
+	case s == "":
+		return time.Time{}, fmt.Errorf("no time given")
+	case s == "now":
+		return now, nil
+	case strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+"):
+		d, err := ParseDuration(s)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return now.Add(d), nil
+	}
+
+	for _, layout := range timeRangeLayouts {
+		t, err := time.ParseInLocation(layout, s, now.Location())
+		if err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unknown time %q", s)
+}
+
+// Duration is the length of the range
+func (r TimeRange) Duration() time.Duration {
+	return r.End.Sub(r.Start)
+}
+
+// Contains determines if t is in the range, including the start and end
+func (r TimeRange) Contains(t time.Time) bool {
+	return !t.Before(r.Start) && !t.After(r.End)
+}
+
+func (r TimeRange) String() string {
+	if r.Start.IsZero() && r.End.IsZero() {
+		return ""
+	}
+
+	return r.Start.Format(time.RFC3339) + ".." + r.End.Format(time.RFC3339)
+}
+
+// -- TimeRange Value
+type timeRangeValue TimeRange
+
+func (r *timeRangeValue) Set(s string) error {
+	v, err := ParseTimeRange(s)
+	if err != nil {
+		return err
+	}
+
+	*r = timeRangeValue(v)
+	return nil
+}
+
+func (r *timeRangeValue) Get() interface{} { return TimeRange(*r) }
+
+func (r *timeRangeValue) String() string { return TimeRange(*r).String() }
+
+func (r *timeRangeValue) Reset() { *r = timeRangeValue{} }
+
+func (r *timeRangeValue) PlaceHolder() string { return "START..END" }
+
+// TimeRange parses a window of time like 2024-01-01..2024-02-01 or -2h..now, see ParseTimeRange()
+func (p *parserMixin) TimeRange() (target *TimeRange) {
+	target = new(TimeRange)
+	p.TimeRangeVar(target)
+	return
+}
+
+// TimeRangeVar parses a window of time like 2024-01-01..2024-02-01 or -2h..now, see ParseTimeRange()
+func (p *parserMixin) TimeRangeVar(target *TimeRange) {
+	p.SetValue((*timeRangeValue)(target))
+}
@@ -0,0 +1,45 @@
+package fisk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeRange(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	r, err := parseTimeRange("2024-01-01..2024-02-01", now)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), r.Start)
+	assert.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), r.End)
+	assert.Equal(t, 31*24*time.Hour, r.Duration())
+
+	r, err = parseTimeRange("-2h..now", now)
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(-2*time.Hour), r.Start)
+	assert.Equal(t, now, r.End)
+	assert.True(t, r.Contains(now.Add(-time.Hour)))
+	assert.False(t, r.Contains(now.Add(time.Second)))
+
+	r, err = parseTimeRange("2024-03-01T08:30..+1d", now)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC), r.Start)
+	assert.Equal(t, now.Add(24*time.Hour), r.End)
+
+	_, err = parseTimeRange("now", now)
+	assert.EqualError(t, err, `invalid time range "now", expected a range like 2024-01-01..2024-02-01 or -2h..now`)
+
+	_, err = parseTimeRange("now..-1d", now)
+	assert.EqualError(t, err, `invalid time range "now..-1d", end is before start`)
+
+	_, err = parseTimeRange("yesterday..now", now)
+	assert.EqualError(t, err, `invalid start of time range: unknown time "yesterday"`)
+
+	app := newTestApp()
+	window := app.Flag("window", "").TimeRange()
+	_, err = app.Parse([]string{"--window=-1w..now"})
+	assert.NoError(t, err)
+	assert.Equal(t, 7*24*time.Hour, window.Duration().Round(time.Second))
+}
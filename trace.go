@@ -0,0 +1,109 @@
+package fisk
+
+// ParseEventKind is the kind of a ParseEvent
+type ParseEventKind int
+
+const (
+	// ParseEventToken is emitted for every token produced by the tokenizer
+	ParseEventToken ParseEventKind = iota
+	// ParseEventDefault is emitted when a default or environment value is applied to a flag or argument
+	ParseEventDefault
+	// ParseEventAction is emitted after an action or pre action was run
+	ParseEventAction
+)
+
+func (k ParseEventKind) String() string {
+	switch k {
+	case ParseEventToken:
+		return "token"
+	case ParseEventDefault:
+		return "default"
+	case ParseEventAction:
+		return "action"
+	}
+	return "?"
+}
+
+// ParseEvent describes a single step taken while parsing and executing a command line
+type ParseEvent struct {
+	Kind ParseEventKind
+	// Token is the token that was produced for ParseEventToken, values of Secret() flags are masked
+	Token *Token
+	// Clause is the *FlagClause or *ArgClause a default was applied to or the *Application, *CmdClause, *FlagClause or *ArgClause owning an action
+	Clause interface{}
+	// Value is the default value that was applied, masked for Secret() flags and arguments
+	Value string
+	// Source is where a default value came from, either "default" or "environment $VAR"
+	Source string
+	// PreAction indicates the action was a PreAction
+	PreAction bool
+	// Err is the error returned by an action
+	Err error
+}
+
+// TraceParse registers a function that receives a ParseEvent for every token
+// consumed, default applied and action run
+func (a *Application) TraceParse(tracer func(ev ParseEvent)) *Application {
+	a.tracers = append(a.tracers, tracer)
+	return a
+}
+
+// tracing determines if events should be emitted, secondary parses done
+// to render help or completions are not traced
+func (p *ParseContext) tracing() bool {
+	return p.app != nil && !p.ignoreDefault && len(p.app.tracers) > 0
+}
+
+func (p *ParseContext) trace(ev ParseEvent) {
+	if !p.tracing() {
+		return
+	}
+
+	for _, tracer := range p.app.tracers {
+		tracer(ev)
+	}
+}
+
+// tokenProduced records and traces a token, the values of secret flags are masked
+func (p *ParseContext) tokenProduced(token *Token) {
+	if token.Type == TokenArg && p.lastToken != nil && p.isSecretFlagToken(p.lastToken) {
+		token = &Token{Index: token.Index, Type: token.Type, Value: redactedValue}
+	}
+	p.lastToken = token
+
+	if p.recordTokens {
+		p.tokens = append(p.tokens, token)
+	}
+
+	p.trace(ParseEvent{Kind: ParseEventToken, Token: token})
+}
+
+func (p *ParseContext) isSecretFlagToken(token *Token) bool {
+	var flag *FlagClause
+
+	switch token.Type {
+	case TokenLong:
+		flag = p.flags.long[token.Value]
+	case TokenShort:
+		flag = p.flags.short[token.Value]
+	}
+
+	return flag != nil && flag.secret && !isBoolFlag(flag.value)
+}
+
+func (p *ParseContext) traceDefault(clause interface{}, envar string, hasEnvar bool, hasDefault bool, value Value, secret bool) {
+	if !p.tracing() {
+		return
+	}
+
+	source := defaultSource(envar, hasEnvar, hasDefault)
+	if source == "" {
+		return
+	}
+
+	p.trace(ParseEvent{Kind: ParseEventDefault, Clause: clause, Value: debugValue(value, secret), Source: source})
+}
+
+func (p *ParseContext) traceAction(owner interface{}, pre bool, err error) {
+	p.trace(ParseEvent{Kind: ParseEventAction, Clause: owner, PreAction: pre, Err: err})
+}
@@ -0,0 +1,44 @@
+package fisk
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTraceParse(t *testing.T) {
+	os.Setenv("TEST_TRACE_REPLICAS", "3")
+	defer os.Unsetenv("TEST_TRACE_REPLICAS")
+
+	var events []string
+	app := newTestApp().TraceParse(func(ev ParseEvent) {
+		switch ev.Kind {
+		case ParseEventToken:
+			events = append(events, fmt.Sprintf("token %s %s", ev.Token.Type, ev.Token.Value))
+		case ParseEventDefault:
+			events = append(events, fmt.Sprintf("default --%s=%s %s", ev.Clause.(*FlagClause).name, ev.Value, ev.Source))
+		case ParseEventAction:
+			events = append(events, fmt.Sprintf("action %s pre=%t err=%v", ev.Clause.(*CmdClause).name, ev.PreAction, ev.Err))
+		}
+	})
+
+	add := app.Command("add", "").
+		PreAction(func(*ParseContext) error { return nil }).
+		Action(func(*ParseContext) error { return fmt.Errorf("failed") })
+	add.Flag("token", "").Secret().String()
+	add.Flag("replicas", "").Envar("TEST_TRACE_REPLICAS").Int()
+
+	_, err := app.Parse([]string{"add", "--token=s3cret"})
+	assert.Error(t, err)
+
+	assert.Equal(t, []string{
+		"token argument add",
+		"token long flag token",
+		"token argument *****",
+		"default --replicas=3 environment $TEST_TRACE_REPLICAS",
+		"action add pre=true err=<nil>",
+		"action add pre=false err=failed",
+	}, events)
+}
@@ -6,7 +6,10 @@ import (
 	"fmt"
 	"go/doc"
 	"go/doc/comment"
+	"html"
 	"io"
+	"os"
+	"strconv"
 	"strings"
 	"text/template"
 )
@@ -60,7 +63,7 @@ func (a *Application) Usage(args []string) {
 	context, err := a.parseContext(true, args)
 	a.FatalIfError(err, "")
 
-	if err := a.UsageForContextWithTemplate(context, 2, a.usageTemplate); err != nil {
+	if err := a.UsageForContextWithTemplate(context, 2, styledTemplate(a.usageTemplate)); err != nil {
 		panic(err)
 	}
 }
@@ -96,7 +99,11 @@ func formatFlag(haveShort bool, flag *FlagModel) string {
 	}
 
 	if flag.Short != 0 {
-		flagString += fmt.Sprintf("-%c, --%s", flag.Short, flagName)
+		flagString += fmt.Sprintf("-%c, ", flag.Short)
+		for _, short := range flag.ShortAliases {
+			flagString += fmt.Sprintf("-%c, ", short)
+		}
+		flagString += "--" + flagName
 	} else {
 		if haveShort {
 			flagString += fmt.Sprintf("    --%s", flagName)
@@ -124,17 +131,168 @@ type templateContext struct {
 	HelpFlagIsSet bool
 	Width         int
 	Context       *templateParseContext
+	// HelpHeader is shown before the usage, see Application.HelpHeader()
+	HelpHeader string
+	// HelpFooter is the footer of the selected command followed by that of the application
+	HelpFooter string
 }
 
 // UsageForContext displays usage information from a ParseContext (obtained from
 // Application.ParseContext() or Action(f) callbacks).
 func (a *Application) UsageForContext(context *ParseContext) error {
-	return a.UsageForContextWithTemplate(context, 2, a.usageTemplate)
+	return a.UsageForContextWithTemplate(context, 2, styledTemplate(a.usageTemplate))
+}
+
+// argSection is a group of arguments shown under a heading in help
+type argSection struct {
+	// Name is the heading, empty for arguments without a group
+	Name string
+	Args []*ArgModel
+}
+
+// argGroups groups arguments by Group() in the order the groups are first seen
+func argGroups(args []*ArgModel) []*argSection {
+	var sections []*argSection
+	byName := map[string]*argSection{}
+
+	for _, arg := range args {
+		section, ok := byName[arg.Group]
+		if !ok {
+			section = &argSection{Name: arg.Group}
+			byName[arg.Group] = section
+			sections = append(sections, section)
+		}
+		section.Args = append(section.Args, arg)
+	}
+
+	return sections
+}
+
+// flagSection is a group of flags shown under a heading in help
+type flagSection struct {
+	// Name is the heading, empty for flags without a group
+	Name  string
+	Flags []*FlagModel
+}
+
+// flagGroups groups visible flags by Group(), flags without a group come first
+// followed by the groups in the order they are first seen
+func flagGroups(flags []*FlagModel) []*flagSection {
+	ungrouped := &flagSection{}
+	sections := []*flagSection{ungrouped}
+	byName := map[string]*flagSection{"": ungrouped}
+
+	for _, flag := range flags {
+		if flag.Hidden {
+			continue
+		}
+
+		section, ok := byName[flag.Group]
+		if !ok {
+			section = &flagSection{Name: flag.Group}
+			byName[flag.Group] = section
+			sections = append(sections, section)
+		}
+		section.Flags = append(section.Flags, flag)
+	}
+
+	if len(ungrouped.Flags) == 0 {
+		sections = sections[1:]
+	}
+
+	return sections
+}
+
+// allCommands are the visible commands and their subcommands, depth first
+func allCommands(cmds []*CmdModel) []*CmdModel {
+	var all []*CmdModel
+	for _, cmd := range cmds {
+		if cmd.Hidden {
+			continue
+		}
+
+		all = append(all, cmd)
+		if cmd.CmdGroupModel != nil {
+			all = append(all, allCommands(cmd.Commands)...)
+		}
+	}
+
+	return all
+}
+
+// fishQuote single quotes s for fish
+func fishQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
+
+// docAnchor is the anchor of a command in generated documentation
+func docAnchor(name string) string {
+	return "command-" + strings.Join(strings.Fields(name), "-")
+}
+
+// commandPattern is a shell case pattern matching the names and aliases of
+// the visible commands other than help
+func commandPattern(cmds []*CmdModel) string {
+	var words []string
+	for _, cmd := range cmds {
+		if cmd.Hidden || cmd.Name == "help" {
+			continue
+		}
+
+		words = append(words, cmd.Name)
+		words = append(words, cmd.Aliases...)
+	}
+
+	return strings.Join(words, "|")
+}
+
+// flagsToTwoColumns formats visible flags and their help, the long help is used when long is set
+func flagsToTwoColumns(f []*FlagModel, long bool) [][2]string {
+	rows := [][2]string{}
+	haveShort := false
+	for _, flag := range f {
+		if flag.Short != 0 {
+			haveShort = true
+			break
+		}
+	}
+	for _, flag := range f {
+		if flag.Hidden {
+			continue
+		}
+
+		help := flag.HelpWithEnvar()
+		if long {
+			help = flag.LongHelpWithEnvar()
+		}
+		rows = append(rows, [2]string{formatFlag(haveShort, flag), help})
+	}
+	return rows
+}
+
+// usageWidth is the width to render usage at, see TerminalWidth()
+func (a *Application) usageWidth() int {
+	if a.terminalWidth > 0 {
+		return a.terminalWidth
+	}
+
+	// COLUMNS complies with http://pubs.opengroup.org/onlinepubs/009604499/basedefs/xbd_chap08.html
+	for _, env := range []string{"FISK_WIDTH", "COLUMNS"} {
+		if width, err := strconv.Atoi(os.Getenv(env)); err == nil && width > 0 {
+			return width
+		}
+	}
+
+	if width := a.term().Width(); width > 0 {
+		return width
+	}
+
+	return 80
 }
 
 // UsageForContextWithTemplate is the base usage function. You generally don't need to use this.
 func (a *Application) UsageForContextWithTemplate(context *ParseContext, indent int, tmpl string) error {
-	width := guessWidth(a.usageWriter)
+	width := a.usageWidth()
 	funcs := template.FuncMap{
 		"Indent": func(level int) string {
 			return strings.Repeat(" ", level*indent)
@@ -153,7 +311,21 @@ func (a *Application) UsageForContextWithTemplate(context *ParseContext, indent
 
 			return buf.String()
 		},
-		"FormatFlag": formatFlag,
+		"FormatFlag":     formatFlag,
+		"CommandPattern": commandPattern,
+		"AllCommands":    allCommands,
+		"FishQuote":      fishQuote,
+		"ZshCompletions": func() []*zshCompletion {
+			return a.zshCompletions()
+		},
+		"HTML":            html.EscapeString,
+		"Anchor":          docAnchor,
+		"DescribeFlag":    describeFlag,
+		"DescribeArg":     describeArg,
+		"DescribeCommand": describeCommand,
+		"ColorEnabled": func() bool {
+			return a.term().ColorEnabled()
+		},
 		"VisibleFlags": func(flags []*FlagModel) []*FlagModel {
 			var vis []*FlagModel
 			for _, flag := range flags {
@@ -164,21 +336,21 @@ func (a *Application) UsageForContextWithTemplate(context *ParseContext, indent
 
 			return vis
 		},
-		"FlagsToTwoColumns": func(f []*FlagModel) [][2]string {
-			rows := [][2]string{}
-			haveShort := false
-			for _, flag := range f {
-				if flag.Short != 0 {
-					haveShort = true
-					break
-				}
-			}
-			for _, flag := range f {
-				if !flag.Hidden {
-					rows = append(rows, [2]string{formatFlag(haveShort, flag), flag.HelpWithEnvar()})
+		"VisibleArgs": func(args []*ArgModel) []*ArgModel {
+			var vis []*ArgModel
+			for _, arg := range args {
+				if !arg.Hidden {
+					vis = append(vis, arg)
 				}
 			}
-			return rows
+
+			return vis
+		},
+		"FlagsToTwoColumns": func(f []*FlagModel) [][2]string {
+			return flagsToTwoColumns(f, false)
+		},
+		"LongFlagsToTwoColumns": func(f []*FlagModel) [][2]string {
+			return flagsToTwoColumns(f, true)
 		},
 		"GlobalFlags": func(c *templateParseContext) []*FlagModel {
 			if c.SelectedCommand == nil {
@@ -230,7 +402,7 @@ func (a *Application) UsageForContextWithTemplate(context *ParseContext, indent
 			rows := [][2]string{}
 			for _, cmd := range c {
 				if !cmd.Hidden && cmd.FullCommand != "help" {
-					shortHelp := strings.Split(cmd.Help, "\n")[0]
+					shortHelp := strings.Split(cmd.Help, "\n")[0] + cmd.Annotations()
 					rows = append(rows, [2]string{cmd.FullCommand, shortHelp})
 				}
 			}
@@ -254,6 +426,8 @@ func (a *Application) UsageForContextWithTemplate(context *ParseContext, indent
 			}
 			return rows
 		},
+		"ArgGroups":  argGroups,
+		"FlagGroups": flagGroups,
 		"FormatTwoColumns": func(rows [][2]string) string {
 			buf := bytes.NewBuffer(nil)
 			formatTwoColumns(buf, indent, indent, width, rows)
@@ -291,19 +465,34 @@ func (a *Application) UsageForContextWithTemplate(context *ParseContext, indent
 	if err != nil {
 		return err
 	}
+
+	// reuse the cached models rather than building subtrees for every render
+	idx := a.cachedModelIndex()
+
 	var selectedCommand *CmdModel
 	if context.SelectedCommand != nil {
-		selectedCommand = context.SelectedCommand.Model()
+		selectedCommand = idx.cmdModel(context.SelectedCommand)
 	}
+	var footers []string
+	if selectedCommand != nil && selectedCommand.HelpFooter != "" {
+		footers = append(footers, selectedCommand.HelpFooter)
+	}
+	if a.helpFooter != "" {
+		footers = append(footers, a.helpFooter)
+	}
+
 	ctx := templateContext{
-		App:           a.Model(),
+		App:           a.sharedModel(),
 		Width:         width,
 		HelpFlagIsSet: a.helpFlagIsSet,
+		HelpHeader:    a.helpHeader,
+		HelpFooter:    strings.Join(footers, "\n\n"),
 		Context: &templateParseContext{
 			SelectedCommand: selectedCommand,
-			FlagGroupModel:  context.flags.Model(),
-			ArgGroupModel:   context.arguments.Model(),
+			FlagGroupModel:  idx.flagGroupModel(context.flags),
+			ArgGroupModel:   idx.argGroupModel(context.arguments),
 		},
 	}
-	return t.Execute(a.usageWriter, ctx)
+
+	return t.Execute(a.usageWriterFor(context), ctx)
 }
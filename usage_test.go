@@ -131,3 +131,179 @@ func TestShortMainUSage(t *testing.T) {
 	assert.Contains(t, buf.String(), "subsub1 long help")
 	assert.NotContains(t, buf.String(), "subsub2 long help")
 }
+
+func TestUsageReusesCachedModel(t *testing.T) {
+	var buf bytes.Buffer
+
+	a := New("test", "Test Command").UsageWriter(&buf).Terminate(nil)
+	sub := a.Command("sub", "Sub command")
+	sub.Flag("flag", "A flag").String()
+	sub.Arg("arg", "An arg").String()
+
+	a.Parse([]string{"sub", "--help"})
+	first := buf.String()
+	assert.Contains(t, first, "--flag")
+	assert.Contains(t, first, "<arg>")
+
+	var cached *CmdModel
+	for _, cm := range a.sharedModel().Commands {
+		if cm.Name == "sub" {
+			cached = cm
+		}
+	}
+	assert.NotNil(t, cached)
+	assert.Same(t, cached, a.cachedModelIndex().cmdModel(sub))
+
+	buf.Reset()
+	a.Parse([]string{"sub", "--help"})
+	assert.Equal(t, first, buf.String())
+}
+
+func TestTerminalWidth(t *testing.T) {
+	t.Setenv("COLUMNS", "100")
+	t.Setenv("FISK_WIDTH", "")
+
+	a := newTestApp()
+	assert.Equal(t, 100, a.usageWidth())
+
+	t.Setenv("FISK_WIDTH", "120")
+	assert.Equal(t, 120, a.usageWidth())
+
+	a.TerminalWidth(60)
+	assert.Equal(t, 60, a.usageWidth())
+}
+
+func TestArgGroups(t *testing.T) {
+	var buf bytes.Buffer
+
+	a := New("test", "Test").Writer(&buf).Terminate(nil).UsageTemplate(KingpinDefaultUsageTemplate)
+	cp := a.Command("cp", "Copy")
+	cp.Arg("mode", "Copy mode").String()
+	cp.Arg("source", "Source stream").Group("Source").String()
+	cp.Arg("destination", "Destination stream").Group("Destination").String()
+	cp.Arg("subject", "Source subject").Group("Source").String()
+
+	a.Parse([]string{"cp", "--help"})
+	usage := buf.String()
+	assert.Contains(t, usage, "Args:\n  [<mode>]  Copy mode\n")
+	assert.Contains(t, usage, "Source:\n  [<source>]   Source stream\n  [<subject>]  Source subject\n")
+	assert.Contains(t, usage, "Destination:\n  [<destination>]  Destination stream\n")
+
+	buf.Reset()
+	a.UsageForContextWithTemplate(a.LastParseContext(), 2, ManPageTemplate)
+	assert.Contains(t, buf.String(), ".PP\n\\fISource\\fR\n.TP\n\\fB<source>\\fR\nSource stream\n")
+}
+
+func TestHelpHeaderAndFooter(t *testing.T) {
+	for name, tmpl := range map[string]string{
+		"shorter":  ShorterMainUsageTemplate,
+		"compact":  CompactMainUsageTemplate,
+		"kingpin":  KingpinDefaultUsageTemplate,
+		"separate": SeparateOptionalFlagsUsageTemplate,
+		"compacts": CompactUsageTemplate,
+		"man":      ManPageTemplate,
+	} {
+		w := bytes.NewBuffer(nil)
+		app := New("test", "").UsageWriter(w).UsageTemplate(tmpl).Terminate(nil)
+		app.HelpHeader("Preview release").HelpFooter("Docs at https://example.net")
+		app.Command("stream", "Streams").HelpFooter("See 'test cheat stream' for examples")
+
+		_, err := app.Parse([]string{"stream", "--help"})
+		assert.NoError(t, err, name)
+
+		out := w.String()
+		if name == "man" {
+			assert.Contains(t, out, ".PP\nSee 'test cheat stream' for examples\n", name)
+			assert.True(t, strings.HasSuffix(out, ".SH \"NOTES\"\nDocs at https://example.net\n"), name)
+			continue
+		}
+
+		assert.True(t, strings.HasPrefix(out, "Preview release\n\nusage: test stream"), name)
+		assert.True(t, strings.HasSuffix(out, "\nSee 'test cheat stream' for examples\n\nDocs at https://example.net\n"), name)
+	}
+}
+
+func TestFlagClause_HelpLong(t *testing.T) {
+	for name, tmpl := range map[string]string{
+		"default":  KingpinDefaultUsageTemplate,
+		"long":     LongHelpTemplate,
+		"man":      ManPageTemplate,
+		"markdown": MarkdownTemplate,
+	} {
+		w := bytes.NewBuffer(nil)
+		app := New("test", "").UsageWriter(w).UsageTemplate(tmpl).Terminate(nil)
+		app.Flag("replicas", "Replica count").HelpLong("The number of replicas to keep, odd numbers are best").Int()
+
+		_, err := app.Parse([]string{"--help"})
+		assert.NoError(t, err, name)
+
+		if name == "default" {
+			assert.Contains(t, w.String(), "Replica count", name)
+			assert.NotContains(t, w.String(), "odd numbers", name)
+		} else {
+			assert.Contains(t, w.String(), "The number of replicas to keep, odd numbers are best", name)
+		}
+	}
+}
+
+func TestFlagGroups(t *testing.T) {
+	var buf bytes.Buffer
+
+	a := New("test", "Test").Writer(&buf).Terminate(nil).UsageTemplate(KingpinDefaultUsageTemplate)
+	pub := a.Command("pub", "Publish")
+	pub.Flag("tlscert", "TLS certificate").Group("TLS").String()
+	pub.Flag("user", "Username").Group("Authentication").String()
+	pub.Flag("tlskey", "TLS key").Group("TLS").String()
+	pub.Flag("count", "Messages to publish").Int()
+	pub.Flag("secret", "").Group("Hidden").Hidden().String()
+
+	a.Parse([]string{"pub", "--help"})
+	usage := buf.String()
+	assert.Contains(t, usage, "Flags:\n  --help       Show context-sensitive help\n  --count=INT  Messages to publish\n\nTLS:\n  --tlscert=TLSCERT  TLS certificate\n  --tlskey=TLSKEY    TLS key\n\nAuthentication:\n  --user=USER  Username\n")
+	assert.NotContains(t, usage, "Hidden:")
+
+	buf.Reset()
+	a.UsageForContextWithTemplate(a.LastParseContext(), 2, ManPageTemplate)
+	assert.Contains(t, buf.String(), ".PP\n\\fITLS\\fR\n.TP\n\\fB--tlscert=TLSCERT\\fR\nTLS certificate\n")
+}
+
+func TestAccessibleHelpStyle(t *testing.T) {
+	var buf bytes.Buffer
+
+	app := newTestApp().UsageWriter(&buf)
+	app.Flag("server", "Server address").Short('s').Default("127.0.0.1").Envar("SERVER").URL()
+	app.Flag("trace", "Trace").Bool()
+	add := app.Command("stream", "Manage streams").Alias("str").Command("add", "Adds a stream")
+	add.Arg("name", "Stream name").Required().String()
+	add.Flag("storage", "Storage").Enum("file", "memory")
+	add.Flag("replicas", "Replicas").Int()
+
+	app.Parse([]string{"stream", "add", "--help"})
+	assert.Contains(t, buf.String(), "--storage={file|memory}  Storage")
+
+	t.Setenv("FISK_HELP_STYLE", "accessible")
+	buf.Reset()
+	app.Reset()
+	app.Parse([]string{"stream", "add", "--help"})
+	assert.Equal(t, `Usage: test stream add
+
+Adds a stream
+
+Arguments:
+Argument name, required: Stream name
+
+Flags:
+Flag --storage, takes one of file, memory: Storage
+Flag --replicas, takes an INT: Replicas
+
+Global flags:
+Flag --help: Show context-sensitive help
+Flag --server, short -s, takes a URL, default 127.0.0.1, environment variable SERVER: Server address
+Flag --trace, negate using --no-trace: Trace
+`, buf.String())
+
+	buf.Reset()
+	app.Reset()
+	app.Parse([]string{"--help"})
+	assert.Contains(t, buf.String(), "Command stream, alias str: Manage streams\n")
+}
@@ -40,6 +40,12 @@ type Getter interface {
 	Get() interface{}
 }
 
+// Resetter is an optional interface for values that can be reset to their
+// zero value by Application.Reset()
+type Resetter interface {
+	Reset()
+}
+
 // Optional interface to indicate boolean flags that don't accept a value, and
 // implicitly have a --no-<x> negation counterpart.
 type boolFlag interface {
@@ -57,6 +63,62 @@ type repeatableFlag interface {
 	IsCumulative() bool
 }
 
+// Optional interface for boolean flags that accept a value given as --name=value.
+type optionalValueFlag interface {
+	IsOptionalValue() bool
+}
+
+// Optional interface for values that suggest a place-holder for help when
+// none is set on the flag.
+type placeHolderValue interface {
+	PlaceHolder() string
+}
+
+// typePlaceHolder derives a place-holder from the type of v, empty when the
+// type says nothing more useful than the name of the flag
+func typePlaceHolder(v Value) string {
+	switch t := v.(type) {
+	case placeHolderValue:
+		return t.PlaceHolder()
+	case *accumulator:
+		return typePlaceHolder(t.element(reflect.New(t.typ).Interface()))
+	case *durationValue:
+		return "DURATION"
+	case *intValue, *int8Value, *int16Value, *int32Value, *int64Value:
+		return "INT"
+	case *uintValue, *uint8Value, *uint16Value, *uint32Value, *uint64Value:
+		return "UINT"
+	case *float32Value, *float64Value:
+		return "FLOAT"
+	case *decimalValue:
+		return "DECIMAL"
+	case *fileStatValue:
+		return t.placeHolder
+	case *fileValue:
+		return "FILE"
+	case *urlValue, *urlListValue:
+		return "URL"
+	case *ipValue, *resolvedIPValue:
+		return "IP"
+	case *tcpAddrValue:
+		return "HOST:PORT"
+	case *bytesValue:
+		return "BYTES"
+	case *regexpValue:
+		return "REGEXP"
+	case *hexBytesValue:
+		return "HEX"
+	case *stringMapValue:
+		return "KEY=VALUE"
+	case *enumValue:
+		return "{" + strings.Join(t.options, "|") + "}"
+	case *enumsValue:
+		return "{" + strings.Join(t.options, "|") + "}"
+	}
+
+	return ""
+}
+
 // Text is the interface to the dynamic value stored in a flag.
 // (The default value is represented as a string.)
 type Text interface {
@@ -129,6 +191,10 @@ func (a *accumulator) IsCumulative() bool {
 	return true
 }
 
+func (a *accumulator) Reset() {
+	a.slice.Elem().Set(reflect.Zero(a.slice.Elem().Type()))
+}
+
 // BoolFlag is an optional interface to specify that a flag is a boolean flag.
 type BoolFlag interface {
 	// BoolFlagIsNegatable Specify if the flag is negatable (ie. supports both --no-<name> and --name).
@@ -166,6 +232,8 @@ func (d *durationValue) Get() interface{} { return time.Duration(*d) }
 
 func (d *durationValue) String() string { return (*time.Duration)(d).String() }
 
+func (d *durationValue) Reset() { *d = 0 }
+
 // -- map[string]string Value
 type stringMapValue map[string]string
 
@@ -196,6 +264,10 @@ func (s *stringMapValue) IsCumulative() bool {
 	return true
 }
 
+func (s *stringMapValue) Reset() {
+	*s = map[string]string{}
+}
+
 // -- net.IP Value
 type ipValue net.IP
 
@@ -220,6 +292,10 @@ func (i *ipValue) String() string {
 	return (*net.IP)(i).String()
 }
 
+func (i *ipValue) Reset() {
+	*i = nil
+}
+
 // -- *net.TCPAddr Value
 type tcpAddrValue struct {
 	addr **net.TCPAddr
@@ -246,11 +322,16 @@ func (i *tcpAddrValue) String() string {
 	return (*i.addr).String()
 }
 
+func (i *tcpAddrValue) Reset() {
+	*i.addr = nil
+}
+
 // -- existingFile Value
 
 type fileStatValue struct {
-	path      *string
-	predicate func(os.FileInfo) error
+	path        *string
+	predicate   func(os.FileInfo) error
+	placeHolder string
 }
 
 func newFileStatValue(p *string, predicate func(os.FileInfo) error) *fileStatValue {
@@ -280,6 +361,10 @@ func (e *fileStatValue) String() string {
 	return *e.path
 }
 
+func (e *fileStatValue) Reset() {
+	*e.path = ""
+}
+
 // -- os.File value
 
 type fileValue struct {
@@ -312,6 +397,10 @@ func (f *fileValue) String() string {
 	return (*f.f).Name()
 }
 
+func (f *fileValue) Reset() {
+	*f.f = nil
+}
+
 // -- url.URL Value
 type urlValue struct {
 	u **url.URL
@@ -341,6 +430,10 @@ func (u *urlValue) String() string {
 	return (*u.u).String()
 }
 
+func (u *urlValue) Reset() {
+	*u.u = nil
+}
+
 // -- []*url.URL Value
 type urlListValue []*url.URL
 
@@ -369,6 +462,10 @@ func (u *urlListValue) String() string {
 	return strings.Join(out, ",")
 }
 
+func (u *urlListValue) Reset() {
+	*u = nil
+}
+
 func (u *urlListValue) IsCumulative() bool {
 	return true
 }
@@ -390,6 +487,10 @@ func (a *enumValue) String() string {
 	return *a.value
 }
 
+func (a *enumValue) Reset() {
+	*a.value = ""
+}
+
 func (a *enumValue) Set(value string) error {
 	for _, v := range a.options {
 		if v == value {
@@ -439,6 +540,10 @@ func (s *enumsValue) IsCumulative() bool {
 	return true
 }
 
+func (s *enumsValue) Reset() {
+	*s.value = nil
+}
+
 // -- units.Base2Bytes Value
 type bytesValue units.Base2Bytes
 
@@ -456,26 +561,34 @@ func (d *bytesValue) Get() interface{} { return units.Base2Bytes(*d) }
 
 func (d *bytesValue) String() string { return (*units.Base2Bytes)(d).String() }
 
+func (d *bytesValue) Reset() { *d = 0 }
+
 func newExistingFileValue(target *string) *fileStatValue {
-	return newFileStatValue(target, func(s os.FileInfo) error {
+	v := newFileStatValue(target, func(s os.FileInfo) error {
 		if s.IsDir() {
 			return fmt.Errorf("'%s' is a directory", s.Name())
 		}
 		return nil
 	})
+	v.placeHolder = "FILE"
+	return v
 }
 
 func newExistingDirValue(target *string) *fileStatValue {
-	return newFileStatValue(target, func(s os.FileInfo) error {
+	v := newFileStatValue(target, func(s os.FileInfo) error {
 		if !s.IsDir() {
 			return fmt.Errorf("'%s' is a file", s.Name())
 		}
 		return nil
 	})
+	v.placeHolder = "DIR"
+	return v
 }
 
 func newExistingFileOrDirValue(target *string) *fileStatValue {
-	return newFileStatValue(target, func(s os.FileInfo) error { return nil })
+	v := newFileStatValue(target, func(s os.FileInfo) error { return nil })
+	v.placeHolder = "PATH"
+	return v
 }
 
 type counterValue int
@@ -493,6 +606,7 @@ func (c *counterValue) Get() interface{}   { return (int)(*c) }
 func (c *counterValue) IsBoolFlag() bool   { return true }
 func (c *counterValue) String() string     { return fmt.Sprintf("%d", *c) }
 func (c *counterValue) IsCumulative() bool { return true }
+func (c *counterValue) Reset()             { *c = 0 }
 
 func resolveHost(value string) (net.IP, error) {
 	if ip := net.ParseIP(value); ip != nil {
@@ -30,6 +30,8 @@ func (f *boolValue) Get() interface{} { return (bool)(*f.v) }
 
 func (f *boolValue) String() string { return fmt.Sprintf("%v", *f.v) }
 
+func (f *boolValue) Reset() { *f.v = *new(bool) }
+
 // Bool parses the next command-line value as bool.
 func (p *parserMixin) Bool() (target *bool) {
 	target = new(bool)
@@ -73,6 +75,8 @@ func (f *unNegatableBoolValue) Get() interface{} { return (bool)(*f.v) }
 
 func (f *unNegatableBoolValue) String() string { return fmt.Sprintf("%v", *f.v) }
 
+func (f *unNegatableBoolValue) Reset() { *f.v = *new(bool) }
+
 // UnNegatableBool parses the next command-line value as bool.
 func (p *parserMixin) UnNegatableBool() (target *bool) {
 	target = new(bool)
@@ -116,6 +120,8 @@ func (f *stringValue) Get() interface{} { return (string)(*f.v) }
 
 func (f *stringValue) String() string { return string(*f.v) }
 
+func (f *stringValue) Reset() { *f.v = *new(string) }
+
 // String parses the next command-line value as string.
 func (p *parserMixin) String() (target *string) {
 	target = new(string)
@@ -159,6 +165,8 @@ func (f *uintValue) Get() interface{} { return (uint)(*f.v) }
 
 func (f *uintValue) String() string { return fmt.Sprintf("%v", *f.v) }
 
+func (f *uintValue) Reset() { *f.v = *new(uint) }
+
 // Uint parses the next command-line value as uint.
 func (p *parserMixin) Uint() (target *uint) {
 	target = new(uint)
@@ -202,6 +210,8 @@ func (f *uint8Value) Get() interface{} { return (uint8)(*f.v) }
 
 func (f *uint8Value) String() string { return fmt.Sprintf("%v", *f.v) }
 
+func (f *uint8Value) Reset() { *f.v = *new(uint8) }
+
 // Uint8 parses the next command-line value as uint8.
 func (p *parserMixin) Uint8() (target *uint8) {
 	target = new(uint8)
@@ -245,6 +255,8 @@ func (f *uint16Value) Get() interface{} { return (uint16)(*f.v) }
 
 func (f *uint16Value) String() string { return fmt.Sprintf("%v", *f.v) }
 
+func (f *uint16Value) Reset() { *f.v = *new(uint16) }
+
 // Uint16 parses the next command-line value as uint16.
 func (p *parserMixin) Uint16() (target *uint16) {
 	target = new(uint16)
@@ -288,6 +300,8 @@ func (f *uint32Value) Get() interface{} { return (uint32)(*f.v) }
 
 func (f *uint32Value) String() string { return fmt.Sprintf("%v", *f.v) }
 
+func (f *uint32Value) Reset() { *f.v = *new(uint32) }
+
 // Uint32 parses the next command-line value as uint32.
 func (p *parserMixin) Uint32() (target *uint32) {
 	target = new(uint32)
@@ -331,6 +345,8 @@ func (f *uint64Value) Get() interface{} { return (uint64)(*f.v) }
 
 func (f *uint64Value) String() string { return fmt.Sprintf("%v", *f.v) }
 
+func (f *uint64Value) Reset() { *f.v = *new(uint64) }
+
 // Uint64 parses the next command-line value as uint64.
 func (p *parserMixin) Uint64() (target *uint64) {
 	target = new(uint64)
@@ -374,6 +390,8 @@ func (f *intValue) Get() interface{} { return (int)(*f.v) }
 
 func (f *intValue) String() string { return fmt.Sprintf("%v", *f.v) }
 
+func (f *intValue) Reset() { *f.v = *new(int) }
+
 // Int parses the next command-line value as int.
 func (p *parserMixin) Int() (target *int) {
 	target = new(int)
@@ -417,6 +435,8 @@ func (f *int8Value) Get() interface{} { return (int8)(*f.v) }
 
 func (f *int8Value) String() string { return fmt.Sprintf("%v", *f.v) }
 
+func (f *int8Value) Reset() { *f.v = *new(int8) }
+
 // Int8 parses the next command-line value as int8.
 func (p *parserMixin) Int8() (target *int8) {
 	target = new(int8)
@@ -460,6 +480,8 @@ func (f *int16Value) Get() interface{} { return (int16)(*f.v) }
 
 func (f *int16Value) String() string { return fmt.Sprintf("%v", *f.v) }
 
+func (f *int16Value) Reset() { *f.v = *new(int16) }
+
 // Int16 parses the next command-line value as int16.
 func (p *parserMixin) Int16() (target *int16) {
 	target = new(int16)
@@ -503,6 +525,8 @@ func (f *int32Value) Get() interface{} { return (int32)(*f.v) }
 
 func (f *int32Value) String() string { return fmt.Sprintf("%v", *f.v) }
 
+func (f *int32Value) Reset() { *f.v = *new(int32) }
+
 // Int32 parses the next command-line value as int32.
 func (p *parserMixin) Int32() (target *int32) {
 	target = new(int32)
@@ -546,6 +570,8 @@ func (f *int64Value) Get() interface{} { return (int64)(*f.v) }
 
 func (f *int64Value) String() string { return fmt.Sprintf("%v", *f.v) }
 
+func (f *int64Value) Reset() { *f.v = *new(int64) }
+
 // Int64 parses the next command-line value as int64.
 func (p *parserMixin) Int64() (target *int64) {
 	target = new(int64)
@@ -589,6 +615,8 @@ func (f *float64Value) Get() interface{} { return (float64)(*f.v) }
 
 func (f *float64Value) String() string { return fmt.Sprintf("%v", *f.v) }
 
+func (f *float64Value) Reset() { *f.v = *new(float64) }
+
 // Float64 parses the next command-line value as float64.
 func (p *parserMixin) Float64() (target *float64) {
 	target = new(float64)
@@ -632,6 +660,8 @@ func (f *float32Value) Get() interface{} { return (float32)(*f.v) }
 
 func (f *float32Value) String() string { return fmt.Sprintf("%v", *f.v) }
 
+func (f *float32Value) Reset() { *f.v = *new(float32) }
+
 // Float32 parses the next command-line value as float32.
 func (p *parserMixin) Float32() (target *float32) {
 	target = new(float32)
@@ -753,6 +783,8 @@ func (f *regexpValue) Get() interface{} { return (*regexp.Regexp)(*f.v) }
 
 func (f *regexpValue) String() string { return fmt.Sprintf("%v", *f.v) }
 
+func (f *regexpValue) Reset() { *f.v = *new(*regexp.Regexp) }
+
 // Regexp parses the next command-line value as *regexp.Regexp.
 func (p *parserMixin) Regexp() (target **regexp.Regexp) {
 	target = new(*regexp.Regexp)
@@ -796,6 +828,8 @@ func (f *resolvedIPValue) Get() interface{} { return (net.IP)(*f.v) }
 
 func (f *resolvedIPValue) String() string { return fmt.Sprintf("%v", *f.v) }
 
+func (f *resolvedIPValue) Reset() { *f.v = *new(net.IP) }
+
 // Resolve a hostname or IP to an IP.
 func (p *parserMixin) ResolvedIP() (target *net.IP) {
 	target = new(net.IP)
@@ -839,6 +873,8 @@ func (f *hexBytesValue) Get() interface{} { return ([]byte)(*f.v) }
 
 func (f *hexBytesValue) String() string { return fmt.Sprintf("%v", *f.v) }
 
+func (f *hexBytesValue) Reset() { *f.v = *new([]byte) }
+
 // Bytes as a hex string.
 func (p *parserMixin) HexBytes() (target *[]byte) {
 	target = new([]byte)
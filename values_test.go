@@ -95,3 +95,32 @@ func TestSetValueDoesNotReset(t *testing.T) {
 	app.Flag("set", "").StringMapVar(&mapping)
 	assert.NotEmpty(t, mapping)
 }
+
+func TestTypePlaceHolders(t *testing.T) {
+	app := newTestApp()
+	app.Flag("timeout", "").Duration()
+	app.Flag("count", "").Int()
+	app.Flag("config", "").ExistingFile()
+	app.Flag("dirs", "").ExistingDirs()
+	app.Flag("server", "").URL()
+	app.Flag("format", "").Enum("json", "yaml")
+	app.Flag("name", "").String()
+	app.Flag("wait", "").PlaceHolder("SECONDS").Duration()
+	app.Flag("retries", "").Default("3").Int()
+
+	expected := map[string]string{
+		"timeout": "DURATION",
+		"count":   "INT",
+		"config":  "FILE",
+		"dirs":    "DIR",
+		"server":  "URL",
+		"format":  "{json|yaml}",
+		"name":    "NAME",
+		"wait":    "SECONDS",
+		"retries": "3",
+	}
+
+	for name, want := range expected {
+		assert.Equal(t, want, app.GetFlag(name).Model().FormatPlaceHolder(), name)
+	}
+}
@@ -0,0 +1,24 @@
+package fisk
+
+// WithVerbosityFlags adds global --verbose (-v) and --quiet (-q) flags, the
+// resolved level is available using ParseContext.Verbosity()
+func (a *Application) WithVerbosityFlags() *Application {
+	a.verbosityFlags = true
+	a.Flag("verbose", "Increase output verbosity, may be repeated").Short('v').CounterVar(&a.verbose)
+	a.Flag("quiet", "Only show errors").Short('q').UnNegatableBoolVar(&a.quiet)
+	return a
+}
+
+// Verbosity is the level set using the flags added by WithVerbosityFlags(), -1
+// when --quiet was given, otherwise the number of times --verbose was given
+func (p *ParseContext) Verbosity() int {
+	if p.app == nil || !p.app.verbosityFlags {
+		return 0
+	}
+
+	if p.app.quiet {
+		return -1
+	}
+
+	return p.app.verbose
+}
@@ -0,0 +1,30 @@
+package fisk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerbosityFlags(t *testing.T) {
+	parse := func(args ...string) int {
+		t.Helper()
+
+		app := newTestApp().WithVerbosityFlags()
+		app.Arg("x", "").String()
+
+		pc, err := app.ParseContext(args)
+		assert.NoError(t, err)
+		_, err = app.setValues(pc)
+		assert.NoError(t, err)
+
+		return pc.Verbosity()
+	}
+
+	assert.Equal(t, 0, parse())
+	assert.Equal(t, 1, parse("-v"))
+	assert.Equal(t, 3, parse("-vvv"))
+	assert.Equal(t, 2, parse("--verbose", "x", "-v"))
+	assert.Equal(t, -1, parse("-q"))
+	assert.Equal(t, 0, (&ParseContext{}).Verbosity())
+}
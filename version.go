@@ -0,0 +1,219 @@
+package fisk
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"runtime/debug"
+	"text/template"
+)
+
+// DefaultVersionTemplate is the template used by the command added by WithVersionCommand()
+var DefaultVersionTemplate = `{{.Name}} version {{if .Version}}{{.Version}}{{else}}unknown{{end}}
+{{- if .Commit}}
+  Commit: {{.Commit}}
+{{- end}}
+{{- if .BuildDate}}
+   Built: {{.BuildDate}}
+{{- end}}
+      Go: {{.GoVersion}} {{.OS}}/{{.Arch}}
+`
+
+// VersionFormatter writes info to w in format, format is "text" for the plain
+// version, see Application.VersionFormatter()
+type VersionFormatter func(w io.Writer, format string, info VersionInfo) error
+
+// VersionInfo describes the version and build of the application
+type VersionInfo struct {
+	Name      string `json:"name" yaml:"name"`
+	Version   string `json:"version" yaml:"version"`
+	Commit    string `json:"commit,omitempty" yaml:"commit,omitempty"`
+	BuildDate string `json:"build_date,omitempty" yaml:"build_date,omitempty"`
+	GoVersion string `json:"go_version" yaml:"go_version"`
+	OS        string `json:"os" yaml:"os"`
+	Arch      string `json:"arch" yaml:"arch"`
+	// Details are additional build details like those from VersionFromBuildInfo()
+	Details map[string]string `json:"details,omitempty" yaml:"details,omitempty"`
+}
+
+// VersionBuild sets the commit and date the application was built from,
+// typically set using ldflags, shown by the command added by WithVersionCommand()
+func (a *Application) VersionBuild(commit string, date string) *Application {
+	a.versionCommit = commit
+	a.versionBuildDate = date
+	return a
+}
+
+// VersionTemplate sets the template used by the command added by
+// WithVersionCommand(), the template is executed with a VersionInfo
+func (a *Application) VersionTemplate(tmpl string) *Application {
+	a.versionTemplate = tmpl
+	return a
+}
+
+// VersionFormatter renders the version for --version=format and the command
+// added by WithVersionCommand(), replacing the built in text, json and yaml formats
+func (a *Application) VersionFormatter(formatter VersionFormatter) *Application {
+	a.versionFormatter = formatter
+	return a
+}
+
+// VersionInfo describes the version and build of the application
+func (a *Application) VersionInfo() VersionInfo {
+	return VersionInfo{
+		Name:      a.Name,
+		Version:   a.version,
+		Commit:    a.versionCommit,
+		BuildDate: a.versionBuildDate,
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		Details:   a.versionDetails,
+	}
+}
+
+// VersionFromBuildInfo sets the version, commit and build date from the
+// information Go embeds in binaries, like those built using go install, adding
+// the --version flag when needed.
+//
+// Values already set using Version() and VersionBuild() are kept, the module
+// path and whether the source was modified are added to VersionInfo().Details
+func (a *Application) VersionFromBuildInfo() *Application {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return a
+	}
+
+	return a.versionFromBuildInfo(info)
+}
+
+func (a *Application) versionFromBuildInfo(info *debug.BuildInfo) *Application {
+	if a.versionDetails == nil {
+		a.versionDetails = map[string]string{}
+	}
+	if info.Main.Path != "" {
+		a.versionDetails["module"] = info.Main.Path
+	}
+
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs":
+			a.versionDetails["vcs"] = setting.Value
+		case "vcs.revision":
+			if a.versionCommit == "" {
+				a.versionCommit = setting.Value
+			}
+		case "vcs.time":
+			if a.versionBuildDate == "" {
+				a.versionBuildDate = setting.Value
+			}
+		case "vcs.modified":
+			a.versionDetails["dirty"] = setting.Value
+		}
+	}
+
+	if a.version == "" {
+		a.version = info.Main.Version
+		if a.version == "" || a.version == "(devel)" {
+			a.version = "devel"
+		}
+	}
+
+	if a.VersionFlag == nil {
+		a.Version(a.version)
+	}
+
+	return a
+}
+
+// WithVersionCommand adds a version command showing the version, build and
+// runtime details using VersionTemplate() or as JSON with --output json.
+//
+// When called after OutputFormatFlag() the command uses the global --output
+// flag instead of adding its own.
+func (a *Application) WithVersionCommand() *Application {
+	format := "text"
+
+	a.VersionCommand = a.Commandf("version", "Shows the version of %s", a.Name).Action(func(pc *ParseContext) error {
+		return a.writeVersion(pc, format)
+	})
+
+	if a.long["output"] == nil {
+		a.VersionCommand.Flag("output", "Output format").Short('o').Default("text").EnumVar(&format, "text", "json", "yaml")
+	}
+
+	return a
+}
+
+func (a *Application) writeVersion(pc *ParseContext, format string) error {
+	if a.VersionCommand.long["output"] == nil {
+		format = pc.OutputFormat()
+	}
+
+	return a.formatVersion(format, false)
+}
+
+// formatVersion writes the version in format, plain text for the --version flag is just the version
+func (a *Application) formatVersion(format string, flag bool) error {
+	info := a.VersionInfo()
+
+	if a.versionFormatter != nil {
+		return a.versionFormatter(a.usageWriter, format, info)
+	}
+
+	switch format {
+	case "json", "yaml":
+		return RenderOutput(a.usageWriter, format, info)
+	}
+
+	if flag {
+		_, err := fmt.Fprintln(a.usageWriter, info.Version)
+		return err
+	}
+
+	tmpl := a.versionTemplate
+	if tmpl == "" {
+		tmpl = DefaultVersionTemplate
+	}
+
+	t, err := template.New("version").Parse(tmpl)
+	if err != nil {
+		return err
+	}
+
+	return t.Execute(a.usageWriter, info)
+}
+
+// -- --version Value, a boolean flag that accepts a format
+type versionFormatValue struct {
+	app    *Application
+	format *string
+}
+
+func (v *versionFormatValue) Set(s string) error {
+	switch s {
+	case "true", "text":
+		*v.format = "text"
+	case "json", "yaml":
+		*v.format = s
+	default:
+		if v.app.versionFormatter == nil {
+			return fmt.Errorf("unsupported version format %q, expected text, json or yaml", s)
+		}
+		*v.format = s
+	}
+
+	return nil
+}
+
+func (v *versionFormatValue) Get() interface{} { return *v.format != "" }
+
+func (v *versionFormatValue) String() string { return fmt.Sprintf("%v", *v.format != "") }
+
+func (v *versionFormatValue) Reset() { *v.format = "" }
+
+func (v *versionFormatValue) IsBoolFlag() bool { return true }
+
+func (v *versionFormatValue) BoolFlagIsNegatable() bool { return false }
+
+func (v *versionFormatValue) IsOptionalValue() bool { return true }
@@ -0,0 +1,109 @@
+package fisk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionCommand(t *testing.T) {
+	w := &bytes.Buffer{}
+	app := newTestApp().Version("1.2.3").VersionBuild("abc123", "2024-01-02").WithVersionCommand()
+	app.UsageWriter(w)
+
+	_, err := app.Parse([]string{"version"})
+	assert.NoError(t, err)
+	assert.Equal(t, "test version 1.2.3\n  Commit: abc123\n   Built: 2024-01-02\n      Go: "+runtime.Version()+" "+runtime.GOOS+"/"+runtime.GOARCH+"\n", w.String())
+
+	w.Reset()
+	_, err = app.Parse([]string{"version", "--output", "json"})
+	assert.NoError(t, err)
+	var info VersionInfo
+	assert.NoError(t, json.Unmarshal(w.Bytes(), &info))
+	assert.Equal(t, app.VersionInfo(), info)
+
+	w.Reset()
+	app = newTestApp().Version("1.2.3").VersionTemplate("{{.Name}}@{{.Version}}\n").OutputFormatFlag("table", "json").WithVersionCommand()
+	app.UsageWriter(w)
+	_, err = app.Parse([]string{"version"})
+	assert.NoError(t, err)
+	assert.Equal(t, "test@1.2.3\n", w.String())
+	assert.Nil(t, app.VersionCommand.GetFlag("output"))
+
+	w.Reset()
+	_, err = app.Parse([]string{"version", "-o", "json"})
+	assert.NoError(t, err)
+	assert.Contains(t, w.String(), `"version": "1.2.3"`)
+}
+
+func TestVersionFromBuildInfo(t *testing.T) {
+	w := &bytes.Buffer{}
+	app := newTestApp().versionFromBuildInfo(&debug.BuildInfo{
+		Main: debug.Module{Path: "example.net/tool", Version: "v1.4.0"},
+		Settings: []debug.BuildSetting{
+			{Key: "vcs", Value: "git"},
+			{Key: "vcs.revision", Value: "abc123"},
+			{Key: "vcs.time", Value: "2024-01-02T10:00:00Z"},
+			{Key: "vcs.modified", Value: "true"},
+		},
+	})
+	app.UsageWriter(w)
+
+	info := app.VersionInfo()
+	assert.Equal(t, "v1.4.0", info.Version)
+	assert.Equal(t, "abc123", info.Commit)
+	assert.Equal(t, "2024-01-02T10:00:00Z", info.BuildDate)
+	assert.Equal(t, map[string]string{"module": "example.net/tool", "vcs": "git", "dirty": "true"}, info.Details)
+
+	_, err := app.Parse([]string{"--version"})
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.4.0\n", w.String())
+
+	app = newTestApp().Version("2.0.0").VersionBuild("release", "").versionFromBuildInfo(&debug.BuildInfo{
+		Main:     debug.Module{Version: "(devel)"},
+		Settings: []debug.BuildSetting{{Key: "vcs.revision", Value: "abc123"}},
+	})
+	assert.Equal(t, "2.0.0", app.VersionInfo().Version)
+	assert.Equal(t, "release", app.VersionInfo().Commit)
+
+	app = newTestApp().versionFromBuildInfo(&debug.BuildInfo{Main: debug.Module{Version: "(devel)"}})
+	assert.Equal(t, "devel", app.VersionInfo().Version)
+}
+
+func TestVersionFlagFormat(t *testing.T) {
+	w := &bytes.Buffer{}
+	app := newTestApp().Version("1.2.3")
+	app.UsageWriter(w)
+
+	_, err := app.Parse([]string{"--version"})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.3\n", w.String())
+
+	w.Reset()
+	_, err = app.Parse([]string{"--version=json"})
+	assert.NoError(t, err)
+	var info VersionInfo
+	assert.NoError(t, json.Unmarshal(w.Bytes(), &info))
+	assert.Equal(t, "test", info.Name)
+	assert.Equal(t, "1.2.3", info.Version)
+	assert.Equal(t, runtime.Version(), info.GoVersion)
+	assert.Equal(t, runtime.GOOS, info.OS)
+
+	_, err = app.Parse([]string{"--version=xml"})
+	assert.EqualError(t, err, `unsupported version format "xml", expected text, json or yaml`)
+
+	w.Reset()
+	app.VersionFormatter(func(w io.Writer, format string, info VersionInfo) error {
+		_, err := fmt.Fprintf(w, "%s %s %s\n", format, info.Name, info.Version)
+		return err
+	})
+	_, err = app.Parse([]string{"--version=xml"})
+	assert.NoError(t, err)
+	assert.Equal(t, "xml test 1.2.3\n", w.String())
+}
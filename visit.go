@@ -0,0 +1,57 @@
+package fisk
+
+// ModelNode is a command, flag or argument visited by ApplicationModel.Visit(),
+// exactly one of Command, Flag or Arg is set
+type ModelNode struct {
+	// Path is the command path leading to the node, for commands this includes the command itself
+	Path    []string
+	Command *CmdModel
+	Flag    *FlagModel
+	Arg     *ArgModel
+}
+
+// ModelFilter selects the nodes visited by ApplicationModel.Visit(), nodes
+// below a skipped command are skipped as well
+type ModelFilter struct {
+	SkipHidden     bool
+	SkipDeprecated bool
+	SkipPlugins    bool
+}
+
+// Visit calls cb for every node of the model that passes filter, starting with
+// the application flags and arguments followed by the commands depth first
+func (m *ApplicationModel) Visit(filter ModelFilter, cb func(node ModelNode)) {
+	filter.visit(nil, m.FlagGroupModel, m.ArgGroupModel, m.CmdGroupModel, cb)
+}
+
+func (f ModelFilter) visit(path []string, flags *FlagGroupModel, args *ArgGroupModel, cmds *CmdGroupModel, cb func(node ModelNode)) {
+	if flags != nil {
+		for _, flag := range flags.Flags {
+			if (f.SkipHidden && flag.Hidden) || (f.SkipDeprecated && flag.Deprecated != "") {
+				continue
+			}
+			cb(ModelNode{Path: path, Flag: flag})
+		}
+	}
+
+	if args != nil {
+		for _, arg := range args.Args {
+			if f.SkipHidden && arg.Hidden {
+				continue
+			}
+			cb(ModelNode{Path: path, Arg: arg})
+		}
+	}
+
+	if cmds != nil {
+		for _, cmd := range cmds.Commands {
+			if (f.SkipHidden && cmd.Hidden) || (f.SkipDeprecated && cmd.Deprecated != "") || (f.SkipPlugins && cmd.Plugin) {
+				continue
+			}
+
+			cmdPath := append(append([]string{}, path...), cmd.Name)
+			cb(ModelNode{Path: cmdPath, Command: cmd})
+			f.visit(cmdPath, cmd.FlagGroupModel, cmd.ArgGroupModel, cmd.CmdGroupModel, cb)
+		}
+	}
+}
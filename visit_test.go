@@ -0,0 +1,42 @@
+package fisk
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModelVisit(t *testing.T) {
+	app := newTestApp()
+	app.Flag("server", "").String()
+	app.Flag("old", "").Deprecated("use --server").String()
+	stream := app.Command("stream", "")
+	stream.Command("add", "").Arg("name", "").String()
+	stream.Command("secret", "").Hidden().Flag("x", "").String()
+
+	plugin := newTestApp()
+	plugin.Command("run", "")
+	j, err := plugin.Freeze()
+	assert.NoError(t, err)
+	_, err = app.ExternalPluginCommand("/bin/true", j, "plugin", "A plugin")
+	assert.NoError(t, err)
+
+	visit := func(filter ModelFilter) []string {
+		var nodes []string
+		app.Model().Visit(filter, func(node ModelNode) {
+			switch {
+			case node.Command != nil:
+				nodes = append(nodes, strings.Join(node.Path, " "))
+			case node.Flag != nil && !ignoreInCount[node.Flag.Name] && !strings.HasPrefix(node.Flag.Name, "help"):
+				nodes = append(nodes, strings.Join(append(node.Path, "--"+node.Flag.Name), " "))
+			case node.Arg != nil:
+				nodes = append(nodes, strings.Join(append(node.Path, "<"+node.Arg.Name+">"), " "))
+			}
+		})
+		return nodes
+	}
+
+	assert.Equal(t, []string{"--server", "--old", "stream", "stream add", "stream add <name>", "stream secret", "stream secret --x", "plugin", "plugin run"}, visit(ModelFilter{}))
+	assert.Equal(t, []string{"--server", "stream", "stream add", "stream add <name>"}, visit(ModelFilter{SkipHidden: true, SkipDeprecated: true, SkipPlugins: true}))
+}
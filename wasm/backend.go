@@ -0,0 +1,227 @@
+// Package wasm runs fisk plugins compiled to WebAssembly using the WASI
+// preview 1 interface in the wazero sandbox.
+//
+// Plugins have no access to the network or filesystem unless a directory is
+// mounted using Mount() and see no host environment variables unless they are
+// allowed using Env(), they are added to an application using PluginCommand():
+//
+//	backend, err := wasm.Load("plugins/stream.wasm")
+//	if err != nil {
+//		panic(err)
+//	}
+//
+//	app.PluginCommand("stream.wasm", backend, "", "")
+package wasm
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/choria-io/fisk"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+	"github.com/tetratelabs/wazero/sys"
+)
+
+// Backend loads and runs a WebAssembly plugin, it implements fisk.PluginBackend
+type Backend struct {
+	wasm       []byte
+	stdin      io.Reader
+	stdout     io.Writer
+	stderr     io.Writer
+	mount      fs.FS
+	mountPoint string
+	introspect string
+	env        map[string]bool
+
+	mu       sync.Mutex
+	runtime  wazero.Runtime
+	compiled wazero.CompiledModule
+}
+
+// ExitError is returned when a plugin exits with a non zero code
+type ExitError struct {
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("plugin exited with code %d", e.Code)
+}
+
+// ExitCode is the code the plugin exited with, the application exits with the same code
+func (e *ExitError) ExitCode() int {
+	return e.Code
+}
+
+var _ fisk.PluginBackend = (*Backend)(nil)
+
+// New creates a backend for the WebAssembly module in wasm using the standard input and outputs
+func New(wasm []byte) *Backend {
+	return &Backend{
+		wasm:       wasm,
+		stdin:      os.Stdin,
+		stdout:     os.Stdout,
+		stderr:     os.Stderr,
+		introspect: "--fisk-introspect",
+	}
+}
+
+// Load creates a backend for the WebAssembly module in file
+func Load(file string) (*Backend, error) {
+	wasm, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(wasm), nil
+}
+
+// Stdio sets the input and outputs of the plugin
+func (b *Backend) Stdio(stdin io.Reader, stdout io.Writer, stderr io.Writer) *Backend {
+	b.stdin = stdin
+	b.stdout = stdout
+	b.stderr = stderr
+	return b
+}
+
+// Mount gives the plugin access to fsys at the guest path, for example
+// Mount(os.DirFS("/tmp/work"), "/work")
+func (b *Backend) Mount(fsys fs.FS, guest string) *Backend {
+	b.mount = fsys
+	b.mountPoint = guest
+	return b
+}
+
+// Env allows the plugin to see the named host environment variables, by default it sees none
+func (b *Backend) Env(names ...string) *Backend {
+	if b.env == nil {
+		b.env = map[string]bool{}
+	}
+	for _, name := range names {
+		b.env[name] = true
+	}
+	return b
+}
+
+// IntrospectionFlag sets the flag used to retrieve the model of plugins that renamed --fisk-introspect
+func (b *Backend) IntrospectionFlag(name string) *Backend {
+	b.introspect = "--" + name
+	return b
+}
+
+// Close releases the runtime and compiled module
+func (b *Backend) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.runtime == nil {
+		return nil
+	}
+
+	err := b.runtime.Close(context.Background())
+	b.runtime = nil
+	b.compiled = nil
+
+	return err
+}
+
+// PluginModel runs the plugin with the introspection flag and returns its output
+func (b *Backend) PluginModel() (json.RawMessage, error) {
+	var out bytes.Buffer
+
+	err := b.run("plugin", []string{b.introspect}, nil, bytes.NewReader(nil), &out, b.stderr)
+	if err != nil {
+		return nil, err
+	}
+
+	return out.Bytes(), nil
+}
+
+// ExecutePlugin runs the plugin with the arguments of execution and the variables allowed by Env() from its environment
+func (b *Backend) ExecutePlugin(execution *fisk.PluginExecution) error {
+	return b.run(execution.Command, execution.Args, execution.Env, b.stdin, b.stdout, b.stderr)
+}
+
+// module compiles the plugin once and reuses it for every run
+func (b *Backend) module(ctx context.Context) (wazero.Runtime, wazero.CompiledModule, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.compiled != nil {
+		return b.runtime, b.compiled, nil
+	}
+
+	runtime := wazero.NewRuntime(ctx)
+	_, err := wasi_snapshot_preview1.Instantiate(ctx, runtime)
+	if err != nil {
+		runtime.Close(ctx)
+		return nil, nil, err
+	}
+
+	compiled, err := runtime.CompileModule(ctx, b.wasm)
+	if err != nil {
+		runtime.Close(ctx)
+		return nil, nil, fmt.Errorf("could not compile plugin: %w", err)
+	}
+
+	b.runtime = runtime
+	b.compiled = compiled
+
+	return runtime, compiled, nil
+}
+
+func (b *Backend) run(name string, args []string, env []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	ctx := context.Background()
+
+	runtime, compiled, err := b.module(ctx)
+	if err != nil {
+		return err
+	}
+
+	// an empty name lets the module be instantiated for every run
+	config := wazero.NewModuleConfig().
+		WithName("").
+		WithArgs(append([]string{name}, args...)...).
+		WithStdin(stdin).
+		WithStdout(stdout).
+		WithStderr(stderr).
+		WithSysWalltime().
+		WithSysNanotime().
+		WithRandSource(rand.Reader)
+
+	for _, e := range env {
+		k, v, _ := strings.Cut(e, "=")
+		if b.env[k] {
+			config = config.WithEnv(k, v)
+		}
+	}
+
+	if b.mount != nil {
+		config = config.WithFSConfig(wazero.NewFSConfig().WithFSMount(b.mount, b.mountPoint))
+	}
+
+	mod, err := runtime.InstantiateModule(ctx, compiled, config)
+	if mod != nil {
+		mod.Close(ctx)
+	}
+
+	var exitErr *sys.ExitError
+	if errors.As(err, &exitErr) {
+		if exitErr.ExitCode() == 0 {
+			return nil
+		}
+
+		return &ExitError{Code: int(exitErr.ExitCode())}
+	}
+
+	return err
+}
@@ -0,0 +1,67 @@
+package wasm
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/choria-io/fisk"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildPlugin(t *testing.T) string {
+	t.Helper()
+
+	if testing.Short() {
+		t.Skip("building the plugin is slow")
+	}
+
+	file := filepath.Join(t.TempDir(), "plugin.wasm")
+	cmd := exec.Command("go", "build", "-o", file, "./testdata/plugin")
+	cmd.Env = append(os.Environ(), "GOOS=wasip1", "GOARCH=wasm")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("could not build plugin: %v: %s", err, out)
+	}
+
+	return file
+}
+
+func TestBackend(t *testing.T) {
+	backend, err := Load(buildPlugin(t))
+	assert.NoError(t, err)
+	defer backend.Close()
+
+	var stdout bytes.Buffer
+	backend.Stdio(strings.NewReader(""), &stdout, io.Discard)
+
+	app := fisk.New("host", "").Terminate(nil)
+	cmd, err := app.PluginCommand("plugin.wasm", backend, "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "plugin", cmd.Model().Name)
+	assert.Equal(t, "A test plugin", cmd.Model().Help)
+
+	_, err = app.Parse([]string{"plugin", "hello", "bob"})
+	assert.NoError(t, err)
+	assert.Equal(t, "hello bob\n", stdout.String())
+
+	// the host environment is not visible unless allowed
+	stdout.Reset()
+	err = backend.ExecutePlugin(&fisk.PluginExecution{Command: "plugin.wasm", Args: []string{"hello", "bob"}, Env: []string{"GREETING=!"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "hello bob\n", stdout.String())
+
+	stdout.Reset()
+	backend.Env("GREETING")
+	err = backend.ExecutePlugin(&fisk.PluginExecution{Command: "plugin.wasm", Args: []string{"hello", "bob"}, Env: []string{"GREETING=!", "SECRET=x"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "hello bob!\n", stdout.String())
+
+	err = backend.ExecutePlugin(&fisk.PluginExecution{Command: "plugin.wasm", Args: []string{"fail"}})
+	assert.EqualError(t, err, "plugin exited with code 3")
+	assert.Equal(t, 3, fisk.ExitCodeFor(err))
+}
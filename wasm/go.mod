@@ -0,0 +1,19 @@
+// To develop against a local checkout of fisk use a workspace rather than a replace:
+//
+//	go work init . ./wasm
+module github.com/choria-io/fisk/wasm
+
+go 1.22.0
+
+require (
+	github.com/choria-io/fisk v0.0.0-20261016020958-47d21383681c
+	github.com/stretchr/testify v1.9.0
+	github.com/tetratelabs/wazero v1.8.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/text v0.17.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/choria-io/fisk"
+)
+
+func main() {
+	app := fisk.New("plugin", "A test plugin")
+	name := app.Command("hello", "Says hello").Arg("name", "").Required().String()
+	app.Command("fail", "Exits with code 3")
+
+	switch app.MustParseWithUsage(os.Args[1:]) {
+	case "hello":
+		fmt.Printf("hello %s%s\n", *name, os.Getenv("GREETING"))
+	case "fail":
+		os.Exit(3)
+	}
+}
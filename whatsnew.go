@@ -0,0 +1,65 @@
+package fisk
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// WhatsNew shows text once, on the first run of version in an interactive
+// terminal, for example to highlight new commands after an upgrade.
+//
+// The last version shown is stored in $XDG_STATE_HOME/<app>/whatsnew. Notices
+// are disabled using --no-tips, the <APP>_NO_TIPS environment variable or when
+// the CI environment variable is set.
+func (a *Application) WhatsNew(version string, text string) *Application {
+	a.whatsNewVersion = version
+	a.whatsNewText = text
+	a.Flag("no-tips", "Disables notices about new features").Envar(envarTransform(a.Name + "_NO_TIPS")).UnNegatableBoolVar(&a.noTips)
+
+	return a
+}
+
+func (a *Application) whatsNewStateFile() (string, error) {
+	dir := os.Getenv("XDG_STATE_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".local", "state")
+	}
+
+	return filepath.Join(dir, a.Name, "whatsnew"), nil
+}
+
+// maybeShowWhatsNew shows the WhatsNew() notice when the version was not seen before
+func (a *Application) maybeShowWhatsNew() {
+	if a.whatsNewVersion == "" || a.noTips || os.Getenv("CI") != "" || !a.term().IsTTY() {
+		return
+	}
+
+	state, err := a.whatsNewStateFile()
+	if err != nil {
+		return
+	}
+
+	seen, err := os.ReadFile(state)
+	if err == nil && strings.TrimSpace(string(seen)) == a.whatsNewVersion {
+		return
+	}
+
+	fmt.Fprintf(a.errorWriter, "What's new in %s %s:\n\n%s\n", a.Name, a.whatsNewVersion, strings.TrimRight(a.whatsNewText, "\n"))
+	switch {
+	case a.ChangelogCommand != nil:
+		fmt.Fprintf(a.errorWriter, "\nRun '%s changelog --current' for all the changes\n", a.Name)
+	case a.CheatCommand != nil:
+		fmt.Fprintf(a.errorWriter, "\nRun '%s cheat' for examples\n", a.Name)
+	}
+	fmt.Fprintln(a.errorWriter)
+
+	if os.MkdirAll(filepath.Dir(state), 0700) == nil {
+		os.WriteFile(state, []byte(a.whatsNewVersion+"\n"), 0600)
+	}
+}
@@ -0,0 +1,65 @@
+package fisk
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ttyTerminal struct{}
+
+func (ttyTerminal) IsTTY() bool                   { return true }
+func (ttyTerminal) Width() int                    { return 80 }
+func (ttyTerminal) ColorEnabled() bool            { return false }
+func (ttyTerminal) Input() io.Reader              { return strings.NewReader("") }
+func (ttyTerminal) ReadPassword() (string, error) { return "", io.EOF }
+
+func TestWhatsNew(t *testing.T) {
+	state := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", state)
+	t.Setenv("CI", "")
+
+	errs := &bytes.Buffer{}
+	app := New("tool", "").Terminal(ttyTerminal{}).WhatsNew("1.1.0", "The stream clone command was added")
+	app.ErrorWriter(errs).Terminate(nil)
+	app.Command("run", "")
+
+	_, err := app.Parse([]string{"run"})
+	assert.NoError(t, err)
+	assert.Equal(t, "What's new in tool 1.1.0:\n\nThe stream clone command was added\n\n", errs.String())
+
+	seen, err := os.ReadFile(filepath.Join(state, "tool", "whatsnew"))
+	assert.NoError(t, err)
+	assert.Equal(t, "1.1.0\n", string(seen))
+
+	errs.Reset()
+	_, err = app.Parse([]string{"run"})
+	assert.NoError(t, err)
+	assert.Empty(t, errs.String())
+
+	app = New("tool", "").Terminal(ttyTerminal{}).WhatsNew("1.2.0", "Faster")
+	app.ErrorWriter(errs).Terminate(nil)
+	app.Command("run", "")
+
+	_, err = app.Parse([]string{"run", "--no-tips"})
+	assert.NoError(t, err)
+	assert.Empty(t, errs.String())
+
+	t.Setenv("TOOL_NO_TIPS", "true")
+	app.Reset()
+	_, err = app.Parse([]string{"run"})
+	assert.NoError(t, err)
+	assert.Empty(t, errs.String())
+
+	os.Unsetenv("TOOL_NO_TIPS")
+	app.Reset()
+	app.WithCheats()
+	_, err = app.Parse([]string{"run"})
+	assert.NoError(t, err)
+	assert.Equal(t, "What's new in tool 1.2.0:\n\nFaster\n\nRun 'tool cheat' for examples\n\n", errs.String())
+}
@@ -0,0 +1,169 @@
+package fisk
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Wizard adds an --interactive flag to the command that prompts for every
+// argument and flag before running the command, the wizard is also started
+// when the command is invoked without any arguments or flags on a terminal
+func (c *CmdClause) Wizard() *CmdClause {
+	c.wizard = true
+	c.Flag("interactive", "Prompt for all arguments and flags").UnNegatableBoolVar(&c.wizardRequested)
+	return c
+}
+
+func (a *Application) maybeRunWizard(context *ParseContext) error {
+	cmd := context.SelectedCommand
+	if cmd == nil || !cmd.wizard {
+		return nil
+	}
+
+	if !cmd.wizardRequested {
+		if !a.term().IsTTY() {
+			return nil
+		}
+
+		for _, element := range context.Elements {
+			switch clause := element.Clause.(type) {
+			case *ArgClause:
+				return nil
+			case *FlagClause:
+				if cmd.flagGroup.long[clause.name] == clause {
+					return nil
+				}
+			}
+		}
+	}
+
+	return a.runWizard(context, a.newPrompter())
+}
+
+func (a *Application) runWizard(context *ParseContext, p *prompter) error {
+	cmd := context.SelectedCommand
+
+	given := map[interface{}]bool{}
+	for _, element := range context.Elements {
+		given[element.Clause] = true
+	}
+
+	for _, arg := range cmd.args {
+		if given[arg] || arg.hidden {
+			continue
+		}
+
+		values, err := wizardAsk(p, "<"+arg.name+">", arg.help, arg.value, arg.defaultValues, arg.consumesRemainder(), arg.secret)
+		if err != nil {
+			return err
+		}
+
+		// answers replace the defaults already set into cumulative values
+		if len(values) > 0 && arg.consumesRemainder() {
+			resetValue(arg.value)
+		}
+
+		for _, v := range values {
+			if err := arg.set(v); err != nil {
+				return fmt.Errorf("%s: %w", arg.name, err)
+			}
+			context.matchedArg(arg, v)
+		}
+	}
+
+	for _, flag := range cmd.flagOrder {
+		if given[flag] || flag.hidden || flag.name == "interactive" {
+			continue
+		}
+		if _, ok := flag.value.(*passwordValue); ok {
+			continue
+		}
+
+		cumulative := false
+		if v, ok := flag.value.(repeatableFlag); ok {
+			cumulative = v.IsCumulative()
+		}
+
+		values, err := wizardAsk(p, "--"+flag.name, flag.help, flag.value, flag.defaultValues, cumulative && !isBoolFlag(flag.value), flag.secret)
+		if err != nil {
+			return err
+		}
+
+		if len(values) > 0 && cumulative {
+			resetValue(flag.value)
+		}
+
+		for _, v := range values {
+			if err := flag.set(context, v); err != nil {
+				return fmt.Errorf("%s: %w", flag.name, err)
+			}
+			flag.isSetByUser()
+			context.matchedFlag(flag, v)
+		}
+	}
+
+	return nil
+}
+
+// wizardAsk asks for the values of a flag or argument, secrets are read without
+// echo and their defaults are not shown
+func wizardAsk(p *prompter, name string, help string, value Value, defaults []string, cumulative bool, secret bool) ([]string, error) {
+	label := name
+	if help != "" {
+		label = fmt.Sprintf("%s (%s)", name, help)
+	}
+
+	def := ""
+	if len(defaults) > 0 && !secret {
+		def = defaults[0]
+	}
+
+	if isBoolFlag(value) {
+		answer, err := p.ask(label+" [y/n]", def)
+		if err != nil {
+			return nil, err
+		}
+
+		switch strings.ToLower(answer) {
+		case "y", "yes", "true":
+			return []string{"true"}, nil
+		case "n", "no", "false":
+			return []string{"false"}, nil
+		}
+
+		return nil, nil
+	}
+
+	var values []string
+	for {
+		var (
+			answer string
+			err    error
+		)
+
+		switch options := enumOptions(value); {
+		case secret:
+			answer, err = p.askSecret(label)
+		case len(options) > 0:
+			answer, err = p.choose(label, options, def)
+		default:
+			answer, err = p.ask(label, def)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// a blank answer keeps the default set before the wizard ran
+		if answer == "" || (answer == def && len(values) == 0) {
+			return values, nil
+		}
+
+		values = append(values, answer)
+		if !cumulative {
+			return values, nil
+		}
+
+		def = ""
+		label = name + " (another value, blank to finish)"
+	}
+}
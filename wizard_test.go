@@ -0,0 +1,93 @@
+package fisk
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWizard(t *testing.T) {
+	app := newTestApp()
+	add := app.Command("add", "").Wizard()
+	name := add.Arg("name", "The stream name").Required().String()
+	storage := add.Flag("storage", "Storage backend").Default("file").Enum("file", "memory")
+	replicas := add.Flag("replicas", "").Default("1").Int()
+	force := add.Flag("force", "").UnNegatableBool()
+	subjects := add.Flag("subjects", "").Strings()
+
+	pc, err := app.ParseContext([]string{"add", "--interactive"})
+	assert.NoError(t, err)
+	assert.NoError(t, app.setDefaults(pc))
+	_, err = app.setValues(pc)
+	assert.NoError(t, err)
+
+	out := bytes.NewBuffer(nil)
+	in := strings.NewReader("ORDERS\n2\n\ny\none\ntwo\n\n")
+	assert.NoError(t, app.runWizard(pc, newPrompter(in, out)))
+
+	assert.Equal(t, "ORDERS", *name)
+	assert.Equal(t, "memory", *storage)
+	assert.Equal(t, 1, *replicas)
+	assert.True(t, *force)
+	assert.Equal(t, []string{"one", "two"}, *subjects)
+	assert.Contains(t, out.String(), "<name> (The stream name): ")
+	assert.Contains(t, out.String(), "  2) memory")
+	assert.NoError(t, app.validateRequired(pc))
+}
+
+func TestWizardNotStartedWithArgs(t *testing.T) {
+	app := newTestApp()
+	add := app.Command("add", "").Wizard()
+	name := add.Arg("name", "").Required().String()
+
+	_, err := app.Parse([]string{"add", "ORDERS"})
+	assert.NoError(t, err)
+	assert.Equal(t, "ORDERS", *name)
+}
+
+func TestWizardSecret(t *testing.T) {
+	app := newTestApp()
+	add := app.Command("add", "").Wizard()
+	token := add.Flag("token", "").Default("s3cret").Secret().String()
+
+	pc, err := app.ParseContext([]string{"add", "--interactive"})
+	assert.NoError(t, err)
+	assert.NoError(t, app.setDefaults(pc))
+	_, err = app.setValues(pc)
+	assert.NoError(t, err)
+
+	var prompted int
+	out := bytes.NewBuffer(nil)
+	p := newPrompter(strings.NewReader(""), out)
+	p.password = func() (string, error) {
+		prompted++
+		return "other", nil
+	}
+
+	assert.NoError(t, app.runWizard(pc, p))
+	assert.Equal(t, 1, prompted)
+	assert.Equal(t, "other", *token)
+	assert.Contains(t, out.String(), "--token: ")
+	assert.NotContains(t, out.String(), "s3cret")
+}
+
+func TestWizardCumulativeDefaults(t *testing.T) {
+	app := newTestApp()
+	add := app.Command("add", "").Wizard()
+	subjects := add.Flag("subjects", "").Default("ORDERS.*").Strings()
+	tags := add.Flag("tags", "").Default("prod").Strings()
+
+	pc, err := app.ParseContext([]string{"add", "--interactive"})
+	assert.NoError(t, err)
+	assert.NoError(t, app.setDefaults(pc))
+	_, err = app.setValues(pc)
+	assert.NoError(t, err)
+
+	in := strings.NewReader("one\ntwo\n\n\n")
+	assert.NoError(t, app.runWizard(pc, newPrompter(in, bytes.NewBuffer(nil))))
+
+	assert.Equal(t, []string{"one", "two"}, *subjects)
+	assert.Equal(t, []string{"prod"}, *tags)
+}
@@ -0,0 +1,235 @@
+package fisk
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// zshCompletion is a generated zsh completion function for the application or a command
+type zshCompletion struct {
+	Function string
+	Root     bool
+	Specs    []string
+	Commands []*zshSubcommand
+}
+
+// zshSubcommand is a command that can be selected from a zshCompletion
+type zshSubcommand struct {
+	Names    string
+	Describe string
+	Function string
+}
+
+var zshFunctionChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// zshCompletions builds the completion functions for the application and all its visible commands
+func (a *Application) zshCompletions() []*zshCompletion {
+	root := "_" + zshFunctionChars.ReplaceAllString(a.Name, "_")
+
+	return zshCompletionsFor(root, root, a.flagGroup.flagOrder, nil, a.argGroup, a.cmdGroup)
+}
+
+func zshCompletionsFor(root string, function string, flags []*FlagClause, inherited []*FlagClause, args *argGroup, cmds *cmdGroup) []*zshCompletion {
+	var specs []string
+	seen := map[string]bool{}
+
+	// own flags take precedence over those inherited from parents
+	for _, flag := range append(append([]*FlagClause{}, flags...), inherited...) {
+		if flag.hidden || seen[flag.name] {
+			continue
+		}
+		seen[flag.name] = true
+		specs = append(specs, zshFlagSpecs(root, flag)...)
+	}
+
+	available := append(append([]*FlagClause{}, flags...), inherited...)
+
+	current := &zshCompletion{Function: function, Root: function == root}
+	result := []*zshCompletion{current}
+
+	var visible []*CmdClause
+	for _, cmd := range cmds.commandOrder {
+		if !cmd.hidden {
+			visible = append(visible, cmd)
+		}
+	}
+
+	if len(visible) > 0 {
+		specs = append(specs, "'1: :->command'", "'*:: :->args'")
+
+		for _, cmd := range visible {
+			sub := function + "_" + zshFunctionChars.ReplaceAllString(cmd.name, "_")
+			names := append([]string{cmd.name}, cmd.aliases...)
+
+			current.Commands = append(current.Commands, &zshSubcommand{
+				Names:    strings.Join(names, "|"),
+				Describe: zshQuote(zshEscape(cmd.name, ":") + ":" + firstLine(cmd.help)),
+				Function: sub,
+			})
+
+			result = append(result, zshCompletionsFor(root, sub, cmd.flagGroup.flagOrder, available, cmd.argGroup, cmd.cmdGroup)...)
+		}
+	} else {
+		specs = append(specs, zshArgSpecs(root, args.args)...)
+	}
+
+	current.Specs = specs
+
+	return result
+}
+
+// zshFlagSpecs are the _arguments specs for a flag
+func zshFlagSpecs(root string, flag *FlagClause) []string {
+	model := flag.Model()
+	help := "[" + zshEscape(firstLine(model.Help), `\[]`) + "]"
+
+	var names []string
+	if flag.shorthand != 0 {
+		names = append(names, "-"+string(flag.shorthand))
+	}
+	for _, short := range flag.shortAliases {
+		names = append(names, "-"+string(short))
+	}
+
+	if model.IsBoolFlag() {
+		names = append(names, "--"+flag.name)
+	} else {
+		for i := range names {
+			names[i] += "+"
+		}
+		names = append(names, "--"+flag.name+"=")
+	}
+
+	var exclusions string
+	if model.IsCumulative() {
+		exclusions = "*"
+	} else if len(names) > 1 {
+		exclusions = "(" + strings.Join(zshOptionNames(names), " ") + ")"
+	}
+
+	description := help
+	if !model.IsBoolFlag() {
+		description += ":" + zshEscape(model.FormatPlaceHolder(), `\:`) + ":" + zshAction(root, flag.value, &flag.completionsMixin)
+	}
+
+	var spec string
+	if len(names) > 1 {
+		spec = zshQuote(exclusions) + "{" + strings.Join(names, ",") + "}" + zshQuote(description)
+	} else {
+		spec = zshQuote(exclusions + names[0] + description)
+	}
+
+	specs := []string{spec}
+	if model.IsNegatable() {
+		specs = append(specs, zshQuote("--no-"+flag.name+help))
+	}
+
+	return specs
+}
+
+// zshArgSpecs are the _arguments specs for the positional arguments of a command
+func zshArgSpecs(root string, args []*ArgClause) []string {
+	var specs []string
+
+	for i, arg := range args {
+		model := arg.Model()
+		if model.Hidden {
+			continue
+		}
+
+		message := model.PlaceHolder
+		if message == "" {
+			message = arg.name
+		}
+		if model.Help != "" {
+			message += " -- " + firstLine(model.Help)
+		}
+
+		position := fmt.Sprintf("%d:", i+1)
+		switch {
+		case model.IsCumulative():
+			position = "*:"
+		case !model.Required:
+			position += ":"
+		}
+
+		specs = append(specs, zshQuote(position+zshEscape(message, `\:`)+":"+zshAction(root, arg.value, &arg.completionsMixin)))
+	}
+
+	return specs
+}
+
+// zshAction completes paths when hinted, enums from their options, values with hints using the application and everything else as files
+func zshAction(root string, value Value, hints *completionsMixin) string {
+	if hints.pathHint != nil {
+		switch {
+		case hints.pathHint.dirs:
+			return "_files -/"
+		case len(hints.pathHint.exts) > 0:
+			return `_files -g "*.(` + strings.Join(hints.pathHint.exts, "|") + `)"`
+		default:
+			return "_files"
+		}
+	}
+
+	if len(hints.hintActions) == 0 {
+		var options []string
+		switch v := value.(type) {
+		case *enumValue:
+			options = v.options
+		case *enumsValue:
+			options = v.options
+		}
+
+		if len(options) > 0 {
+			escaped := make([]string, len(options))
+			for i, option := range options {
+				escaped[i] = zshEscape(option, `\:() `)
+			}
+
+			return "(" + strings.Join(escaped, " ") + ")"
+		}
+	}
+
+	if len(hints.hintActions) > 0 || len(hints.builtinHintActions) > 0 {
+		return root + "_hints"
+	}
+
+	return "_files"
+}
+
+func zshOptionNames(names []string) []string {
+	var out []string
+	for _, name := range names {
+		out = append(out, strings.TrimRight(name, "+="))
+	}
+
+	return out
+}
+
+// zshEscape backslash escapes any of chars in s
+func zshEscape(s string, chars string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(chars, r) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// zshQuote single quotes s for zsh
+func zshQuote(s string) string {
+	if s == "" {
+		return ""
+	}
+
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func firstLine(s string) string {
+	return strings.SplitN(s, "\n", 2)[0]
+}